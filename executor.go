@@ -0,0 +1,39 @@
+package sqlx
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"reflect"
+)
+
+//Executor represents database handle shared by *sql.DB, *sql.Conn and *sql.Tx
+type Executor interface {
+	ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error)
+	PrepareContext(ctx context.Context, query string) (*sql.Stmt, error)
+	QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error)
+	QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row
+}
+
+//TxBeginner represents executor able to start a transaction, i.e. *sql.DB or *sql.Conn
+type TxBeginner interface {
+	BeginTx(ctx context.Context, opts *sql.TxOptions) (*sql.Tx, error)
+}
+
+//DriverType returns executor driver (or driver connection) type, nil if it can not be inferred (i.e. *sql.Tx)
+func DriverType(db Executor) reflect.Type {
+	switch actual := db.(type) {
+	case *sql.DB:
+		return reflect.TypeOf(actual.Driver())
+	case *sql.Conn:
+		var result reflect.Type
+		_ = actual.Raw(func(driverConn interface{}) error {
+			result = reflect.TypeOf(driverConn)
+			return nil
+		})
+		return result
+	case interface{ Driver() driver.Driver }:
+		return reflect.TypeOf(actual.Driver())
+	}
+	return nil
+}
@@ -0,0 +1,100 @@
+package sqlx
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"io"
+	"os"
+	"time"
+)
+
+//Interceptor represents statement execution interceptor, i.e. structured logging, slow query detection or metrics
+type Interceptor interface {
+	//BeforeExec is called before statement execution, returned context is used by execution and AfterExec
+	BeforeExec(ctx context.Context, SQL string, args []interface{}) context.Context
+	//AfterExec is called once statement was executed, result is nil for queries
+	AfterExec(ctx context.Context, SQL string, args []interface{}, result sql.Result, err error, duration time.Duration)
+}
+
+//Interceptors represents interceptor chain, BeforeExec is called in chain order, AfterExec in reverse order
+type Interceptors []Interceptor
+
+//BeforeExec calls chain BeforeExec
+func (c Interceptors) BeforeExec(ctx context.Context, SQL string, args []interface{}) context.Context {
+	for _, interceptor := range c {
+		ctx = interceptor.BeforeExec(ctx, SQL, args)
+	}
+	return ctx
+}
+
+//AfterExec calls chain AfterExec
+func (c Interceptors) AfterExec(ctx context.Context, SQL string, args []interface{}, result sql.Result, err error, duration time.Duration) {
+	for i := len(c) - 1; i >= 0; i-- {
+		c[i].AfterExec(ctx, SQL, args, result, err, duration)
+	}
+}
+
+//Chain returns interceptor calling all non nil interceptors, nil if there is none
+func Chain(interceptors ...Interceptor) Interceptor {
+	var result Interceptors
+	for _, interceptor := range interceptors {
+		switch actual := interceptor.(type) {
+		case nil:
+		case Interceptors:
+			result = append(result, actual...)
+		default:
+			result = append(result, actual)
+		}
+	}
+	switch len(result) {
+	case 0:
+		return nil
+	case 1:
+		return result[0]
+	}
+	return result
+}
+
+//InterceptExec runs exec with interceptor callbacks
+func InterceptExec(ctx context.Context, interceptor Interceptor, SQL string, args []interface{}, exec func(ctx context.Context) (sql.Result, error)) (sql.Result, error) {
+	if interceptor == nil {
+		return exec(ctx)
+	}
+	ctx = interceptor.BeforeExec(ctx, SQL, args)
+	started := time.Now()
+	result, err := exec(ctx)
+	interceptor.AfterExec(ctx, SQL, args, result, err, time.Since(started))
+	return result, err
+}
+
+//InterceptQuery runs query with interceptor callbacks, duration does not include reading rows
+func InterceptQuery(ctx context.Context, interceptor Interceptor, SQL string, args []interface{}, query func(ctx context.Context) (*sql.Rows, error)) (*sql.Rows, error) {
+	if interceptor == nil {
+		return query(ctx)
+	}
+	ctx = interceptor.BeforeExec(ctx, SQL, args)
+	started := time.Now()
+	rows, err := query(ctx)
+	interceptor.AfterExec(ctx, SQL, args, nil, err, time.Since(started))
+	return rows, err
+}
+
+//SQLPrinter represents interceptor printing executed SQL, stdout is used by default
+type SQLPrinter struct {
+	Writer io.Writer
+}
+
+//BeforeExec prints SQL
+func (p *SQLPrinter) BeforeExec(ctx context.Context, SQL string, args []interface{}) context.Context {
+	writer := p.Writer
+	if writer == nil {
+		writer = os.Stdout
+	}
+	_, _ = fmt.Fprintln(writer, SQL)
+	return ctx
+}
+
+//AfterExec does nothing
+func (p *SQLPrinter) AfterExec(ctx context.Context, SQL string, args []interface{}, result sql.Result, err error, duration time.Duration) {
+}
@@ -2,7 +2,7 @@ package config
 
 import (
 	"context"
-	"database/sql"
+	"github.com/viant/sqlx"
 	"github.com/viant/sqlx/metadata"
 	"github.com/viant/sqlx/metadata/info"
 	"github.com/viant/sqlx/metadata/sink"
@@ -10,7 +10,7 @@ import (
 )
 
 //Columns returns table columns
-func Columns(ctx context.Context, session *sink.Session, db *sql.DB, table string, options ...option.Option) ([]sink.Column, error) {
+func Columns(ctx context.Context, session *sink.Session, db sqlx.Executor, table string, options ...option.Option) ([]sink.Column, error) {
 	meta := metadata.New()
 
 	tableColumns := make([]sink.Column, 0)
@@ -2,21 +2,25 @@ package config
 
 import (
 	"context"
-	"database/sql"
+	"github.com/viant/sqlx"
 	"github.com/viant/sqlx/io"
+	"github.com/viant/sqlx/io/telemetry"
 	"github.com/viant/sqlx/metadata/info"
 	"github.com/viant/sqlx/option"
 )
 
 //Config represents general config
 type Config struct {
-	TableName string
-	TagName   string
-	Identity  string
-	Columns   io.Columns
-	Dialect   *info.Dialect
-	Mapper    io.ColumnMapper
-	Builder   io.Builder
+	TableName   string
+	TagName     string
+	Identity    string
+	Columns     io.Columns
+	Dialect     *info.Dialect
+	Mapper      io.ColumnMapper
+	Builder     io.Builder
+	Interceptor sqlx.Interceptor
+	Tracer      option.Tracer
+	Invalidator io.Invalidator
 }
 
 //New creates a  config
@@ -25,7 +29,7 @@ func New(tableName string) *Config {
 }
 
 //ApplyOption applied config option
-func (c *Config) ApplyOption(ctx context.Context, db *sql.DB, options ...option.Option) error {
+func (c *Config) ApplyOption(ctx context.Context, db sqlx.Executor, options ...option.Option) error {
 	for _, opt := range options {
 		switch actual := opt.(type) {
 		case *info.Dialect:
@@ -36,6 +40,8 @@ func (c *Config) ApplyOption(ctx context.Context, db *sql.DB, options ...option.
 			c.Columns = actual
 		case option.Identity:
 			c.Identity = string(actual)
+		case option.Tracer:
+			c.Tracer = actual
 		default:
 			if mapper, ok := opt.(io.ColumnMapper); ok {
 				c.Mapper = mapper
@@ -45,6 +51,14 @@ func (c *Config) ApplyOption(ctx context.Context, db *sql.DB, options ...option.
 				c.Builder = builder
 				continue
 			}
+			if invalidator, ok := opt.(io.Invalidator); ok {
+				c.Invalidator = invalidator
+				continue
+			}
+			if interceptor, ok := opt.(sqlx.Interceptor); ok {
+				c.Interceptor = sqlx.Chain(c.Interceptor, interceptor)
+				continue
+			}
 		}
 	}
 	c.ensureTagName()
@@ -52,13 +66,56 @@ func (c *Config) ApplyOption(ctx context.Context, db *sql.DB, options ...option.
 	return c.ensureDialect(ctx, db)
 }
 
+//InterceptorFor returns config and options interceptors chain, showSQL adds SQL printer
+func (c *Config) InterceptorFor(options []option.Option, showSQL bool) sqlx.Interceptor {
+	var printer sqlx.Interceptor
+	if showSQL {
+		printer = &sqlx.SQLPrinter{}
+	}
+	return sqlx.Chain(printer, c.Interceptor, option.Options(options).Interceptor())
+}
+
+//TracerFor returns options tracer, or config tracer
+func (c *Config) TracerFor(options []option.Option) option.Tracer {
+	if result := option.Options(options).Tracer(); result != nil {
+		return result
+	}
+	return c.Tracer
+}
+
+//InvalidatorFor returns options cache invalidator, or config invalidator
+func (c *Config) InvalidatorFor(options []option.Option) io.Invalidator {
+	var result io.Invalidator
+	if option.Assign(options, &result) {
+		return result
+	}
+	return c.Invalidator
+}
+
+//InvalidateCache invalidates cache entries depending on config table once modification made with transaction is committed,
+//see io.InvalidateOnCommit, entries are not invalidated for transaction managed by caller (io.ErrCallerTransaction),
+//the caller calls Invalidate after commit, failure does not fail the committed modification, it is recorded with telemetry instead
+func (c *Config) InvalidateCache(ctx context.Context, transaction *io.Transaction, options []option.Option) {
+	invalidator := c.InvalidatorFor(options)
+	if invalidator == nil || c.TableName == "" {
+		return
+	}
+	tracer := c.TracerFor(options)
+	_ = io.InvalidateOnCommit(ctx, transaction, func(ctx context.Context) error {
+		ctx, span := telemetry.Start(ctx, tracer, "sqlx.cache.Invalidate", telemetry.Table.String(c.TableName))
+		_, err := invalidator.Invalidate(ctx, c.TableName)
+		telemetry.End(span, err)
+		return nil
+	})
+}
+
 func (c *Config) ensureMapper() {
 	if c.Mapper == nil {
 		c.Mapper = io.StructColumnMapper
 	}
 }
 
-func (c *Config) ensureDialect(ctx context.Context, db *sql.DB) error {
+func (c *Config) ensureDialect(ctx context.Context, db sqlx.Executor) error {
 	if c.Dialect != nil {
 		return nil
 	}
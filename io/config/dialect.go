@@ -2,8 +2,8 @@ package config
 
 import (
 	"context"
-	"database/sql"
 	"fmt"
+	"github.com/viant/sqlx"
 	"github.com/viant/sqlx/metadata"
 	"github.com/viant/sqlx/metadata/info"
 	"github.com/viant/sqlx/metadata/registry"
@@ -11,7 +11,7 @@ import (
 )
 
 //Dialect returns a dialect
-func Dialect(ctx context.Context, db *sql.DB, opts ...option.Option) (*info.Dialect, error) {
+func Dialect(ctx context.Context, db sqlx.Executor, opts ...option.Option) (*info.Dialect, error) {
 	options := option.Options(opts)
 	product := options.Product()
 	if product == nil {
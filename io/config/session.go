@@ -2,7 +2,7 @@ package config
 
 import (
 	"context"
-	"database/sql"
+	"github.com/viant/sqlx"
 	"github.com/viant/sqlx/metadata"
 	"github.com/viant/sqlx/metadata/info"
 	"github.com/viant/sqlx/metadata/sink"
@@ -10,7 +10,7 @@ import (
 )
 
 //Session retrieve basic data from the database connection
-func Session(ctx context.Context, db *sql.DB, options ...option.Option) (*sink.Session, error) {
+func Session(ctx context.Context, db sqlx.Executor, options ...option.Option) (*sink.Session, error) {
 	meta := metadata.New()
 	session := new(sink.Session)
 	err := meta.Info(ctx, db, info.KindSession, session, options...)
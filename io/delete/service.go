@@ -2,7 +2,7 @@ package delete
 
 import (
 	"context"
-	"database/sql"
+	"github.com/viant/sqlx"
 	"github.com/viant/sqlx/io"
 	"github.com/viant/sqlx/io/config"
 	"github.com/viant/sqlx/option"
@@ -15,7 +15,7 @@ type Service struct {
 	*config.Config
 	initSession *session
 	mux         sync.Mutex
-	db          *sql.DB
+	db          sqlx.Executor
 }
 
 //Exec runs delete statements
@@ -31,6 +31,11 @@ func (s *Service) Exec(ctx context.Context, any interface{}, options ...option.O
 	if sess, err = s.ensureSession(record, batchSize); err != nil {
 		return 0, err
 	}
+	sess.interceptor = sess.InterceptorFor(options, showSQL)
+	sess.tracer = sess.TracerFor(options)
+	if sess.version != nil { //versioned records are deleted individually to detect stale records
+		batchSize = 1
+	}
 	if err = sess.begin(ctx, s.db, options); err != nil {
 		return 0, err
 	}
@@ -40,8 +45,10 @@ func (s *Service) Exec(ctx context.Context, any interface{}, options ...option.O
 	}
 
 	rowsAffected, err := sess.delete(ctx, record, recordsFn, batchSize)
-	err = sess.end(err)
-	return rowsAffected, err
+	if err = sess.end(err); err == nil && rowsAffected > 0 {
+		sess.InvalidateCache(ctx, sess.Transaction, options)
+	}
+	return rowsAffected, s.Dialect.NormalizeError(err)
 
 }
 
@@ -56,6 +63,8 @@ func (s *Service) ensureSession(record interface{}, batchSize int) (*session, er
 			Config:        s.Config,
 			binder:        sess.binder,
 			columns:       sess.columns,
+			version:       sess.version,
+			softDelete:    sess.softDelete,
 			transactional: false,
 			db:            sess.db,
 		}, nil
@@ -73,7 +82,7 @@ func (s *Service) ensureSession(record interface{}, batchSize int) (*session, er
 }
 
 //New creates an deleter
-func New(ctx context.Context, db *sql.DB, tableName string, options ...option.Option) (*Service, error) {
+func New(ctx context.Context, db sqlx.Executor, tableName string, options ...option.Option) (*Service, error) {
 	var columnMapper io.ColumnMapper
 	if !option.Assign(options, &columnMapper) {
 		columnMapper = io.StructColumnMapper
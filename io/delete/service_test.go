@@ -3,8 +3,10 @@ package delete_test
 import (
 	"context"
 	"database/sql"
+	"errors"
 	_ "github.com/mattn/go-sqlite3"
 	"github.com/stretchr/testify/assert"
+	"github.com/viant/sqlx/io"
 	"github.com/viant/sqlx/io/delete"
 	_ "github.com/viant/sqlx/metadata/product/sqlite"
 	"github.com/viant/sqlx/option"
@@ -17,6 +19,20 @@ func TestService_Exec(t *testing.T) {
 		Id   int    `sqlx:"name=foo_id,primaryKey=true,generator=autoincrement"`
 		Name string `sqlx:"foo_name"`
 	}
+	type tenantEntity struct {
+		TenantId int    `sqlx:"name=tenant_id,primaryKey=true"`
+		Id       int    `sqlx:"name=foo_id,primaryKey=true"`
+		Name     string `sqlx:"foo_name"`
+	}
+	type versionedEntity struct {
+		Id      int    `sqlx:"name=foo_id,primaryKey=true"`
+		Name    string `sqlx:"foo_name"`
+		Version int    `sqlx:"name=version,version"`
+	}
+	type archivedEntity struct {
+		Id       int  `sqlx:"name=foo_id,primaryKey=true"`
+		Archived bool `sqlx:"name=archived,softDelete"`
+	}
 	var useCases = []struct {
 		description string
 		table       string
@@ -28,6 +44,8 @@ func TestService_Exec(t *testing.T) {
 		expect      interface{}
 		initSQL     []string
 		affected    int64
+		expectStale [][]interface{}
+		remaining   int
 	}{
 		{
 			description: "batch delete ",
@@ -72,6 +90,78 @@ func TestService_Exec(t *testing.T) {
 			},
 			affected: 3,
 		},
+		{
+			description: "batch delete with composite key",
+			driver:      "sqlite3",
+			dsn:         "/tmp/sqllite.db",
+			table:       "t2",
+
+			initSQL: []string{
+				"DROP TABLE IF EXISTS t2",
+				"CREATE TABLE t2 (tenant_id INTEGER, foo_id INTEGER, foo_name TEXT, PRIMARY KEY(tenant_id, foo_id))",
+				"INSERT INTO t2 (tenant_id, foo_id) VALUES(1, 1)",
+				"INSERT INTO t2 (tenant_id, foo_id) VALUES(1, 2)",
+				"INSERT INTO t2 (tenant_id, foo_id) VALUES(2, 1)",
+				"INSERT INTO t2 (tenant_id, foo_id) VALUES(2, 2)",
+			},
+			options: option.Options{
+				option.BatchSize(2),
+			},
+			records: []interface{}{
+				&tenantEntity{TenantId: 1, Id: 1},
+				&tenantEntity{TenantId: 2, Id: 2},
+				&tenantEntity{TenantId: 2, Id: 3},
+			},
+			affected: 2,
+		},
+		{
+			description: "versioned delete with stale record",
+			driver:      "sqlite3",
+			dsn:         "/tmp/sqllite.db",
+			table:       "t3",
+
+			initSQL: []string{
+				"DROP TABLE IF EXISTS t3",
+				"CREATE TABLE t3 (foo_id INTEGER PRIMARY KEY, foo_name TEXT, version INTEGER)",
+				"INSERT INTO t3 (foo_id, version) VALUES(1, 1)",
+				"INSERT INTO t3 (foo_id, version) VALUES(2, 2)",
+				"INSERT INTO t3 (foo_id, version) VALUES(3, 1)",
+			},
+			options: option.Options{
+				option.BatchSize(2),
+			},
+			records: []interface{}{
+				&versionedEntity{Id: 1, Version: 1},
+				&versionedEntity{Id: 2, Version: 1},
+				&versionedEntity{Id: 3, Version: 1},
+			},
+			affected:    0,
+			expectStale: [][]interface{}{{2}},
+		},
+		{
+			description: "soft delete",
+			driver:      "sqlite3",
+			dsn:         "/tmp/sqllite.db",
+			table:       "t4",
+
+			initSQL: []string{
+				"DROP TABLE IF EXISTS t4",
+				"CREATE TABLE t4 (foo_id INTEGER PRIMARY KEY, archived BOOLEAN)",
+				"INSERT INTO t4 (foo_id, archived) VALUES(1, false)",
+				"INSERT INTO t4 (foo_id, archived) VALUES(2, true)",
+				"INSERT INTO t4 (foo_id) VALUES(3)",
+			},
+			options: option.Options{
+				option.BatchSize(2),
+			},
+			records: []interface{}{
+				&archivedEntity{Id: 1},
+				&archivedEntity{Id: 2},
+				&archivedEntity{Id: 3},
+			},
+			affected:  2,
+			remaining: 3,
+		},
 	}
 
 outer:
@@ -99,8 +189,21 @@ outer:
 			continue
 		}
 		affected, err := deleter.Exec(context.TODO(), testCase.records, testCase.options...)
-		assert.Nil(t, err, testCase.description)
 		assert.EqualValues(t, testCase.affected, affected, testCase.description)
+		if len(testCase.expectStale) > 0 {
+			staleErr := &io.StaleRecordError{}
+			if assert.True(t, errors.As(err, &staleErr), testCase.description) {
+				assert.EqualValues(t, testCase.expectStale, staleErr.Identities, testCase.description)
+			}
+			continue
+		}
+		assert.Nil(t, err, testCase.description)
+		if testCase.remaining > 0 {
+			var remaining int
+			err = db.QueryRow("SELECT COUNT(*) FROM " + testCase.table).Scan(&remaining)
+			assert.Nil(t, err, testCase.description)
+			assert.EqualValues(t, testCase.remaining, remaining, testCase.description)
+		}
 	}
 
 }
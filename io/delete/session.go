@@ -4,8 +4,10 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
+	"github.com/viant/sqlx"
 	"github.com/viant/sqlx/io"
 	"github.com/viant/sqlx/io/config"
+	"github.com/viant/sqlx/io/telemetry"
 	"github.com/viant/sqlx/option"
 	"reflect"
 )
@@ -17,9 +19,14 @@ type session struct {
 	*config.Config
 	binder        io.PlaceholderBinder
 	columns       io.Columns
+	version       *io.Version
+	softDelete    *io.SoftDelete
 	transactional bool
-	db            *sql.DB
+	db            sqlx.Executor
 	stmt          *sql.Stmt
+	SQL           string //prepared statement SQL
+	interceptor   sqlx.Interceptor
+	tracer        option.Tracer
 }
 
 func (s *session) init(record interface{}) (err error) {
@@ -30,7 +37,16 @@ func (s *session) init(record interface{}) (err error) {
 	if s.columns, s.binder, err = s.Mapper(record, s.TagName, option.IdentityOnly(true)); err != nil {
 		return err
 	}
-	recordlessBuilder, err := NewBuilder(s.TableName, s.columns.Names(), s.Dialect, s.batchSize)
+	s.version = s.columns.Version()
+	recordColumns, _, err := s.Mapper(record, s.TagName)
+	if err != nil {
+		return err
+	}
+	var builderOptions []option.Option
+	if s.softDelete = io.Columns(recordColumns).SoftDelete(); s.softDelete != nil {
+		builderOptions = append(builderOptions, s.softDelete)
+	}
+	recordlessBuilder, err := NewBuilder(s.TableName, s.columns.Names(), s.Dialect, s.batchSize, builderOptions...)
 	if err != nil {
 		return err
 	}
@@ -39,7 +55,7 @@ func (s *session) init(record interface{}) (err error) {
 	return err
 }
 
-func (s *session) begin(ctx context.Context, db *sql.DB, options []option.Option) error {
+func (s *session) begin(ctx context.Context, db sqlx.Executor, options []option.Option) error {
 	var err error
 	s.Transaction, err = io.TransactionFor(ctx, s.Dialect, db, options)
 	if err != nil {
@@ -57,9 +73,7 @@ func (s *session) prepare(ctx context.Context, batchSize int) error {
 			return fmt.Errorf("failed to close stetement: %w", err)
 		}
 	}
-	if showSQL {
-		fmt.Println(SQL)
-	}
+	s.SQL = SQL
 	if s.Transaction != nil {
 		s.stmt, err = s.Transaction.Prepare(SQL)
 		return err
@@ -72,7 +86,7 @@ func (s *session) delete(ctx context.Context, record interface{}, recordsFn func
 	var recValues = make([]interface{}, batchSize*len(s.columns))
 	totalRowsAffected := int64(0)
 	inBatchCount := 0
-
+	var stale *io.StaleRecordError
 	for ; record != nil; record = recordsFn() {
 		offset := inBatchCount * len(s.columns)
 		s.binder(record, recValues[offset:], 0, len(s.columns))
@@ -82,19 +96,28 @@ func (s *session) delete(ctx context.Context, record interface{}, recordsFn func
 			if err != nil {
 				return 0, err
 			}
+			if s.version != nil && rowsAffected < int64(inBatchCount) { //versioned records are deleted individually
+				if stale == nil {
+					stale = &io.StaleRecordError{Table: s.TableName}
+				}
+				stale.Append(recValues[s.columns.PrimaryKeys():])
+			}
 			totalRowsAffected += rowsAffected
 			inBatchCount = 0
 		}
 	}
+	if stale != nil {
+		return 0, stale
+	}
 
 	if inBatchCount > 0 { //overflow
 		err := s.prepare(ctx, inBatchCount)
 		if err != nil {
-			return 0, nil
+			return 0, err
 		}
 		rowsAffected, err := s.flush(ctx, recValues[0:inBatchCount*len(s.columns)])
 		if err != nil {
-			return 0, nil
+			return 0, err
 		}
 		totalRowsAffected += rowsAffected
 	}
@@ -119,8 +142,20 @@ func (s *session) end(err error) error {
 	return s.Transaction.Commit()
 }
 
-func (s *session) flush(ctx context.Context, values []interface{}) (int64, error) {
-	result, err := s.stmt.ExecContext(ctx, values...)
+//flush deletes batched records within telemetry span
+func (s *session) flush(ctx context.Context, values []interface{}) (rowsAffected int64, err error) {
+	ctx, span := telemetry.Start(ctx, s.tracer, "sqlx.delete.flush", telemetry.Table.String(s.TableName), telemetry.BatchSize.Int(len(values)/len(s.columns)))
+	defer func() { telemetry.End(span, err, telemetry.RowsAffected.Int64(rowsAffected)) }()
+	return s.flushValues(ctx, values)
+}
+
+func (s *session) flushValues(ctx context.Context, values []interface{}) (int64, error) {
+	if s.softDelete != nil {
+		values = append([]interface{}{s.softDelete.Value()}, values...)
+	}
+	result, err := sqlx.InterceptExec(ctx, s.interceptor, s.SQL, values, func(ctx context.Context) (sql.Result, error) {
+		return s.stmt.ExecContext(ctx, values...)
+	})
 	if err != nil {
 		return 0, err
 	}
@@ -2,6 +2,7 @@ package delete
 
 import (
 	"fmt"
+	"github.com/viant/sqlx/io"
 	"github.com/viant/sqlx/metadata/info"
 	"github.com/viant/sqlx/option"
 	"strings"
@@ -10,16 +11,19 @@ import (
 const (
 	columnSeparator = ","
 	inFragment      = " IN ("
+	orFragment      = " OR "
+	andFragment     = " AND "
 )
 
 //Builder represent delete DML builder
 type (
 	Builder struct {
-		id           string
-		batchSize    int
-		sql          string
-		valueSize    int
-		valuesOffset int
+		table      string
+		columns    []string
+		dialect    *info.Dialect
+		softDelete *io.SoftDelete
+		batchSize  int
+		sql        string
 	}
 )
 
@@ -29,57 +33,114 @@ func (b *Builder) Build(options ...option.Option) string {
 	if batchSize == b.batchSize {
 		return b.sql
 	}
-	limit := b.valuesOffset + (batchSize * b.valueSize) + (batchSize - 1)
-	result := make([]byte, limit+1)
-	copy(result, b.sql[:limit])
-	result[limit] = ')'
-	return string(result)
+	return b.build(batchSize)
 }
 
-//NewBuilder return insert builder
-func NewBuilder(table string, columns []string, dialect *info.Dialect, batchSize int) (*Builder, error) {
-	if len(columns) == 0 {
-		return nil, fmt.Errorf("columns were empty")
+//build builds DELETE FROM t WHERE (c1,c2) IN ((?,?)) statement, dialects without row value IN use (c1 = ? AND c2 = ?) OR ... criteria,
+//soft delete builds UPDATE t SET marker = ? WHERE (c1,c2) IN ((?,?)) AND <not deleted> statement
+func (b *Builder) build(batchSize int) string {
+	getter := b.dialect.PlaceholderGetter()
+	sb := strings.Builder{}
+	if b.softDelete == nil {
+		sb.WriteString("DELETE FROM ")
+		sb.WriteString(b.table)
+		sb.WriteString(" WHERE ")
+		b.writeCriteria(&sb, batchSize, getter)
+		return sb.String()
+	}
+	sb.WriteString("UPDATE ")
+	sb.WriteString(b.table)
+	sb.WriteString(" SET ")
+	sb.WriteString(b.softDelete.Name)
+	sb.WriteString(" = ")
+	sb.WriteString(getter())
+	sb.WriteString(" WHERE ")
+	orChain := len(b.columns) > 1 && b.dialect.NoRowValueIn && batchSize > 1
+	if orChain {
+		sb.WriteString("(")
+	}
+	b.writeCriteria(&sb, batchSize, getter)
+	if orChain {
+		sb.WriteString(")")
+	}
+	sb.WriteString(andFragment)
+	sb.WriteString(b.softDelete.Criterion())
+	return sb.String()
+}
+
+func (b *Builder) writeCriteria(sb *strings.Builder, batchSize int, getter func() string) {
+	if len(b.columns) > 1 && b.dialect.NoRowValueIn {
+		for i := 0; i < batchSize; i++ {
+			if i > 0 {
+				sb.WriteString(orFragment)
+			}
+			sb.WriteString("(")
+			for k, column := range b.columns {
+				if k > 0 {
+					sb.WriteString(andFragment)
+				}
+				sb.WriteString(column)
+				sb.WriteString(" = ")
+				sb.WriteString(getter())
+			}
+			sb.WriteString(")")
+		}
+		return
 	}
-	getter := dialect.PlaceholderGetter()
-	multiColumn := len(columns) > 1
-	leftOp := strings.Join(columns, ",")
+	multiColumn := len(b.columns) > 1
 	if multiColumn {
-		leftOp = "(" + leftOp + ")"
+		sb.WriteString("(")
 	}
-	rightOp := strings.Builder{}
-	itemSize := 0
+	sb.WriteString(strings.Join(b.columns, columnSeparator))
+	if multiColumn {
+		sb.WriteString(")")
+	}
+	sb.WriteString(inFragment)
 	for i := 0; i < batchSize; i++ {
 		if i > 0 {
-			rightOp.WriteString(columnSeparator)
+			sb.WriteString(columnSeparator)
 		}
 		if multiColumn {
-			rightOp.WriteString("(")
+			sb.WriteString("(")
 		}
-		for k := 0; k < len(columns); k++ {
+		for k := 0; k < len(b.columns); k++ {
 			if k > 0 {
-				rightOp.WriteString(columnSeparator)
+				sb.WriteString(columnSeparator)
 			}
-			rightOp.WriteString(getter())
+			sb.WriteString(getter())
 		}
 		if multiColumn {
-			rightOp.WriteString(")")
-		}
-		if i == 0 {
-			itemSize = rightOp.Len()
+			sb.WriteString(")")
 		}
 	}
+	sb.WriteString(")")
+}
+
+//NewBuilder return insert builder, *io.SoftDelete option turns delete into soft delete update
+func NewBuilder(table string, columns []string, dialect *info.Dialect, batchSize int, options ...option.Option) (*Builder, error) {
+	if len(columns) == 0 {
+		return nil, fmt.Errorf("columns were empty")
+	}
 	result := &Builder{
-		valueSize: itemSize,
+		table:     table,
+		columns:   columns,
+		dialect:   dialect,
 		batchSize: batchSize,
-		sql:       "DELETE FROM " + table + " WHERE " + leftOp + inFragment + rightOp.String() + ")",
 	}
-	result.valuesOffset = strings.Index(result.sql, inFragment) + len(inFragment)
+	for _, anOption := range options {
+		if softDelete, ok := anOption.(*io.SoftDelete); ok {
+			result.softDelete = softDelete
+		}
+	}
+	result.sql = result.build(batchSize)
 	return result, nil
 }
 
 var showSQL bool
 
+//ShowSQL prints executed SQL to stdout
+//
+//Deprecated: use sqlx.SQLPrinter or any other sqlx.Interceptor option
 func ShowSQL(b bool) {
 	showSQL = b
 }
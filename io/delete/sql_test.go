@@ -2,7 +2,9 @@ package delete
 
 import (
 	"github.com/stretchr/testify/assert"
+	"github.com/viant/sqlx/io"
 	"github.com/viant/sqlx/metadata/info"
+	"github.com/viant/sqlx/metadata/product/pg"
 	"github.com/viant/sqlx/option"
 	"testing"
 )
@@ -16,6 +18,7 @@ func TestUpdate_Build(t *testing.T) {
 		dialect          *info.Dialect
 		batchSize        int
 		builderBatchSize int
+		options          []option.Option
 		expect           string
 	}{
 		{
@@ -62,10 +65,60 @@ func TestUpdate_Build(t *testing.T) {
 			},
 			expect: "DELETE FROM foo WHERE (c1,c2) IN ((?,?),(?,?))",
 		},
+		{
+			description:      "delete with two column without row value IN",
+			table:            "foo",
+			columns:          []string{"c1", "c2"},
+			batchSize:        2,
+			builderBatchSize: 3,
+			dialect: &info.Dialect{
+				Placeholder:  "?",
+				NoRowValueIn: true,
+			},
+			expect: "DELETE FROM foo WHERE (c1 = ? AND c2 = ?) OR (c1 = ? AND c2 = ?)",
+		},
+		{
+			description:      "delete with two column and numbered placeholders",
+			table:            "foo",
+			columns:          []string{"c1", "c2"},
+			batchSize:        5,
+			builderBatchSize: 6,
+			dialect: &info.Dialect{
+				Placeholder:         "$",
+				PlaceholderResolver: &pg.PlaceholderGenerator{},
+			},
+			expect: "DELETE FROM foo WHERE (c1,c2) IN (($1,$2),($3,$4),($5,$6),($7,$8),($9,$10))",
+		},
+		{
+			description:      "soft delete",
+			table:            "foo",
+			columns:          []string{"cId"},
+			batchSize:        2,
+			builderBatchSize: 2,
+			dialect: &info.Dialect{
+				Placeholder:         "$",
+				PlaceholderResolver: &pg.PlaceholderGenerator{},
+			},
+			options: []option.Option{&io.SoftDelete{Name: "deleted_at", IsTime: true}},
+			expect:  "UPDATE foo SET deleted_at = $1 WHERE cId IN ($2,$3) AND deleted_at IS NULL",
+		},
+		{
+			description:      "soft delete with two column without row value IN",
+			table:            "foo",
+			columns:          []string{"c1", "c2"},
+			batchSize:        2,
+			builderBatchSize: 2,
+			dialect: &info.Dialect{
+				Placeholder:  "?",
+				NoRowValueIn: true,
+			},
+			options: []option.Option{&io.SoftDelete{Name: "archived", IsBool: true}},
+			expect:  "UPDATE foo SET archived = ? WHERE ((c1 = ? AND c2 = ?) OR (c1 = ? AND c2 = ?)) AND (archived IS NULL OR archived = FALSE)",
+		},
 	}
 
 	for _, testCase := range testCases {
-		builder, err := NewBuilder(testCase.table, testCase.columns, testCase.dialect, testCase.builderBatchSize)
+		builder, err := NewBuilder(testCase.table, testCase.columns, testCase.dialect, testCase.builderBatchSize, testCase.options...)
 		assert.Nil(t, err, testCase.description)
 		actual := builder.Build(option.BatchSize(testCase.batchSize))
 		assert.EqualValues(t, testCase.expect, actual, testCase.description)
@@ -1,5 +1,33 @@
 package io
 
+import (
+	"fmt"
+	"reflect"
+)
+
+// StaleRecordError represents optimistic locking conflict, listed records were modified or removed by another transaction
+type StaleRecordError struct {
+	Table      string
+	Identities [][]interface{}
+}
+
+// Append appends record identity values, pointers are dereferenced
+func (e *StaleRecordError) Append(identity []interface{}) {
+	var values = make([]interface{}, len(identity))
+	for i, value := range identity {
+		values[i] = value
+		if v := reflect.ValueOf(value); v.Kind() == reflect.Ptr && !v.IsNil() {
+			values[i] = v.Elem().Interface()
+		}
+	}
+	e.Identities = append(e.Identities, values)
+}
+
+// Error returns error message
+func (e *StaleRecordError) Error() string {
+	return fmt.Sprintf("stale %v records: %v", e.Table, e.Identities)
+}
+
 // RunWithError sets err as error from passed function
 // used i.e. with deffer
 func RunWithError(fn func() error, err *error) {
@@ -0,0 +1,102 @@
+package errors
+
+import "errors"
+
+//Cause represents normalized database error, it wraps driver error and exposes constraint, table and column if known
+type Cause struct {
+	Constraint string
+	Table      string
+	Column     string
+	Err        error
+}
+
+//Error returns driver error message
+func (c *Cause) Error() string {
+	return c.Err.Error()
+}
+
+//Unwrap returns driver error
+func (c *Cause) Unwrap() error {
+	return c.Err
+}
+
+func (c *Cause) normalized() {}
+
+type (
+	//UniqueViolation represents unique or primary key constraint violation
+	UniqueViolation struct{ Cause }
+	//ForeignKeyViolation represents foreign key constraint violation
+	ForeignKeyViolation struct{ Cause }
+	//NotNullViolation represents not null constraint violation
+	NotNullViolation struct{ Cause }
+	//CheckViolation represents check constraint violation
+	CheckViolation struct{ Cause }
+	//Deadlock represents transaction chosen as deadlock victim
+	Deadlock struct{ Cause }
+	//Timeout represents lock wait or statement timeout
+	Timeout struct{ Cause }
+)
+
+//Kind represents database error kind
+type Kind int
+
+const (
+	//KindUnknown defines unrecognized error
+	KindUnknown = Kind(iota)
+	//KindUniqueViolation defines unique constraint violation
+	KindUniqueViolation
+	//KindForeignKeyViolation defines foreign key constraint violation
+	KindForeignKeyViolation
+	//KindNotNullViolation defines not null constraint violation
+	KindNotNullViolation
+	//KindCheckViolation defines check constraint violation
+	KindCheckViolation
+	//KindDeadlock defines deadlock
+	KindDeadlock
+	//KindTimeout defines lock wait or statement timeout
+	KindTimeout
+)
+
+//New returns typed error for supplied kind, cause is returned unchanged for unknown kind
+func New(kind Kind, cause *Cause) error {
+	switch kind {
+	case KindUniqueViolation:
+		return &UniqueViolation{Cause: *cause}
+	case KindForeignKeyViolation:
+		return &ForeignKeyViolation{Cause: *cause}
+	case KindNotNullViolation:
+		return &NotNullViolation{Cause: *cause}
+	case KindCheckViolation:
+		return &CheckViolation{Cause: *cause}
+	case KindDeadlock:
+		return &Deadlock{Cause: *cause}
+	case KindTimeout:
+		return &Timeout{Cause: *cause}
+	}
+	return cause.Err
+}
+
+//StateKind returns error kind for SQLSTATE code
+func StateKind(state string) Kind {
+	switch state {
+	case "23505":
+		return KindUniqueViolation
+	case "23503":
+		return KindForeignKeyViolation
+	case "23502":
+		return KindNotNullViolation
+	case "23514":
+		return KindCheckViolation
+	case "40P01":
+		return KindDeadlock
+	case "57014", "55P03":
+		return KindTimeout
+	}
+	return KindUnknown
+}
+
+//IsNormalized returns true if err has been already normalized
+func IsNormalized(err error) bool {
+	var cause interface{ normalized() }
+	return errors.As(err, &cause)
+}
@@ -0,0 +1,45 @@
+package errors
+
+import (
+	"errors"
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestNew(t *testing.T) {
+	driverErr := fmt.Errorf("driver error")
+	var testCases = []struct {
+		description string
+		state       string
+		expect      interface{}
+	}{
+		{description: "unique violation", state: "23505", expect: &UniqueViolation{}},
+		{description: "foreign key violation", state: "23503", expect: &ForeignKeyViolation{}},
+		{description: "not null violation", state: "23502", expect: &NotNullViolation{}},
+		{description: "check violation", state: "23514", expect: &CheckViolation{}},
+		{description: "deadlock", state: "40P01", expect: &Deadlock{}},
+		{description: "timeout", state: "57014", expect: &Timeout{}},
+		{description: "unknown", state: "42601"},
+	}
+
+	for _, testCase := range testCases {
+		err := New(StateKind(testCase.state), &Cause{Table: "foo", Err: driverErr})
+		assert.True(t, errors.Is(err, driverErr), testCase.description)
+		assert.Equal(t, driverErr.Error(), err.Error(), testCase.description)
+		if testCase.expect == nil {
+			assert.Equal(t, driverErr, err, testCase.description)
+			assert.False(t, IsNormalized(err), testCase.description)
+			continue
+		}
+		assert.IsType(t, testCase.expect, err, testCase.description)
+		assert.True(t, IsNormalized(fmt.Errorf("wrapped: %w", err)), testCase.description)
+	}
+
+	var unique *UniqueViolation
+	if assert.True(t, errors.As(New(KindUniqueViolation, &Cause{Constraint: "uq_name", Table: "foo", Column: "name", Err: driverErr}), &unique)) {
+		assert.Equal(t, "uq_name", unique.Constraint)
+		assert.Equal(t, "foo", unique.Table)
+		assert.Equal(t, "name", unique.Column)
+	}
+}
@@ -0,0 +1,50 @@
+package batcher
+
+import (
+	"context"
+	"github.com/viant/sqlx/option"
+	"time"
+)
+
+//metrics represents batcher instruments, nil metrics does not record anything
+type metrics struct {
+	queueDepth   option.Counter
+	flushLatency option.Histogram
+	fillRatio    option.Histogram
+}
+
+//collected records collected record
+func (m *metrics) collected(ctx context.Context) {
+	if m == nil {
+		return
+	}
+	m.queueDepth.Add(ctx, 1)
+}
+
+//flushed records flushed batch
+func (m *metrics) flushed(ctx context.Context, size int, maxElements int, latency time.Duration) {
+	if m == nil {
+		return
+	}
+	m.queueDepth.Add(ctx, -int64(size))
+	m.flushLatency.Record(ctx, latency.Seconds())
+	m.fillRatio.Record(ctx, float64(size)/float64(maxElements))
+}
+
+func newMetrics(meter option.Meter) (*metrics, error) {
+	if meter == nil {
+		return nil, nil
+	}
+	var err error
+	result := &metrics{}
+	if result.queueDepth, err = meter.Counter("sqlx.batcher.queue.depth", "Records collected and not yet flushed", "{record}"); err != nil {
+		return nil, err
+	}
+	if result.flushLatency, err = meter.Histogram("sqlx.batcher.flush.duration", "Batch flush latency", "s"); err != nil {
+		return nil, err
+	}
+	if result.fillRatio, err = meter.Histogram("sqlx.batcher.batch.fill", "Flushed batch records to MaxElements ratio", "1"); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
@@ -27,6 +27,7 @@ type Service struct {
 	mux        sync.Mutex
 	ctx        context.Context
 	isWatching int32
+	metrics    *metrics
 }
 
 // Config represents batcher's config
@@ -34,6 +35,8 @@ type Config struct {
 	MaxElements   int
 	MaxDurationMs int
 	BatchSize     int
+	Tracer        option.Tracer //optional inserter flush spans
+	Meter         option.Meter  //optional queue depth, flush latency and batch fill ratio metrics
 }
 
 // CanFlush checks possibility of flushing batch
@@ -65,6 +68,7 @@ func (s *Service) Collect(recPtr interface{}) (*State, error) {
 	defer s.mux.Unlock()
 	batch := s.getActiveBatch()
 	batch.collection.Append(recPtr)
+	s.metrics.collected(s.ctx)
 	return batch.state, nil
 }
 
@@ -99,7 +103,14 @@ func (s *Service) checkBatchExpiry() bool {
 
 func (s *Service) tryFlush(aBatch *Batch) {
 	if atomic.CompareAndSwapInt32(&aBatch.flushed, 0, 1) {
-		_, _, err := s.inserter.Exec(s.ctx, aBatch.collection.newSlice, option.BatchSize(s.config.BatchSize))
+		options := []option.Option{option.BatchSize(s.config.BatchSize)}
+		if s.config.Tracer != nil {
+			options = append(options, s.config.Tracer)
+		}
+		size := aBatch.collection.Len()
+		started := time.Now()
+		_, _, err := s.inserter.Exec(s.ctx, aBatch.collection.newSlice, options...)
+		s.metrics.flushed(s.ctx, size, s.config.MaxElements, time.Since(started))
 		aBatch.state.err = err
 		s.batchPool.Put(aBatch)
 	}
@@ -127,6 +138,11 @@ func New(ctx context.Context, inserter *insert.Service, rType reflect.Type, conf
 		config.BatchSize = defaultBatchSize
 	}
 
+	batcherMetrics, err := newMetrics(config.Meter)
+	if err != nil {
+		return nil, err
+	}
+
 	provider := func() interface{} {
 		return NewBatch(rType, config.MaxElements, config.MaxDurationMs)
 	}
@@ -137,6 +153,7 @@ func New(ctx context.Context, inserter *insert.Service, rType reflect.Type, conf
 		config:     config,
 		batchPool:  newPool(provider),
 		ctx:        ctx,
+		metrics:    batcherMetrics,
 	}
 
 	return service, nil
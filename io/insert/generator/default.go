@@ -2,7 +2,7 @@ package generator
 
 import (
 	"context"
-	"database/sql"
+	"github.com/viant/sqlx"
 	"github.com/viant/sqlx/io"
 	"github.com/viant/sqlx/io/config"
 	"github.com/viant/sqlx/io/read"
@@ -20,14 +20,14 @@ import (
 type Default struct {
 	builder     *Builder
 	dialect     *info.Dialect
-	db          *sql.DB
+	db          sqlx.Executor
 	session     *sink.Session
 	queryMapper read.RowMapper
 	columns     []sink.Column
 }
 
 // NewDefault creates a default generator
-func NewDefault(ctx context.Context, dialect *info.Dialect, db *sql.DB, session *sink.Session) (*Default, error) {
+func NewDefault(ctx context.Context, dialect *info.Dialect, db sqlx.Executor, session *sink.Session) (*Default, error) {
 	if session == nil {
 		var err error
 		if session, err = config.Session(ctx, db, dialect); err != nil {
@@ -0,0 +1,93 @@
+package insert
+
+import (
+	"fmt"
+	"github.com/viant/sqlx/io"
+	"reflect"
+	"strings"
+)
+
+type (
+	//returning represents database generated columns written back to inserted records
+	returning struct {
+		columns   []string
+		positions []int
+		binder    io.PlaceholderBinder
+		size      int
+	}
+
+	//returnedRow represents row returned by insert statement
+	returnedRow struct {
+		id        int64
+		generated []interface{}
+		keys      []interface{}
+	}
+)
+
+//targets returns record generated column field addresses
+func (r *returning) targets(record interface{}) []interface{} {
+	var values = make([]interface{}, r.size)
+	r.binder(record, values, 0, r.size)
+	var result = make([]interface{}, len(r.positions))
+	for i, position := range r.positions {
+		result[i] = values[position]
+	}
+	return result
+}
+
+//newReturning returns generated columns returning or nil if record has no generated column
+func newReturning(columns io.Columns, binder io.PlaceholderBinder) *returning {
+	var result *returning
+	for i, column := range columns {
+		if tag := column.Tag(); tag == nil || !tag.Generated || io.IsIdentityColumn(column) {
+			continue
+		}
+		if result == nil {
+			result = &returning{binder: binder, size: len(columns)}
+		}
+		result.columns = append(result.columns, column.Name())
+		result.positions = append(result.positions, i)
+	}
+	return result
+}
+
+//newValues returns new values with the same types as supplied pointers
+func newValues(pointers []interface{}) []interface{} {
+	var result = make([]interface{}, len(pointers))
+	for i, pointer := range pointers {
+		result[i] = newValue(pointer)
+	}
+	return result
+}
+
+//newValue returns pointer to new value with the same type as supplied pointer
+func newValue(pointer interface{}) interface{} {
+	if pointer == nil || reflect.TypeOf(pointer).Kind() != reflect.Ptr {
+		return new(interface{})
+	}
+	return reflect.New(reflect.TypeOf(pointer).Elem()).Interface()
+}
+
+//setValues copies values to supplied pointers
+func setValues(pointers []interface{}, values []interface{}) {
+	for i, pointer := range pointers {
+		reflect.ValueOf(pointer).Elem().Set(reflect.ValueOf(values[i]).Elem())
+	}
+}
+
+//recordKey returns key of dereferenced values
+func recordKey(values []interface{}) string {
+	var result = make([]string, len(values))
+	for i, value := range values {
+		aValue := reflect.ValueOf(value)
+		for aValue.Kind() == reflect.Ptr && !aValue.IsNil() {
+			aValue = aValue.Elem()
+		}
+		if aValue.Kind() == reflect.Ptr || !aValue.IsValid() {
+			result[i] = "null"
+			continue
+		}
+		result[i] = fmt.Sprintf("%v", aValue.Interface())
+	}
+	return strings.Join(result, "/")
+}
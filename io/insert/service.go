@@ -2,8 +2,8 @@ package insert
 
 import (
 	"context"
-	"database/sql"
 	"fmt"
+	"github.com/viant/sqlx"
 	"github.com/viant/sqlx/io"
 	"github.com/viant/sqlx/io/config"
 	"github.com/viant/sqlx/io/insert/generator"
@@ -19,11 +19,11 @@ type Service struct {
 	options       []option.Option
 	cachedSession *session // The session is for caching only, never use it directly
 	mux           sync.Mutex
-	db            *sql.DB
+	db            sqlx.Executor
 }
 
 //New creates an inserter service
-func New(ctx context.Context, db *sql.DB, tableName string, options ...option.Option) (*Service, error) {
+func New(ctx context.Context, db sqlx.Executor, tableName string, options ...option.Option) (*Service, error) {
 	var columnMapper io.ColumnMapper
 	if !option.Assign(options, &columnMapper) {
 		columnMapper = io.StructColumnMapper
@@ -48,7 +48,10 @@ func (s *Service) NextSequence(ctx context.Context, any interface{}, recordCount
 
 	batchSize := option.Options(options).BatchSize()
 	record := valueAt(0)
-	db := option.Options(options).Db()
+	var db sqlx.Executor
+	if optionDb := option.Options(options).Db(); optionDb != nil {
+		db = optionDb
+	}
 	sess, err := s.NewSession(ctx, record, db, batchSize)
 	if err != nil {
 		return nil, err
@@ -89,15 +92,17 @@ func (s *Service) Exec(ctx context.Context, any interface{}, options ...option.O
 	if record == nil {
 		return 0, 0, fmt.Errorf("invalid record/s %T %v", any, any)
 	}
-	db := option.Options(options).Db()
-	if db == nil {
-		db = s.db
+	var db sqlx.Executor = s.db
+	if optionDb := option.Options(options).Db(); optionDb != nil {
+		db = optionDb
 	}
 
 	sess, err := s.NewSession(ctx, record, db, batchSize)
 	if err != nil {
 		return 0, 0, err
 	}
+	sess.interceptor = sess.InterceptorFor(options, showSQL)
+	sess.tracer = sess.TracerFor(options)
 
 	for _, updater := range sess.recordUpdaters {
 		updaterOpts, err := updater.prepare(ctx, options, sess, valueAt, recordCount)
@@ -121,22 +126,25 @@ func (s *Service) Exec(ctx context.Context, any interface{}, options ...option.O
 		return 0, 0, err
 	}
 
-	if err = sess.begin(ctx, sess.db, options); err != nil {
-		return 0, 0, err
+	retry := option.Options(options).Retry()
+	var restoreIdentities func()
+	if retry != nil {
+		restoreIdentities = sess.identitiesSnapshot(valueAt, recordCount)
 	}
-
-	if err = sess.prepare(ctx, record, batchSize); err != nil {
-		err = sess.end(err)
-		return 0, 0, err
+	for attempt := 1; ; attempt++ {
+		rowsAffected, lastInsertedID, err := sess.exec(ctx, record, batchRecordBuffer, valueAt, recordCount, identities, batchSize, options)
+		if err == nil || !io.ShallRetry(ctx, retry, sess.Dialect, sess.Transaction, attempt, err) {
+			if err == nil && rowsAffected > 0 {
+				sess.InvalidateCache(ctx, sess.Transaction, options)
+			}
+			return rowsAffected, lastInsertedID, sess.Dialect.NormalizeError(err)
+		}
+		restoreIdentities()
 	}
-
-	rowsAffected, lastInsertedID, err := sess.insert(ctx, batchRecordBuffer, valueAt, recordCount, identities)
-	err = sess.end(err)
-	return rowsAffected, lastInsertedID, err
 }
 
 // NewSession creates a new session
-func (s *Service) NewSession(ctx context.Context, record interface{}, db *sql.DB, batchSize int) (*session, error) {
+func (s *Service) NewSession(ctx context.Context, record interface{}, db sqlx.Executor, batchSize int) (*session, error) {
 	s.mux.Lock()
 	defer s.mux.Unlock()
 	rType := reflect.TypeOf(record)
@@ -146,6 +154,9 @@ func (s *Service) NewSession(ctx context.Context, record interface{}, db *sql.DB
 		}
 		return &session{
 			recordUpdaters: s.cachedSession.recordUpdaters,
+			identity:       sess.identity,
+			returning:      sess.returning,
+			keys:           sess.keys,
 			rType:          rType,
 			Config:         sess.Config,
 			binder:         sess.binder,
@@ -156,7 +167,7 @@ func (s *Service) NewSession(ctx context.Context, record interface{}, db *sql.DB
 		}, nil
 	}
 
-	aDialect, err := config.Dialect(ctx, s.db)
+	aDialect, err := config.Dialect(ctx, s.db, s.options...)
 	if err != nil {
 		return nil, err
 	}
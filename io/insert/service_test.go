@@ -3,13 +3,26 @@ package insert_test
 import (
 	"context"
 	"database/sql"
-	_ "github.com/mattn/go-sqlite3"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"github.com/mattn/go-sqlite3"
 	"github.com/stretchr/testify/assert"
+	"github.com/viant/sqlx"
+	"github.com/viant/sqlx/io"
+	"github.com/viant/sqlx/io/config"
+	"github.com/viant/sqlx/io/delete"
+	ioerrors "github.com/viant/sqlx/io/errors"
 	"github.com/viant/sqlx/io/insert"
+	"github.com/viant/sqlx/io/read"
+	"github.com/viant/sqlx/io/update"
 	"github.com/viant/sqlx/metadata/info/dialect"
 	_ "github.com/viant/sqlx/metadata/product/sqlite"
+	"github.com/viant/sqlx/metadata/registry"
 	"github.com/viant/sqlx/option"
+	goIo "io"
 	"testing"
+	"time"
 )
 
 func TestService_Exec(t *testing.T) {
@@ -271,3 +284,631 @@ outer:
 	}
 
 }
+
+func TestService_Exec_generated(t *testing.T) {
+	type entity struct {
+		ID        int    `sqlx:"name=id,autoincrement"`
+		Name      string `sqlx:"name"`
+		Status    string `sqlx:"name=status,generated"`
+		CreatedAt string `sqlx:"name=created_at,generated"`
+	}
+
+	var useCases = []struct {
+		description string
+		table       string
+		records     []*entity
+		options     []option.Option
+		affected    int64
+		lastID      int64
+	}{
+		{
+			description: "single record",
+			table:       "t_generated1",
+			records:     []*entity{{Name: "John"}},
+			affected:    1,
+			lastID:      1,
+		},
+		{
+			description: "multi values batch",
+			table:       "t_generated2",
+			records:     []*entity{{Name: "John"}, {Name: "Bob"}, {Name: "Kate"}},
+			options:     []option.Option{option.BatchSize(2)},
+			affected:    3,
+			lastID:      3,
+		},
+	}
+
+	for _, testCase := range useCases {
+		db, err := sql.Open("sqlite3", "/tmp/sqllite.db")
+		if !assert.Nil(t, err, testCase.description) {
+			continue
+		}
+		for _, SQL := range []string{
+			"DROP TABLE IF EXISTS " + testCase.table,
+			"CREATE TABLE " + testCase.table + " (id INTEGER PRIMARY KEY AUTOINCREMENT, name TEXT, status TEXT DEFAULT 'new', created_at TEXT DEFAULT CURRENT_TIMESTAMP)",
+		} {
+			_, err = db.Exec(SQL)
+			assert.Nil(t, err, testCase.description)
+		}
+		inserter, err := insert.New(context.TODO(), db, testCase.table)
+		if !assert.Nil(t, err, testCase.description) {
+			continue
+		}
+		affected, lastID, err := inserter.Exec(context.TODO(), testCase.records, testCase.options...)
+		if !assert.Nil(t, err, testCase.description) {
+			continue
+		}
+		assert.EqualValues(t, testCase.affected, affected, testCase.description)
+		assert.EqualValues(t, testCase.lastID, lastID, testCase.description)
+		for i, record := range testCase.records {
+			assert.EqualValues(t, i+1, record.ID, testCase.description)
+			assert.EqualValues(t, "new", record.Status, testCase.description)
+			assert.NotEmpty(t, record.CreatedAt, testCase.description)
+		}
+	}
+}
+
+func TestService_Exec_executor(t *testing.T) {
+	type entity struct {
+		ID   int    `sqlx:"name=id,autoincrement"`
+		Name string `sqlx:"name"`
+	}
+	ctx := context.Background()
+	db, err := sql.Open("sqlite3", "/tmp/sqllite.db")
+	if !assert.Nil(t, err) {
+		return
+	}
+	for _, SQL := range []string{
+		"DROP TABLE IF EXISTS t_executor",
+		"CREATE TABLE t_executor (id INTEGER PRIMARY KEY AUTOINCREMENT, name TEXT)",
+	} {
+		if _, err = db.Exec(SQL); !assert.Nil(t, err) {
+			return
+		}
+	}
+	count := func(executor sqlx.Executor) int {
+		var result int
+		assert.Nil(t, executor.QueryRowContext(ctx, "SELECT COUNT(*) FROM t_executor").Scan(&result))
+		return result
+	}
+
+	conn, err := db.Conn(ctx)
+	if !assert.Nil(t, err) {
+		return
+	}
+	inserter, err := insert.NewTyped[entity](ctx, conn, "t_executor")
+	if !assert.Nil(t, err) {
+		return
+	}
+	affected, _, err := inserter.Exec(ctx, []*entity{{Name: "conn"}})
+	assert.Nil(t, err)
+	assert.EqualValues(t, 1, affected)
+	assert.Nil(t, conn.Close())
+	assert.EqualValues(t, 1, count(db))
+
+	tx, err := db.BeginTx(ctx, nil)
+	if !assert.Nil(t, err) {
+		return
+	}
+	aDialect := registry.LookupDialect(registry.MatchProduct(db))
+	txInserter, err := insert.NewTyped[entity](ctx, tx, "t_executor", aDialect)
+	if !assert.Nil(t, err) {
+		return
+	}
+	records := []*entity{{Name: "tx1"}, {Name: "tx2"}}
+	affected, _, err = txInserter.Exec(ctx, records)
+	assert.Nil(t, err)
+	assert.EqualValues(t, 2, affected)
+	assert.EqualValues(t, 3, count(tx), "visible within transaction")
+	assert.Nil(t, tx.Rollback())
+	assert.EqualValues(t, 1, count(db), "rolled back by transaction owner")
+}
+
+func TestService_Exec_unitOfWork(t *testing.T) {
+	type entity struct {
+		ID   int    `sqlx:"name=id,primaryKey"`
+		Name string `sqlx:"name"`
+	}
+	ctx := context.Background()
+	db, err := sql.Open("sqlite3", "/tmp/sqllite.db")
+	if !assert.Nil(t, err) {
+		return
+	}
+	for _, SQL := range []string{
+		"DROP TABLE IF EXISTS t_unit_of_work",
+		"CREATE TABLE t_unit_of_work (id INTEGER PRIMARY KEY, name TEXT)",
+	} {
+		if _, err = db.Exec(SQL); !assert.Nil(t, err) {
+			return
+		}
+	}
+	aDialect := registry.LookupDialect(registry.MatchProduct(db))
+	inserter, err := insert.NewTyped[entity](ctx, db, "t_unit_of_work")
+	if !assert.Nil(t, err) {
+		return
+	}
+	updater, err := update.NewTyped[entity](ctx, db, "t_unit_of_work")
+	if !assert.Nil(t, err) {
+		return
+	}
+	deleter, err := delete.NewTyped[entity](ctx, db, "t_unit_of_work")
+	if !assert.Nil(t, err) {
+		return
+	}
+	reader, err := read.NewTyped[entity](ctx, db, "SELECT id, name FROM t_unit_of_work ORDER BY id")
+	if !assert.Nil(t, err) {
+		return
+	}
+	names := func(ctx context.Context) []string {
+		records, err := reader.QueryAll(ctx)
+		assert.Nil(t, err)
+		var result []string
+		for _, record := range records {
+			result = append(result, record.Name)
+		}
+		return result
+	}
+	failure := errors.New("failure")
+	unit := io.NewUnitOfWork(db, aDialect)
+
+	err = unit.Run(ctx, func(ctx context.Context) error {
+		if _, _, err := inserter.Exec(ctx, []*entity{{ID: 1, Name: "n1"}, {ID: 2, Name: "n2"}}); err != nil {
+			return err
+		}
+		assert.EqualValues(t, []string{"n1", "n2"}, names(ctx), "visible within unit of work")
+		return failure
+	})
+	assert.Equal(t, failure, err)
+	assert.Nil(t, names(ctx), "rolled back unit of work")
+
+	err = unit.Run(ctx, func(ctx context.Context) error {
+		if _, _, err := inserter.Exec(ctx, []*entity{{ID: 1, Name: "n1"}, {ID: 2, Name: "n2"}}); err != nil {
+			return err
+		}
+		if _, err := updater.Exec(ctx, []*entity{{ID: 1, Name: "u1"}}); err != nil {
+			return err
+		}
+		nestedErr := unit.Run(ctx, func(ctx context.Context) error {
+			if _, err := deleter.Exec(ctx, []*entity{{ID: 2}}); err != nil {
+				return err
+			}
+			return failure
+		})
+		assert.Equal(t, failure, nestedErr)
+		_, _, err := inserter.Exec(ctx, []*entity{{ID: 3, Name: "n3"}})
+		return err
+	})
+	assert.Nil(t, err)
+	assert.EqualValues(t, []string{"u1", "n2", "n3"}, names(ctx))
+}
+
+func TestService_Exec_returnedOrder(t *testing.T) {
+	type keyed struct {
+		ID    int    `sqlx:"name=id,autoincrement"`
+		Code  string `sqlx:"name=code,unique"`
+		Label string `sqlx:"name=label,generated"`
+	}
+	type unkeyed struct {
+		ID    int    `sqlx:"name=id,autoincrement"`
+		Code  string `sqlx:"name=code"`
+		Label string `sqlx:"name=label,generated"`
+	}
+	keyedRecords := []*keyed{{Code: "c1"}, {Code: "c2"}, {Code: "c3"}}
+	unkeyedRecords := []*unkeyed{{Code: "c1"}, {Code: "c2"}, {Code: "c3"}}
+	var testCases = []struct {
+		description      string
+		records          interface{}
+		actual           func() []string
+		expectStatements int
+	}{
+		{
+			description:      "returned rows matched by key",
+			records:          keyedRecords,
+			expectStatements: 1,
+			actual: func() []string {
+				var result []string
+				for _, record := range keyedRecords {
+					result = append(result, fmt.Sprintf("%v:%v:%v", record.ID, record.Code, record.Label))
+				}
+				return result
+			},
+		},
+		{
+			description:      "returned rows matched by identity",
+			records:          unkeyedRecords,
+			expectStatements: 1,
+			actual: func() []string {
+				var result []string
+				for _, record := range unkeyedRecords {
+					result = append(result, fmt.Sprintf("%v:%v:%v", record.ID, record.Code, record.Label))
+				}
+				return result
+			},
+		},
+	}
+	db, err := sql.Open("sqlite3", "/tmp/sqllite.db")
+	if !assert.Nil(t, err) {
+		return
+	}
+	aDialect, err := config.Dialect(context.TODO(), db) //wrapped driver product is not detected
+	if !assert.Nil(t, err) {
+		return
+	}
+	reversedDB, err := sql.Open("sqlite3_reversed", "/tmp/sqllite.db")
+	if !assert.Nil(t, err) {
+		return
+	}
+	for _, testCase := range testCases {
+		for _, SQL := range []string{
+			"DROP TABLE IF EXISTS t_returned",
+			"CREATE TABLE t_returned (id INTEGER PRIMARY KEY AUTOINCREMENT, code TEXT UNIQUE, label TEXT GENERATED ALWAYS AS ('#' || code))",
+		} {
+			_, err = db.Exec(SQL)
+			assert.Nil(t, err, testCase.description)
+		}
+		interceptor := &recordingInterceptor{}
+		inserter, err := insert.New(context.TODO(), reversedDB, "t_returned", aDialect, interceptor)
+		if !assert.Nil(t, err, testCase.description) {
+			continue
+		}
+		affected, _, err := inserter.Exec(context.TODO(), testCase.records, option.BatchSize(3))
+		if !assert.Nil(t, err, testCase.description) {
+			continue
+		}
+		assert.EqualValues(t, 3, affected, testCase.description)
+		assert.Equal(t, testCase.expectStatements, len(interceptor.before), testCase.description)
+		var expect []string
+		rows, err := db.Query("SELECT id, code, label FROM t_returned ORDER BY code")
+		if !assert.Nil(t, err, testCase.description) {
+			continue
+		}
+		for rows.Next() {
+			var id int
+			var code, label string
+			assert.Nil(t, rows.Scan(&id, &code, &label), testCase.description)
+			expect = append(expect, fmt.Sprintf("%v:%v:%v", id, code, label))
+		}
+		assert.Nil(t, rows.Close(), testCase.description)
+		assert.EqualValues(t, expect, testCase.actual(), testCase.description)
+	}
+}
+
+func TestService_Exec_returnedStatements(t *testing.T) {
+	type identityOnly struct {
+		ID   int    `sqlx:"name=id,autoincrement"`
+		Code string `sqlx:"name=code"`
+	}
+	type generatedOnly struct {
+		Code  string `sqlx:"name=code"`
+		Label string `sqlx:"name=label,generated"`
+	}
+	identityRecords := []*identityOnly{{Code: "c1"}, {Code: "c2"}, {Code: "c3"}, {Code: "c4"}}
+	generatedRecords := []*generatedOnly{{Code: "c1"}, {Code: "c2"}, {Code: "c3"}, {Code: "c4"}}
+	var testCases = []struct {
+		description      string
+		records          interface{}
+		actual           func() []string
+		expect           func(id int, code, label string) string
+		expectStatements int
+	}{
+		{
+			description: "identity only batch",
+			records:     identityRecords,
+			actual: func() []string {
+				var result []string
+				for _, record := range identityRecords {
+					result = append(result, fmt.Sprintf("%v:%v", record.ID, record.Code))
+				}
+				return result
+			},
+			expect: func(id int, code, label string) string {
+				return fmt.Sprintf("%v:%v", id, code)
+			},
+			expectStatements: 1,
+		},
+		{
+			description: "statement per record without key and identity",
+			records:     generatedRecords,
+			actual: func() []string {
+				var result []string
+				for i, record := range generatedRecords {
+					result = append(result, fmt.Sprintf("%v:%v", i+1, record.Label))
+				}
+				return result
+			},
+			expect: func(id int, code, label string) string {
+				return fmt.Sprintf("%v:%v", id, label)
+			},
+			expectStatements: 4,
+		},
+	}
+	db, err := sql.Open("sqlite3", "/tmp/sqllite.db")
+	if !assert.Nil(t, err) {
+		return
+	}
+	aDialect, err := config.Dialect(context.TODO(), db)
+	if !assert.Nil(t, err) {
+		return
+	}
+	reversedDB, err := sql.Open("sqlite3_reversed", "/tmp/sqllite.db")
+	if !assert.Nil(t, err) {
+		return
+	}
+	for _, testCase := range testCases {
+		for _, SQL := range []string{
+			"DROP TABLE IF EXISTS t_returned_batch",
+			"CREATE TABLE t_returned_batch (id INTEGER PRIMARY KEY AUTOINCREMENT, code TEXT, label TEXT GENERATED ALWAYS AS ('#' || code))",
+		} {
+			_, err = db.Exec(SQL)
+			assert.Nil(t, err, testCase.description)
+		}
+		interceptor := &recordingInterceptor{}
+		inserter, err := insert.New(context.TODO(), reversedDB, "t_returned_batch", aDialect, interceptor)
+		if !assert.Nil(t, err, testCase.description) {
+			continue
+		}
+		affected, _, err := inserter.Exec(context.TODO(), testCase.records, option.BatchSize(4))
+		if !assert.Nil(t, err, testCase.description) {
+			continue
+		}
+		assert.EqualValues(t, 4, affected, testCase.description)
+		assert.Equal(t, testCase.expectStatements, len(interceptor.before), testCase.description)
+		var expect []string
+		rows, err := db.Query("SELECT id, code, label FROM t_returned_batch ORDER BY id")
+		if !assert.Nil(t, err, testCase.description) {
+			continue
+		}
+		for rows.Next() {
+			var id int
+			var code, label string
+			assert.Nil(t, rows.Scan(&id, &code, &label), testCase.description)
+			expect = append(expect, testCase.expect(id, code, label))
+		}
+		assert.Nil(t, rows.Close(), testCase.description)
+		assert.EqualValues(t, expect, testCase.actual(), testCase.description)
+	}
+}
+
+func TestService_Exec_retry(t *testing.T) {
+	type entity struct {
+		ID   int    `sqlx:"name=id,autoincrement"`
+		Name string `sqlx:"name"`
+	}
+	ctx := context.Background()
+	db, err := sql.Open("sqlite3", "/tmp/sqllite.db")
+	if !assert.Nil(t, err) {
+		return
+	}
+	for _, SQL := range []string{
+		"DROP TABLE IF EXISTS t_retry",
+		"CREATE TABLE t_retry (id INTEGER PRIMARY KEY AUTOINCREMENT, name TEXT UNIQUE)",
+		"INSERT INTO t_retry(name) VALUES('locked')",
+	} {
+		if _, err = db.Exec(SQL); !assert.Nil(t, err) {
+			return
+		}
+	}
+	attempts := 0
+	aDialect := *registry.LookupDialect(registry.MatchProduct(db))
+	aDialect.TransientError = func(err error) bool { //unique violation simulates deadlock released before next attempt
+		attempts++
+		_, dErr := db.Exec("DELETE FROM t_retry WHERE name = 'locked'")
+		return dErr == nil
+	}
+
+	tx, err := db.BeginTx(ctx, nil)
+	if !assert.Nil(t, err) {
+		return
+	}
+	inserter, err := insert.New(ctx, tx, "t_retry", &aDialect)
+	if !assert.Nil(t, err) {
+		return
+	}
+	_, _, err = inserter.Exec(ctx, []*entity{{Name: "locked"}}, option.NewRetry(3, time.Millisecond, 0))
+	assert.NotNil(t, err, "caller transaction is not retried")
+	assert.EqualValues(t, 0, attempts)
+	assert.Nil(t, tx.Rollback())
+
+	inserter, err = insert.New(ctx, db, "t_retry", &aDialect)
+	if !assert.Nil(t, err) {
+		return
+	}
+	records := []*entity{{Name: "first"}, {Name: "locked"}}
+	affected, _, err := inserter.Exec(ctx, records, option.NewRetry(3, time.Millisecond, 0))
+	if !assert.Nil(t, err) {
+		return
+	}
+	assert.EqualValues(t, 1, attempts)
+	assert.EqualValues(t, 2, affected)
+	var count int
+	assert.Nil(t, db.QueryRow("SELECT COUNT(*) FROM t_retry WHERE id IN (?, ?)", records[0].ID, records[1].ID).Scan(&count))
+	assert.EqualValues(t, 2, count)
+}
+
+func TestService_Exec_errors(t *testing.T) {
+	type entity struct {
+		ID   int     `sqlx:"name=id,primaryKey"`
+		Name *string `sqlx:"name"`
+	}
+	ctx := context.Background()
+	db, err := sql.Open("sqlite3", "/tmp/sqllite.db")
+	if !assert.Nil(t, err) {
+		return
+	}
+	for _, SQL := range []string{
+		"DROP TABLE IF EXISTS t_errors",
+		"CREATE TABLE t_errors (id INTEGER PRIMARY KEY, name TEXT NOT NULL UNIQUE)",
+		"INSERT INTO t_errors(id, name) VALUES(1, 'n1')",
+	} {
+		if _, err = db.Exec(SQL); !assert.Nil(t, err) {
+			return
+		}
+	}
+	name := "n1"
+	inserter, err := insert.NewTyped[entity](ctx, db, "t_errors")
+	if !assert.Nil(t, err) {
+		return
+	}
+
+	_, _, err = inserter.Exec(ctx, []*entity{{ID: 2, Name: &name}})
+	var unique *ioerrors.UniqueViolation
+	if assert.True(t, errors.As(err, &unique), "unique violation") {
+		assert.Equal(t, "t_errors", unique.Table)
+		assert.Equal(t, "name", unique.Column)
+	}
+
+	_, _, err = inserter.Exec(ctx, []*entity{{ID: 3}})
+	var notNull *ioerrors.NotNullViolation
+	if assert.True(t, errors.As(err, &notNull), "not null violation") {
+		assert.Equal(t, "t_errors", notNull.Table)
+		assert.Equal(t, "name", notNull.Column)
+	}
+}
+
+type recordingInterceptor struct {
+	before []string
+	after  []string
+	errors int
+}
+
+func (r *recordingInterceptor) BeforeExec(ctx context.Context, SQL string, args []interface{}) context.Context {
+	r.before = append(r.before, SQL)
+	return ctx
+}
+
+func (r *recordingInterceptor) AfterExec(ctx context.Context, SQL string, args []interface{}, result sql.Result, err error, duration time.Duration) {
+	r.after = append(r.after, SQL)
+	if err != nil {
+		r.errors++
+	}
+}
+
+func TestService_Exec_interceptor(t *testing.T) {
+	type entity struct {
+		ID   int    `sqlx:"name=id,primaryKey"`
+		Name string `sqlx:"name"`
+	}
+	ctx := context.Background()
+	db, err := sql.Open("sqlite3", "/tmp/sqllite.db")
+	if !assert.Nil(t, err) {
+		return
+	}
+	for _, SQL := range []string{
+		"DROP TABLE IF EXISTS t_interceptor",
+		"CREATE TABLE t_interceptor (id INTEGER PRIMARY KEY, name TEXT)",
+	} {
+		if _, err = db.Exec(SQL); !assert.Nil(t, err) {
+			return
+		}
+	}
+	insertSQL := `INSERT INTO "t_interceptor"(name,id) VALUES (?,?) RETURNING id`
+	serviceInterceptor := &recordingInterceptor{}
+	execInterceptor := &recordingInterceptor{}
+	inserter, err := insert.NewTyped[entity](ctx, db, "t_interceptor", serviceInterceptor)
+	if !assert.Nil(t, err) {
+		return
+	}
+	_, _, err = inserter.Exec(ctx, []*entity{{ID: 1, Name: "n1"}}, execInterceptor)
+	assert.Nil(t, err)
+	_, _, err = inserter.Exec(ctx, []*entity{{ID: 1, Name: "n1"}})
+	assert.NotNil(t, err)
+	assert.Equal(t, []string{insertSQL, insertSQL}, serviceInterceptor.after)
+	assert.Equal(t, 1, serviceInterceptor.errors)
+	assert.Equal(t, []string{insertSQL}, execInterceptor.before)
+
+	updater, err := update.NewTyped[entity](ctx, db, "t_interceptor", serviceInterceptor)
+	if !assert.Nil(t, err) {
+		return
+	}
+	_, err = updater.Exec(ctx, []*entity{{ID: 1, Name: "u1"}})
+	assert.Nil(t, err)
+	deleter, err := delete.NewTyped[entity](ctx, db, "t_interceptor", serviceInterceptor)
+	if !assert.Nil(t, err) {
+		return
+	}
+	_, err = deleter.Exec(ctx, []*entity{{ID: 1}})
+	assert.Nil(t, err)
+	reader, err := read.NewTyped[entity](ctx, db, "SELECT id, name FROM t_interceptor", serviceInterceptor)
+	if !assert.Nil(t, err) {
+		return
+	}
+	_, err = reader.QueryAll(ctx)
+	assert.Nil(t, err)
+	assert.Equal(t, 5, len(serviceInterceptor.before))
+	assert.Equal(t, serviceInterceptor.before, serviceInterceptor.after)
+	assert.Equal(t, "SELECT id, name FROM t_interceptor", serviceInterceptor.after[4])
+}
+
+func init() {
+	sql.Register("sqlite3_reversed", &reversedDriver{})
+}
+
+//reversedDriver represents sqlite driver returning query rows in reversed order
+type reversedDriver struct {
+	sqlite3.SQLiteDriver
+}
+
+type reversedConn struct {
+	driver.Conn
+}
+
+type reversedStmt struct {
+	driver.Stmt
+}
+
+type reversedRows struct {
+	columns []string
+	values  [][]driver.Value
+}
+
+func (d *reversedDriver) Open(name string) (driver.Conn, error) {
+	conn, err := d.SQLiteDriver.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	return &reversedConn{Conn: conn}, nil
+}
+
+func (c *reversedConn) Prepare(query string) (driver.Stmt, error) {
+	stmt, err := c.Conn.Prepare(query)
+	if err != nil {
+		return nil, err
+	}
+	return &reversedStmt{Stmt: stmt}, nil
+}
+
+func (s *reversedStmt) Query(args []driver.Value) (driver.Rows, error) {
+	rows, err := s.Stmt.Query(args) //nolint
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	result := &reversedRows{columns: rows.Columns()}
+	for {
+		values := make([]driver.Value, len(result.columns))
+		if err = rows.Next(values); err == goIo.EOF {
+			return result, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		result.values = append([][]driver.Value{values}, result.values...)
+	}
+}
+
+func (r *reversedRows) Columns() []string {
+	return r.columns
+}
+
+func (r *reversedRows) Close() error {
+	return nil
+}
+
+func (r *reversedRows) Next(dest []driver.Value) error {
+	if len(r.values) == 0 {
+		return goIo.EOF
+	}
+	copy(dest, r.values[0])
+	r.values = r.values[1:]
+	return nil
+}
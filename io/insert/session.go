@@ -4,11 +4,14 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
+	"github.com/viant/sqlx"
 	"github.com/viant/sqlx/io"
 	"github.com/viant/sqlx/io/config"
+	"github.com/viant/sqlx/io/telemetry"
 	"github.com/viant/sqlx/metadata/sink"
 	"github.com/viant/sqlx/option"
 	"reflect"
+	"sort"
 	"strings"
 )
 
@@ -20,15 +23,27 @@ type session struct {
 	*config.Config
 	binder         io.PlaceholderBinder
 	columns        io.Columns
-	db             *sql.DB
+	db             sqlx.Executor
 	stmt           *sql.Stmt
+	SQL            string //prepared statement SQL
+	interceptor    sqlx.Interceptor
+	tracer         option.Tracer
 	recordUpdaters []recordUpdater
+	identity       string     //numeric identity column populated by database
+	returning      *returning //generated columns populated by database
+	returned       [][]interface{}
+	keys           []int //positions of key columns matching returned rows with batched records
 }
 
 func (s *session) init(record interface{}) (err error) {
 	if s.columns, s.binder, err = s.Mapper(record, s.TagName); err != nil {
 		return err
 	}
+	if s.returning = newReturning(s.columns, s.binder); s.returning != nil {
+		if s.columns, s.binder, err = s.Mapper(record, s.TagName, option.Columns(s.insertable())); err != nil {
+			return err
+		}
+	}
 
 	for i, column := range s.columns {
 		if io.IsIdentityColumn(column) {
@@ -43,11 +58,72 @@ func (s *session) init(record interface{}) (err error) {
 		}
 	}
 
-	s.Builder, err = NewBuilder(s.TableName, s.columns.Names(), s.Dialect, s.Identity, s.batchSize)
+	if len(s.recordUpdaters) > 0 {
+		s.identity = s.recordUpdaters[0].getColumn().Name()
+	}
+	var returned []string
+	if s.returning != nil {
+		returned = s.returning.columns
+	}
+	if s.canQuery() {
+		for i, column := range s.columns {
+			if tag := column.Tag(); tag != nil && (tag.PrimaryKey || tag.IsUnique) && !io.IsIdentityColumn(column) {
+				s.keys = append(s.keys, i)
+				returned = append(returned, column.Name())
+			}
+		}
+	}
+	s.Builder, err = NewBuilder(s.TableName, s.columns.Names(), s.Dialect, s.identity, s.batchSize, returned...)
 	return err
 }
 
-func (s *session) begin(ctx context.Context, db *sql.DB, options []option.Option) error {
+//insertable returns non identity column names excluding generated ones
+func (s *session) insertable() []string {
+	var result = make([]string, 0, len(s.columns))
+	for _, column := range s.columns {
+		if tag := column.Tag(); (tag != nil && tag.Generated) || io.IsIdentityColumn(column) {
+			continue
+		}
+		result = append(result, column.Name())
+	}
+	return result
+}
+
+//canQuery returns true if inserted records data is returned with RETURNING or OUTPUT clause
+func (s *session) canQuery() bool {
+	if !s.Dialect.CanReturning && !s.Dialect.CanOutputInserted {
+		return false
+	}
+	return s.identity != "" || s.returning != nil
+}
+
+//statementSize returns records count inserted with a single statement, database does not guarantee returned rows order,
+//returned rows are matched with batched records by key columns or by identity allocated in insert order,
+//without key and identity columns each record is inserted with its own statement
+func (s *session) statementSize(batchSize int) int {
+	if s.canQuery() && len(s.keys) == 0 && s.identity == "" {
+		return 1
+	}
+	return batchSize
+}
+
+//exec inserts records within session transaction
+func (s *session) exec(ctx context.Context, record interface{}, batchRecordBuffer []interface{}, valueAt io.ValueAccessor, recordCount int, identities []interface{}, batchSize int, options []option.Option) (int64, int64, error) {
+	if err := s.begin(ctx, s.db, options); err != nil {
+		return 0, 0, err
+	}
+
+	if err := s.prepare(ctx, record, s.statementSize(batchSize)); err != nil {
+		err = s.end(err)
+		return 0, 0, err
+	}
+
+	rowsAffected, lastInsertedID, err := s.insert(ctx, batchRecordBuffer, valueAt, recordCount, identities)
+	err = s.end(err)
+	return rowsAffected, lastInsertedID, err
+}
+
+func (s *session) begin(ctx context.Context, db sqlx.Executor, options []option.Option) error {
 	var err error
 	s.Transaction, err = io.TransactionFor(ctx, s.Dialect, db, options)
 	if err != nil {
@@ -92,9 +168,7 @@ func (s *session) prepare(ctx context.Context, record interface{}, batchSize int
 		}
 		s.stmt = nil
 	}
-	if showSQL {
-		fmt.Println(SQL)
-	}
+	s.SQL = SQL
 	if s.Transaction != nil {
 		s.stmt, err = s.Transaction.Prepare(SQL)
 		return err
@@ -103,12 +177,36 @@ func (s *session) prepare(ctx context.Context, record interface{}, batchSize int
 	return err
 }
 
+//identitiesSnapshot returns function restoring records identities, used to retry insert after identities were populated
+func (s *session) identitiesSnapshot(valueAt io.ValueAccessor, count int) func() {
+	var targets, values []reflect.Value
+	if len(s.recordUpdaters) > 0 {
+		var recValues = make([]interface{}, len(s.columns))
+		for i := 0; i < count; i++ {
+			s.binder(valueAt(i), recValues, 0, len(s.columns))
+			for _, updater := range s.recordUpdaters {
+				target := reflect.ValueOf(recValues[updater.columnPosition()]).Elem()
+				value := reflect.New(target.Type()).Elem()
+				value.Set(target)
+				targets = append(targets, target)
+				values = append(values, value)
+			}
+		}
+	}
+	return func() {
+		for i, target := range targets {
+			target.Set(values[i])
+		}
+	}
+}
+
 func isClosedError(err error) bool {
 	return strings.Contains(err.Error(), "closed")
 }
 
 func (s *session) insert(ctx context.Context, recValues []interface{}, valueAt io.ValueAccessor, size int, identitiesBatched []interface{}) (int64, int64, error) {
 	inBatchCount := 0
+	batchSize := s.statementSize(s.batchSize)
 	var err error
 	var rowsAffected, totalRowsAffected, lastInsertedID int64
 	var record interface{}
@@ -123,6 +221,12 @@ func (s *session) insert(ctx context.Context, recValues []interface{}, valueAt i
 		}
 
 		s.binder(record, recValues[offset:], 0, len(s.columns))
+		if s.returning != nil {
+			if s.returned == nil {
+				s.returned = make([][]interface{}, s.batchSize)
+			}
+			s.returned[inBatchCount] = s.returning.targets(record)
+		}
 		for _, updater := range s.recordUpdaters {
 			idIndex := offset + updater.columnPosition()
 			identitiesBatched[inBatchCount] = recValues[idIndex]
@@ -132,8 +236,8 @@ func (s *session) insert(ctx context.Context, recValues []interface{}, valueAt i
 		}
 
 		inBatchCount++
-		if inBatchCount >= s.batchSize {
-			rowsAffected, lastInsertedID, err = s.flush(ctx, recValues, identitiesBatched)
+		if inBatchCount >= batchSize {
+			rowsAffected, lastInsertedID, err = s.flush(ctx, recValues[0:batchSize*len(s.columns)], identitiesBatched)
 			if err != nil {
 				return 0, 0, err
 			}
@@ -156,12 +260,22 @@ func (s *session) insert(ctx context.Context, recValues []interface{}, valueAt i
 	return totalRowsAffected, lastInsertedID, err
 }
 
-func (s *session) flush(ctx context.Context, values []interface{}, identities []interface{}) (int64, int64, error) {
-	if s.Dialect.CanReturning {
+//flush inserts batched values within telemetry span
+func (s *session) flush(ctx context.Context, values []interface{}, identities []interface{}) (rowsAffected int64, lastInsertedID int64, err error) {
+	ctx, span := telemetry.Start(ctx, s.tracer, "sqlx.insert.flush", telemetry.Table.String(s.TableName), telemetry.BatchSize.Int(len(values)/len(s.columns)))
+	defer func() { telemetry.End(span, err, telemetry.RowsAffected.Int64(rowsAffected)) }()
+	return s.flushValues(ctx, values, identities)
+}
+
+func (s *session) flushValues(ctx context.Context, values []interface{}, identities []interface{}) (int64, int64, error) {
+	if s.canQuery() {
 		return s.flushQuery(ctx, values, identities)
 	}
+	if s.Dialect.CanReturningInto && s.identity != "" {
+		return s.flushReturningInto(ctx, values, identities)
+	}
 
-	result, err := s.stmt.ExecContext(ctx, values...)
+	result, err := s.execStmt(ctx, values)
 	if err != nil {
 		return 0, 0, err
 	}
@@ -171,9 +285,11 @@ func (s *session) flush(ctx context.Context, values []interface{}, identities []
 		return 0, 0, err
 	}
 
-	id, err := result.LastInsertId()
-	if err != nil {
-		return 0, 0, err
+	var id int64
+	if s.Dialect.CanLastInsertID {
+		if id, err = result.LastInsertId(); err != nil {
+			return 0, 0, err
+		}
 	}
 
 	for _, updater := range s.recordUpdaters {
@@ -190,28 +306,125 @@ func (s *session) flush(ctx context.Context, values []interface{}, identities []
 	return rowsAffected, id, nil
 }
 
-func (s *session) flushQuery(ctx context.Context, values []interface{}, identities []interface{}) (int64, int64, error) {
-	var rowsAffected, newLastInsertedID int64
+//execStmt executes prepared statement with session interceptor
+func (s *session) execStmt(ctx context.Context, args []interface{}) (sql.Result, error) {
+	return sqlx.InterceptExec(ctx, s.interceptor, s.SQL, args, func(ctx context.Context) (sql.Result, error) {
+		return s.stmt.ExecContext(ctx, args...)
+	})
+}
+
+//flushReturningInto executes single record insert, identity is returned into out bind variable
+func (s *session) flushReturningInto(ctx context.Context, values []interface{}, identities []interface{}) (int64, int64, error) {
+	var id int64
+	args := append(values[:len(values):len(values)], sql.Out{Dest: &id})
+	result, err := s.execStmt(ctx, args)
+	if err != nil {
+		return 0, 0, err
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return 0, 0, err
+	}
+	if rowsAffected == 0 {
+		return 0, 0, nil
+	}
+	idPtr, err := io.Int64Ptr(identities, 0)
+	if err != nil {
+		return 0, 0, err
+	}
+	*idPtr = id
+	return rowsAffected, id, nil
+}
+
+//flushQuery executes insert returning identity and generated columns with session interceptor, returned rows are read before interceptor completes
+func (s *session) flushQuery(ctx context.Context, values []interface{}, identities []interface{}) (rowsAffected int64, lastInsertedID int64, err error) {
+	_, err = sqlx.InterceptExec(ctx, s.interceptor, s.SQL, values, func(ctx context.Context) (sql.Result, error) {
+		var err error
+		rowsAffected, lastInsertedID, err = s.queryReturning(ctx, values, identities)
+		return nil, err
+	})
+	return rowsAffected, lastInsertedID, err
+}
+
+//queryReturning executes insert returning identity and generated columns, returned values are written back to the batched records,
+//returned rows are matched with batched records by key columns, otherwise by identity as identities are allocated in insert order
+func (s *session) queryReturning(ctx context.Context, values []interface{}, identities []interface{}) (int64, int64, error) {
 	rows, err := s.stmt.QueryContext(ctx, values...)
 	if err != nil {
 		return 0, 0, err
 	}
 	defer io.RunWithError(rows.Close, &err)
-	rows.NextResultSet()
-	newLastInsertedID = 0
-
+	var returnedRows []*returnedRow
 	for rows.Next() {
-		if err = rows.Scan(&newLastInsertedID); err != nil {
+		row := &returnedRow{}
+		var dest []interface{}
+		if s.identity != "" {
+			dest = append(dest, &row.id)
+		}
+		if s.returning != nil {
+			row.generated = newValues(s.returned[0])
+			dest = append(dest, row.generated...)
+		}
+		for _, position := range s.keys {
+			row.keys = append(row.keys, newValue(values[position]))
+		}
+		dest = append(dest, row.keys...)
+		if err = rows.Scan(dest...); err != nil {
 			return 0, 0, err
 		}
+		returnedRows = append(returnedRows, row)
+	}
+	if err = rows.Err(); err != nil {
+		return 0, 0, err
+	}
 
-		idPtr, err := io.Int64Ptr(identities, int(rowsAffected))
-		if err != nil {
-			return 0, 0, err
+	var records map[string][]int
+	if len(s.keys) > 0 {
+		records = s.recordsByKey(values)
+	} else if s.identity != "" {
+		sort.SliceStable(returnedRows, func(i, j int) bool {
+			return returnedRows[i].id < returnedRows[j].id
+		})
+	}
+	var lastInsertedID int64
+	for i, row := range returnedRows {
+		index := i
+		if records != nil {
+			key := recordKey(row.keys)
+			indexes := records[key]
+			if len(indexes) == 0 {
+				return 0, 0, fmt.Errorf("failed to match returned row with inserted record by key: %v", key)
+			}
+			index, records[key] = indexes[0], indexes[1:]
+		}
+		if s.returning != nil {
+			setValues(s.returned[index], row.generated)
+		}
+		if s.identity != "" {
+			idPtr, err := io.Int64Ptr(identities, index)
+			if err != nil {
+				return 0, 0, err
+			}
+			*idPtr = row.id
+			if row.id > lastInsertedID {
+				lastInsertedID = row.id
+			}
 		}
+	}
+	return int64(len(returnedRows)), lastInsertedID, err
+}
 
-		*idPtr = newLastInsertedID
-		rowsAffected++
+//recordsByKey returns batched records indexes by key
+func (s *session) recordsByKey(values []interface{}) map[string][]int {
+	count := len(values) / len(s.columns)
+	var result = make(map[string][]int, count)
+	var keys = make([]interface{}, len(s.keys))
+	for i := 0; i < count; i++ {
+		for j, position := range s.keys {
+			keys[j] = values[i*len(s.columns)+position]
+		}
+		key := recordKey(keys)
+		result[key] = append(result[key], i)
 	}
-	return rowsAffected, newLastInsertedID, err
+	return result
 }
@@ -10,15 +10,19 @@ import (
 
 const (
 	insertIntoFragment = "INSERT INTO "
+	outputFragment     = " OUTPUT "
+	insertedPrefix     = "INSERTED."
 )
 
 //Builder represent insert DML builder
 type Builder struct {
 	dialect    *info.Dialect
 	id         string
+	returning  []string
 	valuesSize int
 	sql        string
 	batchSize  int
+	columns    int
 	offsets    []uint32
 }
 
@@ -26,8 +30,10 @@ type Builder struct {
 func (b *Builder) Build(record interface{}, options ...option.Option) string {
 	batchSize := option.Options(options).BatchSize()
 	suffix := ""
-	if b.dialect.CanReturning && len(b.id) > 0 {
-		suffix = " RETURNING " + b.id
+	if b.dialect.CanReturning && len(b.returning) > 0 {
+		suffix = " RETURNING " + strings.Join(b.returning, ",")
+	} else if b.dialect.CanReturningInto && len(b.id) > 0 {
+		suffix = " RETURNING " + b.id + " INTO " + b.placeholderAt(batchSize*b.columns)
 	}
 
 	if batchSize == b.batchSize {
@@ -38,8 +44,17 @@ func (b *Builder) Build(record interface{}, options ...option.Option) string {
 	return b.sql[:limit] + suffix
 }
 
-//NewBuilder return insert builder
-func NewBuilder(table string, columns []string, dialect *info.Dialect, identity string, batchSize int) (io.Builder, error) {
+//placeholderAt returns placeholder following supplied number of placeholders
+func (b *Builder) placeholderAt(position int) string {
+	getPlaceholder := b.dialect.PlaceholderGetter()
+	for i := 0; i < position; i++ {
+		getPlaceholder()
+	}
+	return getPlaceholder()
+}
+
+//NewBuilder return insert builder, identity and returned (generated and key) columns are returned with RETURNING or OUTPUT clause if dialect supports it
+func NewBuilder(table string, columns []string, dialect *info.Dialect, identity string, batchSize int, returned ...string) (io.Builder, error) {
 	if len(columns) == 0 {
 		return nil, fmt.Errorf("columns were empty")
 	}
@@ -64,7 +79,23 @@ func NewBuilder(table string, columns []string, dialect *info.Dialect, identity
 		}
 		sqlBuilder.WriteString(column)
 	}
-	sqlBuilder.WriteString(") VALUES ")
+	sqlBuilder.WriteString(")")
+	var returning []string
+	if identity != "" {
+		returning = append(returning, identity)
+	}
+	returning = append(returning, returned...)
+	if dialect.CanOutputInserted && len(returning) > 0 {
+		sqlBuilder.WriteString(outputFragment)
+		for i, column := range returning {
+			if i > 0 {
+				sqlBuilder.WriteString(",")
+			}
+			sqlBuilder.WriteString(insertedPrefix)
+			sqlBuilder.WriteString(column)
+		}
+	}
+	sqlBuilder.WriteString(" VALUES ")
 	getPlaceholder := dialect.PlaceholderGetter()
 	for i := 0; i < batchSize; i++ {
 		if i > 0 {
@@ -85,8 +116,10 @@ func NewBuilder(table string, columns []string, dialect *info.Dialect, identity
 		sql:       sqlBuilder.String(),
 		dialect:   dialect,
 		batchSize: batchSize,
+		columns:   len(columns),
 		offsets:   offsets,
 		id:        identity,
+		returning: returning,
 	}, nil
 }
 
@@ -100,6 +133,9 @@ func estimateBufferSize(table string, columns []string, batchSize int) int {
 
 var showSQL bool
 
+//ShowSQL prints executed SQL to stdout
+//
+//Deprecated: use sqlx.SQLPrinter or any other sqlx.Interceptor option
 func ShowSQL(b bool) {
 	showSQL = b
 }
@@ -3,6 +3,7 @@ package insert
 import (
 	"github.com/stretchr/testify/assert"
 	"github.com/viant/sqlx/metadata/info"
+	"github.com/viant/sqlx/metadata/product/oracle"
 	"github.com/viant/sqlx/option"
 	"testing"
 )
@@ -14,6 +15,7 @@ func TestInsert_Build(t *testing.T) {
 		table         string
 		batchSize     int
 		identity      string
+		generated     []string
 		callBatchSize int
 		columns       []string
 		dialect       *info.Dialect
@@ -52,10 +54,65 @@ func TestInsert_Build(t *testing.T) {
 			callBatchSize: 3,
 			expect:        `INSERT INTO "foo"(c1,cN) VALUES (?,?),(?,?),(?,?)`,
 		},
+		{
+			description: "returning into",
+			table:       "foo",
+			columns:     []string{"c1", "id"},
+			identity:    "id",
+			dialect: &info.Dialect{
+				Placeholder:         ":",
+				CanReturningInto:    true,
+				PlaceholderResolver: &oracle.PlaceholderGenerator{},
+			},
+			batchSize:     1,
+			callBatchSize: 1,
+			expect:        `INSERT INTO "foo"(c1,id) VALUES (:1,:2) RETURNING id INTO :3`,
+		},
+		{
+			description: "returning identity and generated columns",
+			table:       "foo",
+			columns:     []string{"c1", "id"},
+			identity:    "id",
+			generated:   []string{"created_at", "uid"},
+			dialect: &info.Dialect{
+				Placeholder:  "?",
+				CanReturning: true,
+			},
+			batchSize:     3,
+			callBatchSize: 2,
+			expect:        `INSERT INTO "foo"(c1,id) VALUES (?,?),(?,?) RETURNING id,created_at,uid`,
+		},
+		{
+			description: "returning generated columns without identity",
+			table:       "foo",
+			columns:     []string{"c1"},
+			generated:   []string{"created_at"},
+			dialect: &info.Dialect{
+				Placeholder:  "?",
+				CanReturning: true,
+			},
+			batchSize:     1,
+			callBatchSize: 1,
+			expect:        `INSERT INTO "foo"(c1) VALUES (?) RETURNING created_at`,
+		},
+		{
+			description: "output inserted",
+			table:       "foo",
+			columns:     []string{"c1", "id"},
+			identity:    "id",
+			generated:   []string{"created_at"},
+			dialect: &info.Dialect{
+				Placeholder:       "?",
+				CanOutputInserted: true,
+			},
+			batchSize:     2,
+			callBatchSize: 1,
+			expect:        `INSERT INTO "foo"(c1,id) OUTPUT INSERTED.id,INSERTED.created_at VALUES (?,?)`,
+		},
 	}
 
 	for _, testCase := range testCases {
-		builder, err := NewBuilder(testCase.table, testCase.columns, testCase.dialect, testCase.identity, testCase.batchSize)
+		builder, err := NewBuilder(testCase.table, testCase.columns, testCase.dialect, testCase.identity, testCase.batchSize, testCase.generated...)
 		assert.Nil(t, err, testCase.description)
 		actual := builder.Build(nil, option.BatchSize(testCase.callBatchSize))
 		assert.EqualValues(t, testCase.expect, actual, testCase.description)
@@ -0,0 +1,31 @@
+package insert
+
+import (
+	"context"
+	"github.com/viant/sqlx"
+	"github.com/viant/sqlx/option"
+)
+
+//Typed represents type safe inserter service
+type Typed[T any] struct {
+	service *Service
+}
+
+//Service returns underlying inserter service
+func (t *Typed[T]) Service() *Service {
+	return t.service
+}
+
+//Exec inserts records, returns affected rows and last inserted ID
+func (t *Typed[T]) Exec(ctx context.Context, records []*T, options ...option.Option) (int64, int64, error) {
+	return t.service.Exec(ctx, records, options...)
+}
+
+//NewTyped creates a type safe inserter service
+func NewTyped[T any](ctx context.Context, db sqlx.Executor, tableName string, options ...option.Option) (*Typed[T], error) {
+	service, err := New(ctx, db, tableName, options...)
+	if err != nil {
+		return nil, err
+	}
+	return &Typed[T]{service: service}, nil
+}
@@ -0,0 +1,32 @@
+package io
+
+import (
+	"context"
+	"errors"
+)
+
+//ErrCallerTransaction represents error returned when modification runs within transaction managed by caller
+var ErrCallerTransaction = errors.New("transaction is managed by caller")
+
+//Invalidator represents cache invalidating entries that depend on modified tables, i.e. io/read/cache backends
+type Invalidator interface {
+	//Invalidate removes entries depending on any of supplied tables, returns removed entries count
+	Invalidate(ctx context.Context, tables ...string) (int, error)
+}
+
+//InvalidateOnCommit calls invalidate once modification made with transaction is committed,
+//within context unit of work running the transaction invalidate is deferred till the unit of work commits,
+//for transaction managed by caller (*sql.Tx option or executor) ErrCallerTransaction is returned as its commit can not be observed,
+//the caller invalidates entries after commit, otherwise modification has been already committed and invalidate is called right away
+func InvalidateOnCommit(ctx context.Context, transaction *Transaction, invalidate func(ctx context.Context) error) error {
+	if transaction == nil {
+		return invalidate(ctx)
+	}
+	if unit := UnitOfWorkFrom(ctx).unitFor(transaction.Tx); unit != nil {
+		return unit.OnCommit(ctx, invalidate)
+	}
+	if transaction.Global {
+		return ErrCallerTransaction
+	}
+	return invalidate(ctx)
+}
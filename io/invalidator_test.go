@@ -0,0 +1,97 @@
+package io
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestInvalidateOnCommit(t *testing.T) {
+	db, err := sql.Open("sqlite3", "/tmp/sqllite.db")
+	if !assert.Nil(t, err) {
+		return
+	}
+	defer db.Close()
+	failure := fmt.Errorf("failure")
+
+	var testCases = []struct {
+		description string
+		run         func(ctx context.Context, invalidate func(ctx context.Context) error) error
+		expectErr   error
+		expect      int
+	}{
+		{
+			description: "without transaction",
+			run: func(ctx context.Context, invalidate func(ctx context.Context) error) error {
+				return InvalidateOnCommit(ctx, nil, invalidate)
+			},
+			expect: 1,
+		},
+		{
+			description: "committed service transaction",
+			run: func(ctx context.Context, invalidate func(ctx context.Context) error) error {
+				tx, err := db.BeginTx(ctx, nil)
+				if err != nil {
+					return err
+				}
+				defer tx.Rollback()
+				return InvalidateOnCommit(ctx, &Transaction{Tx: tx}, invalidate)
+			},
+			expect: 1,
+		},
+		{
+			description: "caller transaction",
+			run: func(ctx context.Context, invalidate func(ctx context.Context) error) error {
+				tx, err := db.BeginTx(ctx, nil)
+				if err != nil {
+					return err
+				}
+				defer tx.Rollback()
+				return InvalidateOnCommit(ctx, CallerTransaction(ctx, tx, nil), invalidate)
+			},
+			expectErr: ErrCallerTransaction,
+		},
+		{
+			description: "unit of work deferred till commit",
+			run: func(ctx context.Context, invalidate func(ctx context.Context) error) error {
+				return NewUnitOfWork(db).Run(ctx, func(ctx context.Context) error {
+					transaction, err := BeginTransaction(ctx, db, nil)
+					if err != nil {
+						return err
+					}
+					return InvalidateOnCommit(ctx, transaction, invalidate)
+				})
+			},
+			expect: 1,
+		},
+		{
+			description: "rolled back unit of work",
+			run: func(ctx context.Context, invalidate func(ctx context.Context) error) error {
+				return NewUnitOfWork(db).Run(ctx, func(ctx context.Context) error {
+					transaction, err := BeginTransaction(ctx, db, nil)
+					if err != nil {
+						return err
+					}
+					if err = InvalidateOnCommit(ctx, transaction, invalidate); err != nil {
+						return err
+					}
+					return failure
+				})
+			},
+			expectErr: failure,
+		},
+	}
+
+	for _, testCase := range testCases {
+		called := 0
+		err := testCase.run(context.Background(), func(ctx context.Context) error {
+			called++
+			return nil
+		})
+		assert.Equal(t, testCase.expectErr, err, testCase.description)
+		assert.EqualValues(t, testCase.expect, called, testCase.description)
+	}
+}
@@ -3,6 +3,7 @@ package io
 import (
 	"context"
 	"database/sql"
+	"github.com/viant/sqlx"
 	"github.com/viant/sqlx/metadata/info"
 	"github.com/viant/sqlx/option"
 )
@@ -12,5 +13,5 @@ type SessionResolver = func(dialect *info.Dialect) Session
 
 //Session represents load session e.g. MySQL "LOAD DATA LOCAL INFILE"
 type Session interface {
-	Exec(context context.Context, data interface{}, db *sql.DB, tableName string, options ...option.Option) (sql.Result, error)
+	Exec(context context.Context, data interface{}, db sqlx.Executor, tableName string, options ...option.Option) (sql.Result, error)
 }
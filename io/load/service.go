@@ -2,8 +2,10 @@ package load
 
 import (
 	"context"
-	"database/sql"
+	"github.com/viant/sqlx"
+	"github.com/viant/sqlx/io"
 	"github.com/viant/sqlx/io/config"
+	"github.com/viant/sqlx/io/telemetry"
 	"github.com/viant/sqlx/metadata/info"
 	"github.com/viant/sqlx/metadata/sink"
 	"github.com/viant/sqlx/option"
@@ -14,26 +16,31 @@ type Service struct {
 	dialect   *info.Dialect
 	tableName string
 	columns   []sink.Column
-	db        *sql.DB
+	db        sqlx.Executor
+	cache     *config.Config
 }
 
-//New creates instance of Service
-func New(ctx context.Context, db *sql.DB, tableName string) (*Service, error) {
+//New creates instance of Service, io.Invalidator option invalidates table cache entries once data is loaded
+func New(ctx context.Context, db sqlx.Executor, tableName string, options ...option.Option) (*Service, error) {
 	dialect, err := config.Dialect(ctx, db)
 	if err != nil {
 		return nil, err
 	}
-
+	cache := config.New(tableName)
+	option.Assign(options, &cache.Invalidator)
 	return &Service{
 		tableName: tableName,
 		db:        db,
 		dialect:   dialect,
+		cache:     cache,
 	}, nil
 
 }
 
 //Exec executes load statement specific for database
-func (s *Service) Exec(ctx context.Context, any interface{}, options ...option.Option) (int, error) {
+func (s *Service) Exec(ctx context.Context, any interface{}, options ...option.Option) (affected int, err error) {
+	ctx, span := telemetry.Start(ctx, option.Options(options).Tracer(), "sqlx.load.Exec", telemetry.Table.String(s.tableName))
+	defer func() { telemetry.End(span, err, telemetry.RowsAffected.Int(affected)) }()
 	dialect, err := s.ensureDialect(ctx)
 	if err != nil {
 		return 0, err
@@ -42,11 +49,14 @@ func (s *Service) Exec(ctx context.Context, any interface{}, options ...option.O
 
 	exec, err := session.Exec(ctx, any, s.db, s.tableName, options...)
 	if err != nil {
-		return 0, err
+		return 0, dialect.NormalizeError(err)
 	}
 
-	affected, err := exec.RowsAffected()
-	return int(affected), err
+	rowsAffected, err := exec.RowsAffected()
+	if err == nil && rowsAffected > 0 && s.cache != nil {
+		s.cache.InvalidateCache(ctx, io.CallerTransaction(ctx, s.db, options), options)
+	}
+	return int(rowsAffected), err
 }
 
 func (s *Service) ensureDialect(ctx context.Context) (*info.Dialect, error) {
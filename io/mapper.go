@@ -255,7 +255,7 @@ func (b *columnMapperBuilder) appendColumns(field reflect.StructField, caseForma
 		b.identityColumns = append(b.identityColumns, NewColumnWithFields(columnName, "", field.Type, holders, WithTag(tag)))
 		return nil
 	}
-	if b.identityOnly {
+	if b.identityOnly && !tag.Version { //version is checked with identity
 		return nil
 	}
 	if b.columnRestriction.CanUse(columnName) {
@@ -0,0 +1,36 @@
+package merge_test
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"github.com/viant/sqlx/io/merge"
+	_ "github.com/viant/sqlx/metadata/product/mysql"
+	"github.com/viant/sqlx/option"
+	"log"
+)
+
+func ExampleService_Exec() {
+	type Foo struct {
+		ID   int `sqlx:"name=id,primaryKey"`
+		Name string
+	}
+	dsn := ""
+	db, err := sql.Open("mysql", dsn)
+	if err != nil {
+		log.Fatalln(err)
+	}
+
+	merger, err := merge.New(context.TODO(), db, "mytable")
+	if err != nil {
+		log.Fatalln(err)
+	}
+	var records []*Foo
+	//records = getAppRecords()
+
+	inserted, updated, err := merger.Exec(context.TODO(), records, option.BatchSize(1024))
+	if err != nil {
+		log.Fatalln(err)
+	}
+	fmt.Printf("inserted: %v, updated: %v\n", inserted, updated)
+}
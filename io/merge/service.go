@@ -0,0 +1,92 @@
+package merge
+
+import (
+	"context"
+	"github.com/viant/sqlx"
+	"github.com/viant/sqlx/io"
+	"github.com/viant/sqlx/io/config"
+	"github.com/viant/sqlx/metadata/info/dialect"
+	"github.com/viant/sqlx/option"
+	"reflect"
+	"sync"
+)
+
+//Service represents merger (upsert) service
+type Service struct {
+	*config.Config
+	initSession *session
+	mux         sync.Mutex
+	db          sqlx.Executor
+}
+
+//Exec runs upsert statements, it returns inserted and updated record count
+func (s *Service) Exec(ctx context.Context, any interface{}, options ...option.Option) (int64, int64, error) {
+	valueAt, count, err := io.Values(any)
+	if err != nil || count == 0 {
+		return 0, 0, err
+	}
+	batchSize := option.Options(options).BatchSize()
+	record := valueAt(0)
+	var sess *session
+	if sess, err = s.ensureSession(record, batchSize, options...); err != nil {
+		return 0, 0, err
+	}
+	sess.interceptor = sess.InterceptorFor(options, showSQL)
+	if err = sess.begin(ctx, sess.db, options); err != nil {
+		return 0, 0, err
+	}
+	if err = sess.prepare(ctx, sess.batchSize); err != nil {
+		return 0, 0, sess.end(err)
+	}
+	inserted, updated, err := sess.merge(ctx, valueAt, count)
+	if err = sess.end(err); err == nil && inserted+updated > 0 {
+		sess.InvalidateCache(ctx, sess.Transaction, options)
+	}
+	return inserted, updated, err
+}
+
+func (s *Service) ensureSession(record interface{}, batchSize int, options ...option.Option) (*session, error) {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+	rType := reflect.TypeOf(record)
+	if !s.Dialect.Insert.MultiValues() || s.Dialect.Upsert == dialect.UpsertTypeUpdateOrInsert {
+		batchSize = 1
+	}
+	var db sqlx.Executor = s.db
+	if optionDb := option.Options(options).Db(); optionDb != nil {
+		db = optionDb
+	}
+	if sess := s.initSession; sess != nil && sess.rType == rType && sess.batchSize == batchSize {
+		return &session{
+			rType:         rType,
+			batchSize:     sess.batchSize,
+			Config:        s.Config,
+			binder:        sess.binder,
+			columns:       sess.columns,
+			identityIndex: sess.identityIndex,
+			builder:       sess.builder,
+			db:            db,
+		}, nil
+	}
+	result := &session{
+		rType:     rType,
+		Config:    s.Config,
+		batchSize: batchSize,
+		db:        db,
+	}
+	err := result.init(record)
+	if err == nil {
+		s.initSession = result
+	}
+	return result, err
+}
+
+//New creates a merger
+func New(ctx context.Context, db sqlx.Executor, tableName string, options ...option.Option) (*Service, error) {
+	merger := &Service{
+		Config: config.New(tableName),
+		db:     db,
+	}
+	err := merger.ApplyOption(ctx, db, options...)
+	return merger, err
+}
@@ -0,0 +1,145 @@
+package merge_test
+
+import (
+	"context"
+	"database/sql"
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/assert"
+	"github.com/viant/sqlx/io/merge"
+	"github.com/viant/sqlx/io/read"
+	_ "github.com/viant/sqlx/metadata/product/sqlite"
+	"github.com/viant/sqlx/option"
+	"testing"
+)
+
+func TestService_Exec(t *testing.T) {
+
+	type entity struct {
+		ID   int    `sqlx:"name=foo_id,primaryKey=true"`
+		Name string `sqlx:"foo_name"`
+		Desc string `sqlx:"-"`
+		Bar  float64
+	}
+
+	type tenantEntity struct {
+		TenantID int    `sqlx:"name=tenant_id,primaryKey=true"`
+		ID       int    `sqlx:"name=foo_id,primaryKey=true"`
+		Name     string `sqlx:"foo_name"`
+	}
+
+	var useCases = []struct {
+		description string
+		table       string
+		driver      string
+		dsn         string
+		options     []option.Option
+		records     interface{}
+		initSQL     []string
+		inserted    int64
+		updated     int64
+		expect      map[int]string
+	}{
+		{
+			description: "merge new and existing records",
+			driver:      "sqlite3",
+			dsn:         "/tmp/sqllite.db",
+			table:       "m1",
+			initSQL: []string{
+				"DROP TABLE IF EXISTS m1",
+				"CREATE TABLE m1 (foo_id INTEGER PRIMARY KEY, foo_name TEXT, bar DECIMAL)",
+				"INSERT INTO m1 (foo_id, foo_name) VALUES(1, 'old 1')",
+				"INSERT INTO m1 (foo_id, foo_name) VALUES(2, 'old 2')",
+			},
+			records: []*entity{
+				{ID: 1, Name: "John1", Bar: 17},
+				{ID: 2, Name: "John2", Bar: 18},
+				{ID: 3, Name: "John3", Bar: 19},
+			},
+			inserted: 1,
+			updated:  2,
+			expect:   map[int]string{1: "John1", 2: "John2", 3: "John3"},
+		},
+		{
+			description: "merge with batch size: 2",
+			driver:      "sqlite3",
+			dsn:         "/tmp/sqllite.db",
+			table:       "m2",
+			initSQL: []string{
+				"DROP TABLE IF EXISTS m2",
+				"CREATE TABLE m2 (foo_id INTEGER PRIMARY KEY, foo_name TEXT, bar DECIMAL)",
+				"INSERT INTO m2 (foo_id, foo_name) VALUES(3, 'old 3')",
+			},
+			records: []entity{
+				{ID: 1, Name: "John1", Bar: 17},
+				{ID: 2, Name: "John2", Bar: 18},
+				{ID: 3, Name: "John3", Bar: 19},
+			},
+			options: []option.Option{
+				option.BatchSize(2),
+			},
+			inserted: 2,
+			updated:  1,
+			expect:   map[int]string{1: "John1", 2: "John2", 3: "John3"},
+		},
+		{
+			description: "merge with composite key",
+			driver:      "sqlite3",
+			dsn:         "/tmp/sqllite.db",
+			table:       "m3",
+			initSQL: []string{
+				"DROP TABLE IF EXISTS m3",
+				"CREATE TABLE m3 (tenant_id INTEGER, foo_id INTEGER, foo_name TEXT, PRIMARY KEY(tenant_id, foo_id))",
+				"INSERT INTO m3 (tenant_id, foo_id, foo_name) VALUES(1, 1, 'old 1')",
+				"INSERT INTO m3 (tenant_id, foo_id, foo_name) VALUES(2, 1, 'other tenant')",
+			},
+			records: []*tenantEntity{
+				{TenantID: 1, ID: 1, Name: "John1"},
+				{TenantID: 1, ID: 2, Name: "John2"},
+			},
+			options: []option.Option{
+				option.BatchSize(2),
+			},
+			inserted: 1,
+			updated:  1,
+		},
+	}
+
+outer:
+	for _, testCase := range useCases {
+		db, err := sql.Open(testCase.driver, testCase.dsn)
+		if !assert.Nil(t, err, testCase.description) {
+			continue
+		}
+		for _, SQL := range testCase.initSQL {
+			_, err := db.Exec(SQL)
+			if !assert.Nil(t, err, testCase.description) {
+				continue outer
+			}
+		}
+		merger, err := merge.New(context.TODO(), db, testCase.table, testCase.options...)
+		if !assert.Nil(t, err, testCase.description) {
+			continue
+		}
+		inserted, updated, err := merger.Exec(context.TODO(), testCase.records, testCase.options...)
+		if !assert.Nil(t, err, testCase.description) {
+			continue
+		}
+		assert.EqualValues(t, testCase.inserted, inserted, testCase.description)
+		assert.EqualValues(t, testCase.updated, updated, testCase.description)
+		if testCase.expect == nil {
+			continue
+		}
+		reader, err := read.New(context.TODO(), db, "SELECT foo_id, foo_name FROM "+testCase.table, func() interface{} { return &entity{} })
+		if !assert.Nil(t, err, testCase.description) {
+			continue
+		}
+		var actual = map[int]string{}
+		err = reader.QueryAll(context.TODO(), func(row interface{}) error {
+			record := row.(*entity)
+			actual[record.ID] = record.Name
+			return nil
+		})
+		assert.Nil(t, err, testCase.description)
+		assert.EqualValues(t, testCase.expect, actual, testCase.description)
+	}
+}
@@ -0,0 +1,234 @@
+package merge
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"github.com/viant/sqlx"
+	"github.com/viant/sqlx/io"
+	"github.com/viant/sqlx/io/config"
+	"github.com/viant/sqlx/option"
+	"reflect"
+	"strings"
+)
+
+type session struct {
+	*io.Transaction
+	rType     reflect.Type
+	batchSize int
+	*config.Config
+	binder        io.PlaceholderBinder
+	columns       io.Columns
+	identityIndex int
+	builder       *Builder
+	db            sqlx.Executor
+	stmt          *sql.Stmt
+	SQL           string //prepared statement SQL
+	interceptor   sqlx.Interceptor
+}
+
+func (s *session) init(record interface{}) (err error) {
+	if s.columns, s.binder, err = s.Mapper(record, s.TagName); err != nil {
+		return err
+	}
+	if s.identityIndex = s.columns.PrimaryKeys(); s.identityIndex == -1 {
+		return fmt.Errorf("failed to merge %v: primary key was missing in %v", s.TableName, s.rType)
+	}
+	s.builder, err = NewBuilder(s.TableName, s.columns.Names(), s.identityIndex, s.Dialect, s.batchSize)
+	return err
+}
+
+func (s *session) begin(ctx context.Context, db sqlx.Executor, options []option.Option) error {
+	var err error
+	s.Transaction, err = io.TransactionFor(ctx, s.Dialect, db, options)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+func (s *session) prepare(ctx context.Context, batchSize int) error {
+	SQL := s.builder.Build(nil, option.BatchSize(batchSize))
+	var err error
+	if s.stmt != nil {
+		if err = s.stmt.Close(); err != nil {
+			return fmt.Errorf("failed to close stetement: %w", err)
+		}
+	}
+	s.SQL = SQL
+	if s.Transaction != nil {
+		s.stmt, err = s.Transaction.Prepare(SQL)
+		return err
+	}
+	s.stmt, err = s.db.PrepareContext(ctx, SQL)
+	return err
+}
+
+func (s *session) merge(ctx context.Context, valueAt io.ValueAccessor, count int) (int64, int64, error) {
+	var recValues = make([]interface{}, s.batchSize*len(s.columns))
+	var totalInserted, totalUpdated int64
+	inBatchCount := 0
+	for i := 0; i < count; i++ {
+		offset := inBatchCount * len(s.columns)
+		s.binder(valueAt(i), recValues[offset:], 0, len(s.columns))
+		inBatchCount++
+		if inBatchCount == s.batchSize {
+			inserted, updated, err := s.flush(ctx, recValues, inBatchCount)
+			if err != nil {
+				return 0, 0, err
+			}
+			totalInserted += inserted
+			totalUpdated += updated
+			inBatchCount = 0
+		}
+	}
+
+	if inBatchCount > 0 { //overflow
+		if err := s.prepare(ctx, inBatchCount); err != nil {
+			return 0, 0, err
+		}
+		inserted, updated, err := s.flush(ctx, recValues[0:inBatchCount*len(s.columns)], inBatchCount)
+		if err != nil {
+			return 0, 0, err
+		}
+		totalInserted += inserted
+		totalUpdated += updated
+	}
+	return totalInserted, totalUpdated, nil
+}
+
+//flush runs upsert, inserted and updated records are counted with rows returned or affected by the statement,
+//otherwise already existing records are counted before upsert, records that were not inserted are reported as updated
+func (s *session) flush(ctx context.Context, values []interface{}, batchSize int) (int64, int64, error) {
+	switch s.builder.countMode {
+	case countReturned:
+		inserted, err := s.queryInserted(ctx, values)
+		if err != nil {
+			return 0, 0, err
+		}
+		return inserted, int64(batchSize) - inserted, nil
+	case countAffected:
+		result, err := s.exec(ctx, values)
+		if err != nil {
+			return 0, 0, err
+		}
+		affected, err := result.RowsAffected()
+		if err != nil {
+			return 0, 0, err
+		}
+		inserted := insertedByAffected(affected, int64(batchSize))
+		return inserted, int64(batchSize) - inserted, nil
+	}
+	existing, err := s.countExisting(ctx, values, batchSize)
+	if err != nil {
+		return 0, 0, err
+	}
+	if _, err = s.exec(ctx, values); err != nil {
+		return 0, 0, err
+	}
+	return int64(batchSize) - existing, existing, nil
+}
+
+//exec executes prepared upsert statement with session interceptor
+func (s *session) exec(ctx context.Context, values []interface{}) (sql.Result, error) {
+	return sqlx.InterceptExec(ctx, s.interceptor, s.SQL, values, func(ctx context.Context) (sql.Result, error) {
+		return s.stmt.ExecContext(ctx, values...)
+	})
+}
+
+//queryInserted executes prepared upsert statement returning inserted flag or action per record, returns inserted records count
+func (s *session) queryInserted(ctx context.Context, values []interface{}) (inserted int64, err error) {
+	_, err = sqlx.InterceptExec(ctx, s.interceptor, s.SQL, values, func(ctx context.Context) (result sql.Result, err error) {
+		rows, err := s.stmt.QueryContext(ctx, values...)
+		if err != nil {
+			return nil, err
+		}
+		defer io.RunWithError(rows.Close, &err)
+		for rows.Next() {
+			var value interface{}
+			if err = rows.Scan(&value); err != nil {
+				return nil, err
+			}
+			if isInserted(value) {
+				inserted++
+			}
+		}
+		return nil, rows.Err()
+	})
+	return inserted, err
+}
+
+//isInserted returns true for inserted flag or INSERT action
+func isInserted(value interface{}) bool {
+	switch actual := value.(type) {
+	case bool:
+		return actual
+	case int64:
+		return actual != 0
+	case string:
+		return strings.EqualFold(actual, "INSERT")
+	case []byte:
+		return strings.EqualFold(string(actual), "INSERT")
+	}
+	return false
+}
+
+//insertedByAffected returns inserted records count for rows affected reporting one row per inserted, two per updated and none per unchanged record,
+//count is exact unless batch mixes inserted, updated and unchanged records
+func insertedByAffected(affected, batchSize int64) int64 {
+	diff := affected - batchSize
+	if diff < 0 {
+		diff = -diff
+	}
+	if inserted := batchSize - diff; inserted > 0 {
+		return inserted
+	}
+	return 0
+}
+
+func (s *session) countExisting(ctx context.Context, values []interface{}, batchSize int) (int64, error) {
+	columnCount := len(s.columns)
+	var identities = make([]interface{}, 0, batchSize*(columnCount-s.identityIndex))
+	for i := 0; i < batchSize; i++ {
+		offset := i * columnCount
+		identities = append(identities, values[offset+s.identityIndex:offset+columnCount]...)
+	}
+	SQL := s.builder.CountSQL(batchSize)
+	rows, err := sqlx.InterceptQuery(ctx, s.interceptor, SQL, identities, func(ctx context.Context) (*sql.Rows, error) {
+		if s.Transaction != nil {
+			return s.Transaction.QueryContext(ctx, SQL, identities...)
+		}
+		return s.db.QueryContext(ctx, SQL, identities...)
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to count existing %v records: %w", s.TableName, err)
+	}
+	defer rows.Close()
+	var count int64
+	if rows.Next() {
+		err = rows.Scan(&count)
+	}
+	if err == nil {
+		err = rows.Err()
+	}
+	if err != nil {
+		return 0, fmt.Errorf("failed to count existing %v records: %w", s.TableName, err)
+	}
+	return count, nil
+}
+
+func (s *session) end(err error) error {
+	if s.stmt != nil {
+		if sErr := s.stmt.Close(); sErr != nil {
+			err = fmt.Errorf("%w, %v", sErr, err)
+		}
+		s.stmt = nil
+	}
+	if s.Transaction == nil {
+		return err
+	}
+	if err != nil {
+		return s.Transaction.RollbackWithErr(err)
+	}
+	return s.Transaction.Commit()
+}
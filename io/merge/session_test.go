@@ -0,0 +1,40 @@
+package merge
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestInsertedByAffected(t *testing.T) {
+	var testCases = []struct {
+		description string
+		affected    int64
+		batchSize   int64
+		expect      int64
+	}{
+		{description: "all inserted", affected: 3, batchSize: 3, expect: 3},
+		{description: "all updated", affected: 6, batchSize: 3, expect: 0},
+		{description: "all unchanged", affected: 0, batchSize: 3, expect: 0},
+		{description: "inserted and updated", affected: 4, batchSize: 3, expect: 2},
+		{description: "inserted and unchanged", affected: 1, batchSize: 3, expect: 1},
+	}
+	for _, testCase := range testCases {
+		assert.EqualValues(t, testCase.expect, insertedByAffected(testCase.affected, testCase.batchSize), testCase.description)
+	}
+}
+
+func TestIsInserted(t *testing.T) {
+	var testCases = []struct {
+		description string
+		value       interface{}
+		expect      bool
+	}{
+		{description: "inserted flag", value: true, expect: true},
+		{description: "updated flag", value: false},
+		{description: "insert action", value: "INSERT", expect: true},
+		{description: "update action", value: []byte("UPDATE")},
+	}
+	for _, testCase := range testCases {
+		assert.EqualValues(t, testCase.expect, isInserted(testCase.value), testCase.description)
+	}
+}
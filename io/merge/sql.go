@@ -0,0 +1,259 @@
+package merge
+
+import (
+	"fmt"
+	"github.com/viant/sqlx/metadata/info"
+	"github.com/viant/sqlx/metadata/info/dialect"
+	"github.com/viant/sqlx/option"
+	"strings"
+)
+
+const (
+	columnSeparator = ","
+	targetAlias     = "dst"
+	sourceAlias     = "src"
+	outputAction    = " OUTPUT $action"
+)
+
+const (
+	//countExisting represents existing records counted before upsert within the same transaction, counts are approximate
+	//as concurrent transaction can modify records in between
+	countExisting countMode = iota
+	//countReturned represents upsert returning row per inserted or updated record, i.e. PostgreSQL RETURNING (xmax = 0), SQL Server OUTPUT $action
+	countReturned
+	//countAffected represents upsert reporting one affected row per inserted and two per updated record, i.e. MySQL ON DUPLICATE KEY UPDATE
+	countAffected
+)
+
+type (
+	//countMode represents the way inserted and updated records are counted
+	countMode int
+
+	//Builder represent merge (upsert) DML builder
+	Builder struct {
+		table         string
+		columns       []string
+		identityIndex int
+		dialect       *info.Dialect
+		batchSize     int
+		sql           string
+		countMode     countMode
+	}
+)
+
+//Build builds upsert statement for supplied batch size option
+func (b *Builder) Build(record interface{}, options ...option.Option) string {
+	batchSize := option.Options(options).BatchSize()
+	if batchSize == b.batchSize {
+		return b.sql
+	}
+	return b.build(batchSize)
+}
+
+//CountSQL returns SQL counting already existing records for supplied batch size, identity values are used as parameters
+func (b *Builder) CountSQL(batchSize int) string {
+	identities := b.columns[b.identityIndex:]
+	getter := b.dialect.PlaceholderGetter()
+	sb := strings.Builder{}
+	sb.WriteString("SELECT COUNT(1) FROM ")
+	sb.WriteString(b.table)
+	sb.WriteString(" WHERE ")
+	if len(identities) == 1 {
+		sb.WriteString(identities[0])
+		sb.WriteString(" IN (")
+		for i := 0; i < batchSize; i++ {
+			if i > 0 {
+				sb.WriteString(columnSeparator)
+			}
+			sb.WriteString(getter())
+		}
+		sb.WriteString(")")
+		return sb.String()
+	}
+	for i := 0; i < batchSize; i++ {
+		if i > 0 {
+			sb.WriteString(" OR ")
+		}
+		sb.WriteString("(")
+		for j, column := range identities {
+			if j > 0 {
+				sb.WriteString(" AND ")
+			}
+			sb.WriteString(column)
+			sb.WriteString(" = ")
+			sb.WriteString(getter())
+		}
+		sb.WriteString(")")
+	}
+	return sb.String()
+}
+
+func (b *Builder) build(batchSize int) string {
+	switch b.dialect.Upsert {
+	case dialect.UpsertTypeMerge, dialect.UpsertTypeMergeInto:
+		return b.mergeInto(batchSize)
+	case dialect.UpsertTypeInsertOrReplace:
+		return b.insert("INSERT OR REPLACE INTO ", batchSize)
+	case dialect.UpsertTypeUpdateOrInsert:
+		return b.insert("UPDATE OR INSERT INTO ", batchSize) + " MATCHING (" + strings.Join(b.identities(), columnSeparator) + ")"
+	case dialect.UpsertTypeInsertOrUpdate:
+		return b.insert("INSERT INTO ", batchSize) + " ON DUPLICATE KEY UPDATE " + b.assignments("VALUES(", ")")
+	case dialect.UpsertTypeInsertOnConflict:
+		SQL := b.insert("INSERT INTO ", batchSize) + " ON CONFLICT(" + strings.Join(b.identities(), columnSeparator) + ")"
+		if b.identityIndex == 0 {
+			SQL += " DO NOTHING"
+		} else {
+			SQL += " DO UPDATE SET " + b.assignments("excluded.", "")
+		}
+		if b.countMode == countReturned {
+			SQL += " RETURNING " + b.dialect.UpsertInserted
+		}
+		return SQL
+	}
+	return ""
+}
+
+func (b *Builder) identities() []string {
+	return b.columns[b.identityIndex:]
+}
+
+func (b *Builder) insert(prefix string, batchSize int) string {
+	getter := b.dialect.PlaceholderGetter()
+	sb := strings.Builder{}
+	sb.WriteString(prefix)
+	sb.WriteString(b.table)
+	sb.WriteString("(")
+	sb.WriteString(strings.Join(b.columns, columnSeparator))
+	sb.WriteString(") VALUES ")
+	for i := 0; i < batchSize; i++ {
+		if i > 0 {
+			sb.WriteString(columnSeparator)
+		}
+		sb.WriteString("(")
+		for j := range b.columns {
+			if j > 0 {
+				sb.WriteString(columnSeparator)
+			}
+			sb.WriteString(getter())
+		}
+		sb.WriteString(")")
+	}
+	return sb.String()
+}
+
+//assignments returns update assignments, when all columns are identities, identity is assigned to itself
+func (b *Builder) assignments(valuePrefix, valueSuffix string) string {
+	columns := b.columns[:b.identityIndex]
+	if len(columns) == 0 {
+		columns = b.identities()[:1]
+		return columns[0] + " = " + columns[0]
+	}
+	var fragments = make([]string, len(columns))
+	for i, column := range columns {
+		fragments[i] = column + " = " + valuePrefix + column + valueSuffix
+	}
+	return strings.Join(fragments, ", ")
+}
+
+func (b *Builder) mergeInto(batchSize int) string {
+	getter := b.dialect.PlaceholderGetter()
+	sb := strings.Builder{}
+	sb.WriteString("MERGE INTO ")
+	sb.WriteString(b.table)
+	sb.WriteString(" " + targetAlias + " USING (")
+	for i := 0; i < batchSize; i++ {
+		if i > 0 {
+			sb.WriteString(" UNION ALL ")
+		}
+		sb.WriteString("SELECT ")
+		for j, column := range b.columns {
+			if j > 0 {
+				sb.WriteString(", ")
+			}
+			sb.WriteString(getter())
+			if i == 0 {
+				sb.WriteString(" AS ")
+				sb.WriteString(column)
+			}
+		}
+		if b.dialect.DualTable != "" {
+			sb.WriteString(" FROM ")
+			sb.WriteString(b.dialect.DualTable)
+		}
+	}
+	sb.WriteString(") " + sourceAlias + " ON (")
+	for i, column := range b.identities() {
+		if i > 0 {
+			sb.WriteString(" AND ")
+		}
+		sb.WriteString(targetAlias + "." + column + " = " + sourceAlias + "." + column)
+	}
+	sb.WriteString(")")
+	if b.identityIndex > 0 {
+		sb.WriteString(" WHEN MATCHED THEN UPDATE SET ")
+		sb.WriteString(b.assignments(sourceAlias+".", ""))
+	}
+	sb.WriteString(" WHEN NOT MATCHED THEN INSERT (")
+	sb.WriteString(strings.Join(b.columns, columnSeparator))
+	sb.WriteString(") VALUES (")
+	for i, column := range b.columns {
+		if i > 0 {
+			sb.WriteString(columnSeparator)
+		}
+		sb.WriteString(sourceAlias + "." + column)
+	}
+	sb.WriteString(")")
+	if b.countMode == countReturned {
+		sb.WriteString(outputAction)
+	}
+	sb.WriteString(b.dialect.StatementTerminator)
+	return sb.String()
+}
+
+//NewBuilder return merge builder, columns starting from identityIndex are used to match existing records
+func NewBuilder(table string, columns []string, identityIndex int, aDialect *info.Dialect, batchSize int) (*Builder, error) {
+	if len(columns) == 0 {
+		return nil, fmt.Errorf("columns were empty")
+	}
+	if identityIndex < 0 || identityIndex >= len(columns) {
+		return nil, fmt.Errorf("identity index was empty")
+	}
+	result := &Builder{
+		table:         table,
+		columns:       columns,
+		identityIndex: identityIndex,
+		dialect:       aDialect,
+		batchSize:     batchSize,
+		countMode:     countModeOf(aDialect),
+	}
+	if result.sql = result.build(batchSize); result.sql == "" {
+		return nil, fmt.Errorf("unsupported upsert type: %v for %v", aDialect.Upsert, aDialect.Name)
+	}
+	return result, nil
+}
+
+//countModeOf returns the way dialect upsert statement inserted and updated records are counted
+func countModeOf(aDialect *info.Dialect) countMode {
+	switch aDialect.Upsert {
+	case dialect.UpsertTypeInsertOrUpdate:
+		return countAffected
+	case dialect.UpsertTypeInsertOnConflict:
+		if aDialect.UpsertInserted != "" {
+			return countReturned
+		}
+	case dialect.UpsertTypeMerge, dialect.UpsertTypeMergeInto:
+		if aDialect.CanOutputInserted {
+			return countReturned
+		}
+	}
+	return countExisting
+}
+
+var showSQL bool
+
+//ShowSQL prints executed SQL to stdout
+//
+//Deprecated: use sqlx.SQLPrinter or any other sqlx.Interceptor option
+func ShowSQL(b bool) {
+	showSQL = b
+}
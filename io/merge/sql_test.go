@@ -0,0 +1,126 @@
+package merge
+
+import (
+	"github.com/stretchr/testify/assert"
+	"github.com/viant/sqlx/metadata/info"
+	"github.com/viant/sqlx/metadata/info/dialect"
+	"github.com/viant/sqlx/option"
+	"testing"
+)
+
+func TestMerge_Build(t *testing.T) {
+
+	var testCases = []struct {
+		description   string
+		table         string
+		columns       []string
+		identityIndex int
+		dialect       *info.Dialect
+		batchSize     int
+		callBatchSize int
+		expect        string
+		expectCount   string
+	}{
+		{
+			description:   "insert or update",
+			table:         "foo",
+			columns:       []string{"c1", "c2", "id"},
+			identityIndex: 2,
+			dialect:       &info.Dialect{Upsert: dialect.UpsertTypeInsertOrUpdate},
+			batchSize:     2,
+			callBatchSize: 2,
+			expect:        "INSERT INTO foo(c1,c2,id) VALUES (?,?,?),(?,?,?) ON DUPLICATE KEY UPDATE c1 = VALUES(c1), c2 = VALUES(c2)",
+			expectCount:   "SELECT COUNT(1) FROM foo WHERE id IN (?,?)",
+		},
+		{
+			description:   "insert on conflict with smaller batch",
+			table:         "foo",
+			columns:       []string{"c1", "id"},
+			identityIndex: 1,
+			dialect:       &info.Dialect{Upsert: dialect.UpsertTypeInsertOnConflict},
+			batchSize:     3,
+			callBatchSize: 1,
+			expect:        "INSERT INTO foo(c1,id) VALUES (?,?) ON CONFLICT(id) DO UPDATE SET c1 = excluded.c1",
+			expectCount:   "SELECT COUNT(1) FROM foo WHERE id IN (?)",
+		},
+		{
+			description:   "insert on conflict identity only",
+			table:         "foo",
+			columns:       []string{"id"},
+			identityIndex: 0,
+			dialect:       &info.Dialect{Upsert: dialect.UpsertTypeInsertOnConflict},
+			batchSize:     1,
+			callBatchSize: 1,
+			expect:        "INSERT INTO foo(id) VALUES (?) ON CONFLICT(id) DO NOTHING",
+			expectCount:   "SELECT COUNT(1) FROM foo WHERE id IN (?)",
+		},
+		{
+			description:   "insert on conflict returning inserted flag",
+			table:         "foo",
+			columns:       []string{"c1", "id"},
+			identityIndex: 1,
+			dialect:       &info.Dialect{Upsert: dialect.UpsertTypeInsertOnConflict, UpsertInserted: "(xmax = 0)"},
+			batchSize:     2,
+			callBatchSize: 2,
+			expect:        "INSERT INTO foo(c1,id) VALUES (?,?),(?,?) ON CONFLICT(id) DO UPDATE SET c1 = excluded.c1 RETURNING (xmax = 0)",
+			expectCount:   "SELECT COUNT(1) FROM foo WHERE id IN (?,?)",
+		},
+		{
+			description:   "insert or replace",
+			table:         "foo",
+			columns:       []string{"c1", "id"},
+			identityIndex: 1,
+			dialect:       &info.Dialect{Upsert: dialect.UpsertTypeInsertOrReplace},
+			batchSize:     1,
+			callBatchSize: 1,
+			expect:        "INSERT OR REPLACE INTO foo(c1,id) VALUES (?,?)",
+			expectCount:   "SELECT COUNT(1) FROM foo WHERE id IN (?)",
+		},
+		{
+			description:   "merge into with composite key",
+			table:         "foo",
+			columns:       []string{"c1", "tenant_id", "id"},
+			identityIndex: 1,
+			dialect:       &info.Dialect{Upsert: dialect.UpsertTypeMergeInto, StatementTerminator: ";"},
+			batchSize:     2,
+			callBatchSize: 2,
+			expect:        "MERGE INTO foo dst USING (SELECT ? AS c1, ? AS tenant_id, ? AS id UNION ALL SELECT ?, ?, ?) src ON (dst.tenant_id = src.tenant_id AND dst.id = src.id) WHEN MATCHED THEN UPDATE SET c1 = src.c1 WHEN NOT MATCHED THEN INSERT (c1,tenant_id,id) VALUES (src.c1,src.tenant_id,src.id);",
+			expectCount:   "SELECT COUNT(1) FROM foo WHERE (tenant_id = ? AND id = ?) OR (tenant_id = ? AND id = ?)",
+		},
+		{
+			description:   "merge into with output action",
+			table:         "foo",
+			columns:       []string{"c1", "id"},
+			identityIndex: 1,
+			dialect:       &info.Dialect{Upsert: dialect.UpsertTypeMergeInto, StatementTerminator: ";", CanOutputInserted: true},
+			batchSize:     1,
+			callBatchSize: 1,
+			expect:        "MERGE INTO foo dst USING (SELECT ? AS c1, ? AS id) src ON (dst.id = src.id) WHEN MATCHED THEN UPDATE SET c1 = src.c1 WHEN NOT MATCHED THEN INSERT (c1,id) VALUES (src.c1,src.id) OUTPUT $action;",
+			expectCount:   "SELECT COUNT(1) FROM foo WHERE id IN (?)",
+		},
+		{
+			description:   "merge into from dual",
+			table:         "foo",
+			columns:       []string{"c1", "id"},
+			identityIndex: 1,
+			dialect:       &info.Dialect{Upsert: dialect.UpsertTypeMergeInto, DualTable: "DUAL"},
+			batchSize:     1,
+			callBatchSize: 1,
+			expect:        "MERGE INTO foo dst USING (SELECT ? AS c1, ? AS id FROM DUAL) src ON (dst.id = src.id) WHEN MATCHED THEN UPDATE SET c1 = src.c1 WHEN NOT MATCHED THEN INSERT (c1,id) VALUES (src.c1,src.id)",
+			expectCount:   "SELECT COUNT(1) FROM foo WHERE id IN (?)",
+		},
+	}
+
+	for _, testCase := range testCases {
+		builder, err := NewBuilder(testCase.table, testCase.columns, testCase.identityIndex, testCase.dialect, testCase.batchSize)
+		if !assert.Nil(t, err, testCase.description) {
+			continue
+		}
+		actual := builder.Build(nil, option.BatchSize(testCase.callBatchSize))
+		assert.EqualValues(t, testCase.expect, actual, testCase.description)
+		assert.EqualValues(t, testCase.expectCount, builder.CountSQL(testCase.callBatchSize), testCase.description)
+	}
+
+	_, err := NewBuilder("foo", []string{"id"}, 0, &info.Dialect{Upsert: dialect.UpsertTypeUnsupported}, 1)
+	assert.NotNil(t, err, "unsupported upsert")
+}
@@ -0,0 +1,271 @@
+package query
+
+import (
+	"context"
+	"fmt"
+	"github.com/viant/sqlx"
+	"github.com/viant/sqlx/io"
+	"github.com/viant/sqlx/io/read"
+	"github.com/viant/sqlx/metadata/info"
+	"github.com/viant/sqlx/metadata/info/dialect"
+	"github.com/viant/sqlx/metadata/info/placeholder"
+	"github.com/viant/sqlx/metadata/registry"
+	"github.com/viant/sqlx/option"
+	"github.com/viant/xunsafe"
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+const (
+	columnSeparator = ", "
+	//noOrder is required by SQL Server OFFSET FETCH clause when no order was specified
+	noOrder = "(SELECT NULL)"
+)
+
+//Builder represents dialect aware SELECT statement builder, selected columns are derived from record struct
+type Builder struct {
+	table     string
+	newRow    func() interface{}
+	columns   io.Columns
+	binder    io.PlaceholderBinder
+	setMarker *option.SetMarker
+	dialect   *info.Dialect
+	where     []string
+	args      []interface{}
+	groupBy   []string
+	orderBy   []string
+	limit     int
+	offset    int
+	err       error
+}
+
+//Where adds criterion with '?' placeholders bound to args, criteria are joined with AND, slice args are expanded by read.Reader
+func (b *Builder) Where(criterion string, args ...interface{}) *Builder {
+	if placeholders := len(placeholder.Positions(criterion)); placeholders != len(args) && b.err == nil {
+		b.err = fmt.Errorf("invalid criterion: %v, expected %v args, but had %v", criterion, placeholders, len(args))
+	}
+	b.where = append(b.where, criterion)
+	b.args = append(b.args, args...)
+	return b
+}
+
+//Example adds equality criteria for record columns marked with option.SetMarker, or for non-zero values if record has no set marker
+func (b *Builder) Example(record interface{}) *Builder {
+	if reflect.TypeOf(record) != reflect.TypeOf(b.newRow()) {
+		if b.err == nil {
+			b.err = fmt.Errorf("invalid example type: %T, expected: %T", record, b.newRow())
+		}
+		return b
+	}
+	values := make([]interface{}, len(b.columns))
+	b.binder(record, values, 0, len(values))
+	presenceAware := b.setMarker != nil && b.setMarker.Marker != nil
+	ptr := xunsafe.AsPointer(record)
+	for i, value := range values {
+		if presenceAware && !b.setMarker.IsSet(ptr, i) {
+			continue
+		}
+		value, isZero := fieldValue(value)
+		if !presenceAware && isZero {
+			continue
+		}
+		b.where = append(b.where, b.dialect.QuoteIdentifier(b.columns[i].Name())+" = "+placeholder.Default)
+		b.args = append(b.args, value)
+	}
+	return b
+}
+
+//GroupBy adds group by expressions
+func (b *Builder) GroupBy(expressions ...string) *Builder {
+	b.groupBy = append(b.groupBy, expressions...)
+	return b
+}
+
+//OrderBy adds order by expressions, i.e. "name DESC"
+func (b *Builder) OrderBy(expressions ...string) *Builder {
+	b.orderBy = append(b.orderBy, expressions...)
+	return b
+}
+
+//Limit sets max rows count, 0 means no limit
+func (b *Builder) Limit(limit int) *Builder {
+	b.limit = limit
+	return b
+}
+
+//Offset sets number of rows to skip
+func (b *Builder) Offset(offset int) *Builder {
+	b.offset = offset
+	return b
+}
+
+//Build returns SELECT statement with dialect placeholders and its args
+func (b *Builder) Build() (string, []interface{}, error) {
+	SQL, err := b.build(b.dialect)
+	if err != nil {
+		return "", nil, err
+	}
+	if b.dialect != nil {
+		SQL = b.dialect.EnsurePlaceholders(SQL)
+	}
+	return SQL, b.args, nil
+}
+
+//Reader returns read.Reader for built statement and its args, dialect is matched with db if it was not specified
+func (b *Builder) Reader(ctx context.Context, db sqlx.Executor, options ...option.Option) (*read.Reader, []interface{}, error) {
+	aDialect := b.dialect
+	if aDialect == nil {
+		if product := registry.MatchProduct(db); product != nil {
+			aDialect = registry.LookupDialect(product)
+		}
+	}
+	SQL, err := b.build(aDialect)
+	if err != nil {
+		return nil, nil, err
+	}
+	if aDialect != nil && option.Options(options).Dialect() == nil {
+		options = append(options, aDialect)
+	}
+	reader, err := read.New(ctx, db, SQL, b.newRow, options...)
+	if err != nil {
+		return nil, nil, err
+	}
+	return reader, b.args, nil
+}
+
+//QueryAll runs built statement with read.Reader, emit is called for each row
+func (b *Builder) QueryAll(ctx context.Context, db sqlx.Executor, emit func(row interface{}) error, options ...option.Option) error {
+	reader, args, err := b.Reader(ctx, db, options...)
+	if err != nil {
+		return err
+	}
+	defer reader.Close()
+	return reader.QueryAll(ctx, emit, args...)
+}
+
+//build returns SELECT statement with '?' placeholders
+func (b *Builder) build(aDialect *info.Dialect) (string, error) {
+	if b.err != nil {
+		return "", b.err
+	}
+	pagination := dialect.PaginationLimitOffset
+	if aDialect != nil {
+		pagination = aDialect.Pagination
+	}
+	sb := strings.Builder{}
+	sb.WriteString("SELECT ")
+	if pagination == dialect.PaginationTop && b.limit > 0 && b.offset == 0 {
+		sb.WriteString("TOP ")
+		sb.WriteString(strconv.Itoa(b.limit))
+		sb.WriteString(" ")
+	}
+	for i, column := range b.columns {
+		if i > 0 {
+			sb.WriteString(columnSeparator)
+		}
+		sb.WriteString(aDialect.QuoteIdentifier(column.Name()))
+	}
+	sb.WriteString(" FROM ")
+	sb.WriteString(aDialect.QuoteIdentifier(b.table))
+	for i, criterion := range b.where {
+		if i == 0 {
+			sb.WriteString(" WHERE ")
+		} else {
+			sb.WriteString(" AND ")
+		}
+		if len(b.where) > 1 {
+			criterion = "(" + criterion + ")"
+		}
+		sb.WriteString(criterion)
+	}
+	if len(b.groupBy) > 0 {
+		sb.WriteString(" GROUP BY ")
+		sb.WriteString(strings.Join(b.groupBy, columnSeparator))
+	}
+	orderBy := b.orderBy
+	if len(orderBy) == 0 && pagination == dialect.PaginationTop && b.offset > 0 {
+		orderBy = []string{noOrder}
+	}
+	if len(orderBy) > 0 {
+		sb.WriteString(" ORDER BY ")
+		sb.WriteString(strings.Join(orderBy, columnSeparator))
+	}
+	switch pagination {
+	case dialect.PaginationLimitOffset:
+		limit := b.limit
+		if limit == 0 && b.offset > 0 { //OFFSET requires LIMIT i.e. for MySQL and SQLite
+			limit = math.MaxInt
+		}
+		if limit > 0 {
+			sb.WriteString(" LIMIT ")
+			sb.WriteString(strconv.Itoa(limit))
+		}
+		if b.offset > 0 {
+			sb.WriteString(" OFFSET ")
+			sb.WriteString(strconv.Itoa(b.offset))
+		}
+	case dialect.PaginationFetchFirst, dialect.PaginationTop:
+		if pagination == dialect.PaginationTop && b.offset == 0 {
+			break
+		}
+		if b.offset > 0 {
+			sb.WriteString(" OFFSET ")
+			sb.WriteString(strconv.Itoa(b.offset))
+			sb.WriteString(" ROWS")
+		}
+		if b.limit > 0 {
+			sb.WriteString(" FETCH FIRST ")
+			sb.WriteString(strconv.Itoa(b.limit))
+			sb.WriteString(" ROWS ONLY")
+		}
+	}
+	return sb.String(), nil
+}
+
+//New creates SELECT builder for table, columns are derived from newRow struct with io.StructColumns,
+//supported options: option.Tag, option.Columns, *info.Dialect and option.SkipSoftDeleted adding not deleted rows criterion
+func New(table string, newRow func() interface{}, options ...option.Option) (*Builder, error) {
+	recordType := reflect.TypeOf(newRow())
+	if !io.IsStruct(recordType) {
+		return nil, fmt.Errorf("invalid record type: %v, expected struct", recordType)
+	}
+	setMarker := &option.SetMarker{}
+	columns, binder, err := io.StructColumnMapper(recordType, option.Options(options).Tag(), append(options, setMarker)...)
+	if err != nil {
+		return nil, err
+	}
+	if len(columns) == 0 {
+		return nil, fmt.Errorf("failed to build query for %v: no columns", table)
+	}
+	result := &Builder{
+		table:     table,
+		newRow:    newRow,
+		columns:   columns,
+		binder:    binder,
+		setMarker: setMarker,
+		dialect:   option.Options(options).Dialect(),
+	}
+	if option.Options(options).SkipSoftDeleted() {
+		if softDelete := result.columns.SoftDelete(); softDelete != nil {
+			result.where = append(result.where, softDelete.Criterion())
+		}
+	}
+	return result, nil
+}
+
+//fieldValue returns value of field address returned by io.PlaceholderBinder and whether it is zero
+func fieldValue(value interface{}) (interface{}, bool) {
+	if value == nil {
+		return nil, true
+	}
+	rValue := reflect.ValueOf(value)
+	if rValue.Kind() == reflect.Ptr {
+		if rValue.IsNil() {
+			return nil, true
+		}
+		rValue = rValue.Elem()
+	}
+	return rValue.Interface(), rValue.IsZero()
+}
@@ -0,0 +1,178 @@
+package query_test
+
+import (
+	"context"
+	"database/sql"
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/assert"
+	"github.com/viant/sqlx/io/query"
+	"github.com/viant/sqlx/metadata/info"
+	"github.com/viant/sqlx/metadata/info/dialect"
+	"github.com/viant/sqlx/metadata/product/pg"
+	_ "github.com/viant/sqlx/metadata/product/sqlite"
+	"github.com/viant/sqlx/metadata/product/sqlserver"
+	"github.com/viant/sqlx/option"
+	"testing"
+)
+
+type foo struct {
+	ID      int    `sqlx:"name=id,primaryKey"`
+	Name    string `sqlx:"name"`
+	Kind    string `sqlx:"kind"`
+	Deleted bool   `sqlx:"deleted,softDelete"`
+}
+
+func TestBuilder_Build(t *testing.T) {
+	pgDialect := &info.Dialect{Placeholder: "$", PlaceholderResolver: &pg.PlaceholderGenerator{}}
+	sqlServerDialect := &info.Dialect{Placeholder: "@p", PlaceholderResolver: &sqlserver.PlaceHolderGenerator{}, Pagination: dialect.PaginationTop}
+	oracleDialect := &info.Dialect{Placeholder: "?", Pagination: dialect.PaginationFetchFirst}
+	var testCases = []struct {
+		description string
+		options     []option.Option
+		build       func(builder *query.Builder)
+		expectSQL   string
+		expectArgs  []interface{}
+		hasError    bool
+	}{
+		{
+			description: "criteria and limit",
+			options:     []option.Option{pgDialect},
+			build: func(builder *query.Builder) {
+				builder.Where("name = ?", "n").Where("kind IN (?) OR id > ?", []string{"a", "b"}, 1).OrderBy("name DESC").Limit(10).Offset(20)
+			},
+			expectSQL:  `SELECT name, kind, deleted, id FROM "user data" WHERE (name = $1) AND (kind IN ($2) OR id > $3) ORDER BY name DESC LIMIT 10 OFFSET 20`,
+			expectArgs: []interface{}{"n", []string{"a", "b"}, 1},
+		},
+		{
+			description: "offset without limit",
+			build: func(builder *query.Builder) {
+				builder.GroupBy("name, kind, deleted, id").Offset(5)
+			},
+			expectSQL: `SELECT name, kind, deleted, id FROM "user data" GROUP BY name, kind, deleted, id LIMIT 9223372036854775807 OFFSET 5`,
+		},
+		{
+			description: "top",
+			options:     []option.Option{sqlServerDialect},
+			build: func(builder *query.Builder) {
+				builder.Where("name = ?", "n").Limit(3)
+			},
+			expectSQL:  `SELECT TOP 3 name, kind, deleted, id FROM "user data" WHERE name = @p1`,
+			expectArgs: []interface{}{"n"},
+		},
+		{
+			description: "offset fetch without order",
+			options:     []option.Option{sqlServerDialect},
+			build: func(builder *query.Builder) {
+				builder.Limit(3).Offset(6)
+			},
+			expectSQL: `SELECT name, kind, deleted, id FROM "user data" ORDER BY (SELECT NULL) OFFSET 6 ROWS FETCH FIRST 3 ROWS ONLY`,
+		},
+		{
+			description: "fetch first with soft delete",
+			options:     []option.Option{oracleDialect, option.SkipSoftDeleted(true)},
+			build: func(builder *query.Builder) {
+				builder.Limit(3)
+			},
+			expectSQL: `SELECT name, kind, deleted, id FROM "user data" WHERE (deleted IS NULL OR deleted = FALSE) FETCH FIRST 3 ROWS ONLY`,
+		},
+		{
+			description: "query by example",
+			build: func(builder *query.Builder) {
+				builder.Example(&foo{Name: "n", Kind: "k"})
+			},
+			expectSQL:  `SELECT name, kind, deleted, id FROM "user data" WHERE (name = ?) AND (kind = ?)`,
+			expectArgs: []interface{}{"n", "k"},
+		},
+		{
+			description: "args mismatch",
+			build: func(builder *query.Builder) {
+				builder.Where("name = ? AND kind = ?", "n")
+			},
+			hasError: true,
+		},
+	}
+
+	for _, testCase := range testCases {
+		builder, err := query.New("user data", func() interface{} { return &foo{} }, testCase.options...)
+		if !assert.Nil(t, err, testCase.description) {
+			continue
+		}
+		testCase.build(builder)
+		actualSQL, actualArgs, err := builder.Build()
+		if testCase.hasError {
+			assert.NotNil(t, err, testCase.description)
+			continue
+		}
+		assert.Nil(t, err, testCase.description)
+		assert.Equal(t, testCase.expectSQL, actualSQL, testCase.description)
+		assert.EqualValues(t, testCase.expectArgs, actualArgs, testCase.description)
+	}
+}
+
+func TestBuilder_QueryAll(t *testing.T) {
+	type recordPresence struct {
+		ID   bool
+		Name bool
+		Kind bool
+	}
+	type record struct {
+		ID   int             `sqlx:"name=id,primaryKey"`
+		Name string          `sqlx:"name"`
+		Kind string          `sqlx:"kind"`
+		Has  *recordPresence `sqlx:"-" setMarker:"true"`
+	}
+	var testCases = []struct {
+		description string
+		build       func(builder *query.Builder)
+		expect      []int
+	}{
+		{
+			description: "order and pagination",
+			build: func(builder *query.Builder) {
+				builder.Where("id > ?", 1).OrderBy("id DESC").Limit(2).Offset(1)
+			},
+			expect: []int{3, 2},
+		},
+		{
+			description: "set marker example with empty value",
+			build: func(builder *query.Builder) {
+				builder.Example(&record{Kind: "", Has: &recordPresence{Kind: true}})
+			},
+			expect: []int{4},
+		},
+		{
+			description: "slice criterion",
+			build: func(builder *query.Builder) {
+				builder.Where("id IN (?)", []int{1, 3}).OrderBy("id")
+			},
+			expect: []int{1, 3},
+		},
+	}
+	db, err := sql.Open("sqlite3", "/tmp/sqllite.db")
+	if !assert.Nil(t, err) {
+		return
+	}
+	for _, SQL := range []string{
+		"DROP TABLE IF EXISTS t_query",
+		"CREATE TABLE t_query (id INTEGER PRIMARY KEY, name TEXT, kind TEXT)",
+		"INSERT INTO t_query (id, name, kind) VALUES(1, 'a', 'x'), (2, 'b', 'x'), (3, 'c', 'y'), (4, 'd', '')",
+	} {
+		if _, err = db.Exec(SQL); !assert.Nil(t, err) {
+			return
+		}
+	}
+	for _, testCase := range testCases {
+		builder, err := query.New("t_query", func() interface{} { return &record{} })
+		if !assert.Nil(t, err, testCase.description) {
+			continue
+		}
+		testCase.build(builder)
+		var actual []int
+		err = builder.QueryAll(context.TODO(), db, func(row interface{}) error {
+			actual = append(actual, row.(*record).ID)
+			return nil
+		})
+		assert.Nil(t, err, testCase.description)
+		assert.EqualValues(t, testCase.expect, actual, testCase.description)
+	}
+}
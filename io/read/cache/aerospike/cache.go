@@ -12,9 +12,13 @@ import (
 	"github.com/aerospike/aerospike-client-go/types"
 	"github.com/google/uuid"
 	"github.com/viant/parsly/matcher"
+	"github.com/viant/sqlx"
 	"github.com/viant/sqlx/io"
 	"github.com/viant/sqlx/io/read/cache"
+	"github.com/viant/sqlx/io/read/cache/ast"
 	"github.com/viant/sqlx/io/read/cache/hash"
+	"github.com/viant/sqlx/io/telemetry"
+	"github.com/viant/sqlx/option"
 	sio "io"
 	"strconv"
 	"strings"
@@ -48,16 +52,20 @@ type (
 		chanSize        int
 		timeoutConfig   *TimeoutConfig
 		failureHandler  *FailureHandler
+		tracer          option.Tracer
 	}
 )
 
-func (a *Cache) IndexBy(ctx context.Context, db *sql.DB, column, SQL string, args []interface{}) (int, error) {
+func (a *Cache) IndexBy(ctx context.Context, db sqlx.Executor, column, SQL string, args []interface{}) (count int, err error) {
+	ctx, span := telemetry.Start(ctx, a.tracer, "sqlx.cache.aerospike.IndexBy", telemetry.Statement.String(SQL))
+	defer func() { telemetry.End(span, err) }()
 	if args == nil {
 		args = []interface{}{}
 	}
 
+	started := time.Now().UnixNano()
 	querySQL, isOrdered := tryOrderedSQL(SQL, column)
-	rows, err := db.Query(querySQL, args...)
+	rows, err := db.QueryContext(ctx, querySQL, args...)
 	if err != nil {
 		return 0, err
 	}
@@ -79,7 +87,7 @@ func (a *Cache) IndexBy(ctx context.Context, db *sql.DB, column, SQL string, arg
 	var values = make(chan *cache.Indexed, 512)
 	errors := &Errors{}
 	go func() {
-		err = a.fetchAndIndexValues(fields, column, rows, values, isOrdered)
+		err = cache.IndexRows(fields, column, rows, values, isOrdered)
 		errors.Add(err)
 		close(values)
 	}()
@@ -103,6 +111,7 @@ func (a *Cache) IndexBy(ctx context.Context, db *sql.DB, column, SQL string, arg
 	fieldsStringified := string(fieldMarshal)
 
 	inserted := 0
+	var keys []string
 	for value := range values {
 		var metaBin as.BinMap
 		if column == "" {
@@ -111,7 +120,11 @@ func (a *Cache) IndexBy(ctx context.Context, db *sql.DB, column, SQL string, arg
 			metaBin = as.BinMap{}
 		}
 
-		errors.Add(a.writeIndexData(value, URL, column, metaBin))
+		key, err := a.writeIndexData(value, URL, column, metaBin)
+		errors.Add(err)
+		if key != "" {
+			keys = append(keys, key)
+		}
 		inserted++
 	}
 
@@ -120,10 +133,19 @@ func (a *Cache) IndexBy(ctx context.Context, db *sql.DB, column, SQL string, arg
 	}
 
 	if column != "" {
-		return inserted + 1, a.putRowMarker(URL, column, a.metaBin(SQL, argsStringified, fieldsStringified, column))
+		if err = a.putRowMarker(URL, column, a.metaBin(SQL, argsStringified, fieldsStringified, column)); err != nil {
+			return inserted + 1, err
+		}
+		keys = append(keys, a.columnURL(URL, column))
+		inserted++
 	}
 
-	return inserted, nil
+	stale, err := a.addDependencies(ast.Tables(SQL), started, keys...)
+	if err != nil || !stale {
+		return inserted, err
+	}
+
+	return 0, a.deleteStale(keys...)
 }
 
 func tryOrderedSQL(SQL string, column string) (string, bool) {
@@ -179,7 +201,8 @@ func (a *Cache) AddValues(ctx context.Context, entry *cache.Entry, values []inte
 	return entry.Write(marshal)
 }
 
-func (a *Cache) Get(ctx context.Context, SQL string, args []interface{}, options ...interface{}) (*cache.Entry, error) {
+func (a *Cache) Get(ctx context.Context, SQL string, args []interface{}, options ...interface{}) (entry *cache.Entry, err error) {
+	ctx, span := telemetry.Start(ctx, a.tracer, "sqlx.cache.aerospike.Get", telemetry.Statement.String(SQL))
 	var query *cache.ParmetrizedQuery
 	var cacheStats *cache.Stats
 	var refresh bool
@@ -197,6 +220,9 @@ func (a *Cache) Get(ctx context.Context, SQL string, args []interface{}, options
 	if cacheStats == nil {
 		cacheStats = &cache.Stats{}
 	}
+	defer func() {
+		telemetry.End(span, err, telemetry.CacheHit.Bool(entry != nil && entry.Has()), telemetry.CacheType.String(string(cacheStats.Type)))
+	}()
 	cacheStats.Init()
 	if query != nil {
 		query.Init()
@@ -232,6 +258,7 @@ func (a *Cache) get(ctx context.Context, SQL string, args []interface{}, columns
 		Meta: cache.Meta{
 			SQL:          SQL,
 			Args:         jsonEncodedArgs,
+			Tables:       ast.Tables(SQL),
 			ExpiryTimeMs: int(time.Now().Add(expiryDuration).UnixMilli()),
 		},
 		Id: a.entryId(lazyMatch, warmupMatch),
@@ -396,8 +423,10 @@ func (a *Cache) UpdateType(ctx context.Context, entry *cache.Entry, args []inter
 	return true, nil
 }
 
-func (a *Cache) Close(ctx context.Context, entry *cache.Entry) error {
-	err := entry.Close()
+func (a *Cache) Close(ctx context.Context, entry *cache.Entry) (err error) {
+	ctx, span := telemetry.Start(ctx, a.tracer, "sqlx.cache.aerospike.Close", telemetry.CacheKey.String(entry.Id))
+	defer func() { telemetry.End(span, err) }()
+	err = entry.Close()
 	if err != nil {
 		_ = a.Delete(ctx, entry)
 		return err
@@ -406,7 +435,9 @@ func (a *Cache) Close(ctx context.Context, entry *cache.Entry) error {
 	return nil
 }
 
-func (a *Cache) Delete(ctx context.Context, entry *cache.Entry) error {
+func (a *Cache) Delete(ctx context.Context, entry *cache.Entry) (err error) {
+	_, span := telemetry.Start(ctx, a.tracer, "sqlx.cache.aerospike.Delete", telemetry.CacheKey.String(entry.Id))
+	defer func() { telemetry.End(span, err) }()
 	key, err := a.key(entry.Id)
 	if err != nil {
 		return err
@@ -462,6 +493,7 @@ func (a *Cache) newWriter(key *as.Key, aKey string, SQL string, args []byte) *Wr
 		sql:                     SQL,
 		args:                    string(args),
 		cache:                   a,
+		started:                 time.Now().UnixNano(),
 	}
 }
 
@@ -523,20 +555,21 @@ func (a *Cache) updateEntryFields(record *as.Record, entry *cache.Entry) error {
 	return nil
 }
 
-func (a *Cache) writeIndexData(args *cache.Indexed, URL string, column string, metaBin as.BinMap) error {
+//writeIndexData writes indexed data, returns written record key value
+func (a *Cache) writeIndexData(args *cache.Indexed, URL string, column string, metaBin as.BinMap) (string, error) {
 	if args.ColumnValue == nil && args.Column != "" {
-		return nil
+		return "", nil
 	}
 
 	marshal, err := json.Marshal(args.ColumnValue)
 	if err != nil {
-		return err
+		return "", err
 	}
 
 	actualKey := a.columnValueURL(column, marshal, URL)
 	key, err := a.key(actualKey)
 	if err != nil {
-		return err
+		return "", err
 	}
 
 	data := args.Data.Bytes()
@@ -554,7 +587,10 @@ func (a *Cache) writeIndexData(args *cache.Indexed, URL string, column string, m
 		metaBin[dataBin] = string(data)
 	}
 
-	return a.put(key, metaBin)
+	if err = a.put(key, metaBin); err != nil {
+		return "", err
+	}
+	return actualKey, nil
 }
 
 func compress(data []byte) ([]byte, bool) {
@@ -836,36 +872,6 @@ func (a *Cache) updateMetaFields(entry *cache.Entry, match *RecordMatched, colum
 	return nil
 }
 
-func (a *Cache) fetchAndIndexValues(fields []*cache.Field, column string, rows *sql.Rows, dest chan *cache.Indexed, ordered bool) error {
-	indexSource, err := NewIndexSource(column, ordered, fields, dest)
-	if err != nil {
-		return err
-	}
-
-	columnIndex := indexSource.ColumnIndex()
-	placeholders := NewPlaceholders(columnIndex, fields)
-
-	for rows.Next() {
-		if err = rows.Scan(placeholders.ScanPlaceholders()...); err != nil {
-			return err
-		}
-
-		columnValue, ok := placeholders.ColumnValue()
-		if !ok {
-			continue
-		}
-
-		indexed := indexSource.Index(columnValue)
-		indexed.Column = column
-
-		if err = indexed.StringifyData(placeholders.Values()); err != nil {
-			return err
-		}
-	}
-
-	return indexSource.Close()
-}
-
 func (a *Cache) handleResponseFailure(code types.ResultCode) {
 	if a.failureHandler == nil {
 		return
@@ -908,6 +914,7 @@ func New(namespace string, setName string, client *as.Client, timeToLiveInSec ui
 	var allowSmart bool
 	var timeoutConfig *TimeoutConfig
 	var globalFailureHandler *FailureHandler
+	var cacheTracer option.Tracer
 
 	for _, anOption := range options {
 		switch actual := anOption.(type) {
@@ -919,6 +926,8 @@ func New(namespace string, setName string, client *as.Client, timeToLiveInSec ui
 			timeoutConfig = actual
 		case *FailureHandler:
 			globalFailureHandler = actual
+		case option.Tracer:
+			cacheTracer = actual
 		}
 	}
 
@@ -931,5 +940,6 @@ func New(namespace string, setName string, client *as.Client, timeToLiveInSec ui
 		allowSmart:      allowSmart,
 		timeoutConfig:   timeoutConfig,
 		failureHandler:  globalFailureHandler,
+		tracer:          cacheTracer,
 	}, nil
 }
@@ -0,0 +1,108 @@
+package aerospike
+
+import (
+	"context"
+	as "github.com/aerospike/aerospike-client-go"
+	"github.com/viant/sqlx/io/read/cache/ast"
+	"github.com/viant/sqlx/io/telemetry"
+	"strings"
+	"time"
+)
+
+const (
+	keysBin        = "Keys"
+	invalidatedBin = "Invalidated"
+	tableKeyPrefix = "table#"
+)
+
+//Invalidate removes records depending on any of supplied tables, table dependency record keeps dependent record keys in map bin
+//with registration time, keys are removed and invalidation time is set with a single operation, so that keys registered concurrently
+//are either removed or see the invalidation time, returns invalidated record keys count
+func (a *Cache) Invalidate(ctx context.Context, tables ...string) (count int, err error) {
+	_, span := telemetry.Start(ctx, a.tracer, "sqlx.cache.aerospike.Invalidate", telemetry.Table.String(strings.Join(tables, ",")))
+	defer func() { telemetry.End(span, err) }()
+	for _, table := range tables {
+		tableKey, err := a.key(tableKeyPrefix + ast.TableName(table))
+		if err != nil {
+			return count, err
+		}
+
+		record, err := a.client.Operate(a.writePolicy(), tableKey,
+			as.PutOp(as.NewBin(invalidatedBin, time.Now().UnixNano())),
+			as.MapRemoveByIndexRangeOp(keysBin, 0, as.MapReturnType.KEY),
+		)
+		if err != nil {
+			return count, err
+		}
+
+		keys, _ := record.Bins[keysBin].([]interface{})
+		for _, keyValue := range keys {
+			key, err := a.key(keyValue)
+			if err != nil {
+				return count, err
+			}
+
+			if err = a.deleteCascade(key); err != nil {
+				return count, err
+			}
+			count++
+		}
+	}
+
+	return count, nil
+}
+
+//addDependencies adds record keys to tables dependency records, registrations older than cache time to live are pruned,
+//returns true if any of tables was invalidated since started (unix nano), records were populated with stale data in that case
+func (a *Cache) addDependencies(tables []string, started int64, keys ...string) (bool, error) {
+	if len(tables) == 0 || len(keys) == 0 {
+		return false, nil
+	}
+
+	now := time.Now()
+	items := make(map[interface{}]interface{}, len(keys))
+	for _, key := range keys {
+		items[key] = now.UnixNano()
+	}
+
+	stale := false
+	for _, table := range tables {
+		tableKey, err := a.key(tableKeyPrefix + table)
+		if err != nil {
+			return stale, err
+		}
+
+		operations := []*as.Operation{as.MapPutItemsOp(as.DefaultMapPolicy(), keysBin, items), as.GetOpForBin(invalidatedBin)}
+		if a.timeToLiveInSec > 0 {
+			expired := now.Add(-time.Duration(a.timeToLiveInSec) * time.Second).UnixNano()
+			operations = append(operations, as.MapRemoveByValueRangeOp(keysBin, 0, expired, as.MapReturnType.NONE))
+		}
+
+		record, err := a.client.Operate(a.writePolicy(), tableKey, operations...)
+		if err != nil {
+			return stale, err
+		}
+
+		if invalidated, ok := record.Bins[invalidatedBin].(int); ok && int64(invalidated) >= started {
+			stale = true
+		}
+	}
+
+	return stale, nil
+}
+
+//deleteStale removes records populated with stale data
+func (a *Cache) deleteStale(keys ...string) error {
+	for _, keyValue := range keys {
+		key, err := a.key(keyValue)
+		if err != nil {
+			return err
+		}
+
+		if err = a.deleteCascade(key); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
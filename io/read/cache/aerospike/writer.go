@@ -19,6 +19,7 @@ type Writer struct {
 	fields   *string
 	entry    *cache.Entry
 	cache    *Cache
+	started  int64 //unix nano time the writer was created, before source query runs
 
 	expirationTimeInSeconds uint32
 }
@@ -48,7 +49,11 @@ func (w *Writer) Flush() error {
 		childKey = key
 		previousKeyValue = childKeyValue
 	}
-	return nil
+	stale, err := w.cache.addDependencies(w.entry.Meta.Tables, w.started, w.id)
+	if stale {
+		w.delete(w.mainKey)
+	}
+	return err
 }
 
 func (w *Writer) Close() error {
@@ -11,7 +11,10 @@ import (
 	"github.com/viant/afs"
 	"github.com/viant/afs/option"
 	"github.com/viant/sqlx/io/read/cache"
+	"github.com/viant/sqlx/io/read/cache/ast"
 	"github.com/viant/sqlx/io/read/cache/hash"
+	"github.com/viant/sqlx/io/telemetry"
+	soption "github.com/viant/sqlx/option"
 	"strings"
 	"sync"
 	"time"
@@ -36,26 +39,31 @@ type (
 		mux       sync.RWMutex
 		signature string
 		canWrite  map[string]bool
+		filling   map[string]int64 //entry URL to unix nano time entry started to be populated
 		stream    *option.Stream
 		recorder  cache.Recorder
+		tracer    soption.Tracer
 	}
 )
 
-func (c *Cache) IndexBy(ctx context.Context, db *sql.DB, column, SQL string, args []interface{}) (int, error) {
-	return 0, nil
-}
-
 func (c *Cache) Rollback(ctx context.Context, entry *cache.Entry) error {
+	actualURL := strings.ReplaceAll(entry.Meta.URL, ".json"+entry.Id, ".json")
+	defer c.unmark(actualURL) //entry can be populated again by the next query
+	c.fillStarted(entry)
+	_ = c.close(entry)
 	return c.Delete(ctx, entry)
 }
 
 // NewCache creates new cache.
 func NewCache(URL string, ttl time.Duration, signature string, stream *option.Stream, options ...interface{}) (*Cache, error) {
 	var recorder cache.Recorder
+	var cacheTracer soption.Tracer
 	for _, anOption := range options {
 		switch actual := anOption.(type) {
 		case cache.Recorder:
 			recorder = actual
+		case soption.Tracer:
+			cacheTracer = actual
 		}
 	}
 
@@ -69,14 +77,36 @@ func NewCache(URL string, ttl time.Duration, signature string, stream *option.St
 		extension: ".json",
 		signature: signature,
 		canWrite:  map[string]bool{},
+		filling:   map[string]int64{},
 		stream:    stream,
 		recorder:  recorder,
+		tracer:    cacheTracer,
 	}
 
 	return cache, nil
 }
 
-func (c *Cache) Get(ctx context.Context, SQL string, args []interface{}, options ...interface{}) (*cache.Entry, error) {
+func (c *Cache) Get(ctx context.Context, SQL string, args []interface{}, options ...interface{}) (entry *cache.Entry, err error) {
+	ctx, span := telemetry.Start(ctx, c.tracer, "sqlx.cache.afs.Get", telemetry.Statement.String(SQL))
+	var matcher *cache.ParmetrizedQuery
+	var cacheStats *cache.Stats
+	for _, anOption := range options {
+		switch actual := anOption.(type) {
+		case *cache.ParmetrizedQuery:
+			matcher = actual
+		case *cache.Stats:
+			cacheStats = actual
+		}
+	}
+
+	if cacheStats == nil {
+		cacheStats = &cache.Stats{}
+	}
+
+	cacheStats.Init()
+	defer func() {
+		telemetry.End(span, err, telemetry.CacheHit.Bool(entry != nil && entry.Has()), telemetry.CacheType.String(string(cacheStats.Type)))
+	}()
 	URL, err := hash.GenerateURL(SQL, c.storage, c.extension, args)
 	if err != nil {
 		return nil, err
@@ -86,17 +116,39 @@ func (c *Cache) Get(ctx context.Context, SQL string, args []interface{}, options
 		return nil, nil
 	}
 
-	entry, err := c.getEntry(ctx, SQL, args, err, URL)
+	entry, err = c.getEntry(ctx, SQL, args, err, URL)
 	if err != nil || entry == nil {
 		c.unmark(URL)
 		return entry, err
 	}
 
+	cacheStats.Key = URL
 	if entry.Has() {
 		c.unmark(URL)
+		if cacheStats.FoundLazy = len(entry.Meta.Fields) > 0; cacheStats.FoundLazy {
+			cacheStats.Type = cache.TypeReadSingle
+			cacheStats.RecordsCounter = 1
+		}
+		return entry, nil
+	}
+
+	found, err := c.readWarmup(ctx, entry, matcher)
+	if err != nil {
+		c.unmark(URL)
+		c.fillStarted(entry)
+		return nil, err
+	}
+
+	cacheStats.FoundWarmup = found
+	if found {
+		c.unmark(URL)
+		cacheStats.Type = cache.TypeReadMulti
+		cacheStats.RecordsCounter = len(matcher.In)
+		return entry, nil
 	}
 
-	return entry, err
+	cacheStats.Type = cache.TypeWrite
+	return entry, nil
 }
 
 func (c *Cache) getEntry(ctx context.Context, SQL string, args []interface{}, err error, URL string) (*cache.Entry, error) {
@@ -111,6 +163,7 @@ func (c *Cache) getEntry(ctx context.Context, SQL string, args []interface{}, er
 			Args:      argsMarshal,
 			URL:       URL,
 			Signature: c.signature,
+			Tables:    ast.Tables(SQL),
 		},
 	}
 
@@ -136,6 +189,9 @@ func (c *Cache) updateEntry(ctx context.Context, err error, URL string, entry *c
 			id := strings.ReplaceAll(uuid.New().String(), "-", "")
 			entry.Meta.URL += id
 			entry.Id = id
+			c.mux.Lock()
+			c.filling[entry.Meta.URL] = time.Now().UnixNano()
+			c.mux.Unlock()
 		}
 
 		if err == nil {
@@ -252,7 +308,14 @@ func (c *Cache) UpdateType(ctx context.Context, entry *cache.Entry, values []int
 	return true, nil
 }
 
-func (c *Cache) Delete(ctx context.Context, entry *cache.Entry) error {
+func (c *Cache) Delete(ctx context.Context, entry *cache.Entry) (err error) {
+	ctx, span := telemetry.Start(ctx, c.tracer, "sqlx.cache.afs.Delete", telemetry.CacheKey.String(entry.Meta.URL))
+	defer func() { telemetry.End(span, err) }()
+	if entry.Id == "" {
+		if err = c.deleteDependencies(ctx, entry.Meta.Tables, entry.Meta.URL); err != nil {
+			return err
+		}
+	}
 	return c.afs.Delete(ctx, entry.Meta.URL)
 }
 
@@ -274,23 +337,35 @@ func (c *Cache) scanner(e *cache.Entry) cache.ScannerFn {
 	return cache.NewScanner(c.typeHolder, c.recorder).New(e)
 }
 
-func (c *Cache) Close(ctx context.Context, e *cache.Entry) error {
+func (c *Cache) Close(ctx context.Context, e *cache.Entry) (err error) {
+	ctx, span := telemetry.Start(ctx, c.tracer, "sqlx.cache.afs.Close", telemetry.CacheKey.String(e.Meta.URL))
+	defer func() { telemetry.End(span, err) }()
 	actualURL := strings.ReplaceAll(e.Meta.URL, ".json"+e.Id, ".json")
 	defer c.unmark(actualURL)
-	err := c.close(e)
+	started := c.fillStarted(e)
+	err = c.close(e)
 	if err != nil {
 		_ = c.Delete(ctx, e)
 		return err
 	}
 
-	if err = c.moveIfNeeded(ctx, e, actualURL); err != nil {
+	if err = c.moveIfNeeded(ctx, e, actualURL, started); err != nil {
 		return err
 	}
 
 	return nil
 }
 
-func (c *Cache) moveIfNeeded(ctx context.Context, e *cache.Entry, actualURL string) error {
+//fillStarted returns and forgets time entry started to be populated
+func (c *Cache) fillStarted(e *cache.Entry) int64 {
+	c.mux.Lock()
+	defer c.mux.Unlock()
+	started := c.filling[e.Meta.URL]
+	delete(c.filling, e.Meta.URL)
+	return started
+}
+
+func (c *Cache) moveIfNeeded(ctx context.Context, e *cache.Entry, actualURL string, started int64) error {
 	if e.Has() {
 		return nil
 	}
@@ -298,7 +373,11 @@ func (c *Cache) moveIfNeeded(ctx context.Context, e *cache.Entry, actualURL stri
 	if err := c.afs.Move(ctx, e.Meta.URL, actualURL); err != nil {
 		return err
 	}
-	return nil
+	if err := c.addDependencies(ctx, e.Meta.Tables, actualURL); err != nil {
+		return err
+	}
+	_, err := c.discardStale(ctx, e.Meta.Tables, started, actualURL)
+	return err
 }
 
 func (c *Cache) close(e *cache.Entry) error {
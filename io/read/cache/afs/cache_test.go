@@ -0,0 +1,387 @@
+package afs_test
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/assert"
+	afsService "github.com/viant/afs"
+	"github.com/viant/afs/option"
+	"github.com/viant/sqlx/io/read"
+	"github.com/viant/sqlx/io/read/cache"
+	"github.com/viant/sqlx/io/read/cache/afs"
+	option2 "github.com/viant/sqlx/option"
+	"strconv"
+	"testing"
+	"time"
+)
+
+func TestCache_IndexBy(t *testing.T) {
+	type record struct {
+		Id   int    `sqlx:"id"`
+		Name string `sqlx:"name"`
+	}
+	var testCases = []struct {
+		description   string
+		column        string
+		matcher       *cache.ParmetrizedQuery
+		elapsed       time.Duration
+		query         string
+		expect        []int
+		expectCount   int
+		expectType    cache.Type
+		expectCounter int
+		expectWarmup  bool
+		removeShards  bool
+	}{
+		{
+			description: "warmup with matching values",
+			column:      "name",
+			matcher: &cache.ParmetrizedQuery{
+				SQL: "SELECT id, name FROM t_afs_index ORDER BY id",
+				By:  "name",
+				In:  []interface{}{"a", "c", "x"},
+			},
+			query:         "SELECT id, name FROM t_afs_index WHERE name IN ('a', 'c', 'x') ORDER BY id",
+			expect:        []int{1, 2, 4, 3},
+			expectCount:   3,
+			expectType:    cache.TypeReadMulti,
+			expectCounter: 3,
+			expectWarmup:  true,
+		},
+		{
+			description: "warmup with offset and limit per value",
+			column:      "name",
+			matcher: &cache.ParmetrizedQuery{
+				SQL:    "SELECT id, name FROM t_afs_index ORDER BY id",
+				By:     "name",
+				In:     []interface{}{"a", "c"},
+				Offset: 1,
+				Limit:  1,
+			},
+			query:         "SELECT id, name FROM t_afs_index WHERE name IN ('a', 'c') ORDER BY id",
+			expect:        []int{2},
+			expectCount:   3,
+			expectType:    cache.TypeReadMulti,
+			expectCounter: 2,
+			expectWarmup:  true,
+		},
+		{
+			description: "expired warmup",
+			column:      "name",
+			matcher: &cache.ParmetrizedQuery{
+				SQL: "SELECT id, name FROM t_afs_index ORDER BY id",
+				By:  "name",
+				In:  []interface{}{"a"},
+			},
+			elapsed:     2 * time.Minute,
+			query:       "SELECT id, name FROM t_afs_index WHERE name IN ('a') ORDER BY id",
+			expect:      []int{1},
+			expectCount: 3,
+			expectType:  cache.TypeWrite,
+		},
+		{
+			description: "missing shard",
+			column:      "name",
+			matcher: &cache.ParmetrizedQuery{
+				SQL: "SELECT id, name FROM t_afs_index ORDER BY id",
+				By:  "name",
+				In:  []interface{}{"a", "x"},
+			},
+			removeShards: true,
+			query:        "SELECT id, name FROM t_afs_index WHERE name IN ('a', 'x') ORDER BY id",
+			expect:       []int{1},
+			expectCount:  3,
+			expectType:   cache.TypeWrite,
+		},
+		{
+			description: "not indexed matcher column",
+			column:      "id",
+			matcher: &cache.ParmetrizedQuery{
+				SQL: "SELECT id, name FROM t_afs_index ORDER BY id",
+				By:  "name",
+				In:  []interface{}{"a"},
+			},
+			query:       "SELECT id, name FROM t_afs_index WHERE name IN ('a') ORDER BY id",
+			expect:      []int{1},
+			expectCount: 5,
+			expectType:  cache.TypeWrite,
+		},
+		{
+			description:   "whole result",
+			query:         "SELECT id, name FROM t_afs_index ORDER BY id",
+			expect:        []int{1, 2, 3, 4},
+			expectCount:   1,
+			expectType:    cache.TypeReadSingle,
+			expectCounter: 1,
+		},
+	}
+	db, err := sql.Open("sqlite3", "/tmp/sqllite.db")
+	if !assert.Nil(t, err) {
+		return
+	}
+	defer func() { cache.Now = time.Now }()
+	for i, testCase := range testCases {
+		for _, SQL := range []string{
+			"DROP TABLE IF EXISTS t_afs_index",
+			"CREATE TABLE t_afs_index (id INTEGER PRIMARY KEY, name TEXT)",
+			"INSERT INTO t_afs_index (id, name) VALUES(1, 'a'), (2, 'a'), (3, 'c'), (4, 'a')",
+		} {
+			if _, err = db.Exec(SQL); !assert.Nil(t, err, testCase.description) {
+				return
+			}
+		}
+		now := time.Now()
+		cache.Now = func() time.Time { return now }
+		URL := "mem:///tmp/afs_index/" + strconv.Itoa(i)
+		aCache, err := afs.NewCache(URL, time.Minute, "v1", option.NewStream(64*1024, 1024))
+		if !assert.Nil(t, err, testCase.description) {
+			continue
+		}
+		SQL := testCase.query
+		if testCase.matcher != nil {
+			SQL = testCase.matcher.SQL
+		}
+		count, err := aCache.IndexBy(context.TODO(), db, testCase.column, SQL, nil)
+		assert.Nil(t, err, testCase.description)
+		assert.EqualValues(t, testCase.expectCount, count, testCase.description)
+		if testCase.removeShards && !assert.Nil(t, removeShards(context.TODO(), URL+"/"+testCase.column), testCase.description) {
+			continue
+		}
+		if _, err = db.Exec("DELETE FROM t_afs_index WHERE id > 1"); !assert.Nil(t, err, testCase.description) {
+			continue
+		}
+		cache.Now = func() time.Time { return now.Add(testCase.elapsed) }
+		stats := &cache.Stats{}
+		options := []option2.Option{aCache, stats}
+		if testCase.matcher != nil {
+			options = append(options, testCase.matcher)
+		}
+		reader, err := read.New(context.TODO(), db, testCase.query, func() interface{} { return &record{} }, options...)
+		if !assert.Nil(t, err, testCase.description) {
+			continue
+		}
+		var actual []int
+		err = reader.QueryAll(context.TODO(), func(row interface{}) error {
+			actual = append(actual, row.(*record).Id)
+			return nil
+		})
+		assert.Nil(t, err, testCase.description)
+		assert.EqualValues(t, testCase.expect, actual, testCase.description)
+		assert.EqualValues(t, testCase.expectType, stats.Type, testCase.description)
+		assert.EqualValues(t, testCase.expectCounter, stats.RecordsCounter, testCase.description)
+		assert.EqualValues(t, testCase.expectWarmup, stats.FoundWarmup, testCase.description)
+		_ = reader.Close()
+	}
+}
+
+func TestCache_Invalidate(t *testing.T) {
+	type record struct {
+		Id   int    `sqlx:"id"`
+		Name string `sqlx:"name"`
+	}
+	var testCases = []struct {
+		description     string
+		tables          []string
+		expectCount     int
+		expectType      cache.Type
+		expectWarmup    bool
+		expectOtherType cache.Type
+	}{
+		{
+			description:     "modified table entries and shards",
+			tables:          []string{"main.T_AFS_INVALIDATE"},
+			expectCount:     3,
+			expectType:      cache.TypeWrite,
+			expectOtherType: cache.TypeReadSingle,
+		},
+		{
+			description:     "other table entry",
+			tables:          []string{"t_afs_other"},
+			expectCount:     1,
+			expectType:      cache.TypeReadSingle,
+			expectWarmup:    true,
+			expectOtherType: cache.TypeWrite,
+		},
+		{
+			description:     "not cached table",
+			tables:          []string{"t_afs_none"},
+			expectType:      cache.TypeReadSingle,
+			expectWarmup:    true,
+			expectOtherType: cache.TypeReadSingle,
+		},
+	}
+	db, err := sql.Open("sqlite3", "/tmp/sqllite.db")
+	if !assert.Nil(t, err) {
+		return
+	}
+	for _, SQL := range []string{
+		"DROP TABLE IF EXISTS t_afs_invalidate",
+		"CREATE TABLE t_afs_invalidate (id INTEGER PRIMARY KEY, name TEXT)",
+		"INSERT INTO t_afs_invalidate (id, name) VALUES(1, 'a'), (2, 'b')",
+		"DROP TABLE IF EXISTS t_afs_other",
+		"CREATE TABLE t_afs_other (id INTEGER PRIMARY KEY, name TEXT)",
+		"INSERT INTO t_afs_other (id, name) VALUES(1, 'a')",
+	} {
+		if _, err = db.Exec(SQL); !assert.Nil(t, err) {
+			return
+		}
+	}
+	query := func(SQL string, options ...option2.Option) error {
+		reader, err := read.New(context.TODO(), db, SQL, func() interface{} { return &record{} }, options...)
+		if err != nil {
+			return err
+		}
+		defer reader.Close()
+		return reader.QueryAll(context.TODO(), func(row interface{}) error { return nil })
+	}
+	SQL := "SELECT id, name FROM t_afs_invalidate ORDER BY id"
+	otherSQL := "SELECT id, name FROM t_afs_other ORDER BY id"
+	matcher := &cache.ParmetrizedQuery{SQL: "SELECT id, name FROM t_afs_invalidate t ORDER BY id", By: "name", In: []interface{}{"a"}}
+	for _, testCase := range testCases {
+		aCache, err := afs.NewCache(t.TempDir(), time.Minute, "v1", option.NewStream(64*1024, 1024))
+		if !assert.Nil(t, err, testCase.description) {
+			continue
+		}
+		_, err = aCache.IndexBy(context.TODO(), db, matcher.By, matcher.SQL, nil)
+		assert.Nil(t, err, testCase.description)
+		for _, SQL := range []string{SQL, otherSQL} {
+			assert.Nil(t, query(SQL, aCache), testCase.description)
+		}
+
+		count, err := aCache.Invalidate(context.TODO(), testCase.tables...)
+		assert.Nil(t, err, testCase.description)
+		assert.EqualValues(t, testCase.expectCount, count, testCase.description)
+
+		stats := &cache.Stats{}
+		assert.Nil(t, query(SQL, aCache, stats), testCase.description)
+		assert.EqualValues(t, testCase.expectType, stats.Type, testCase.description)
+		stats = &cache.Stats{}
+		assert.Nil(t, query("SELECT id, name FROM t_afs_invalidate WHERE name IN ('a')", aCache, stats, matcher), testCase.description)
+		assert.EqualValues(t, testCase.expectWarmup, stats.FoundWarmup, testCase.description)
+		stats = &cache.Stats{}
+		assert.Nil(t, query(otherSQL, aCache, stats), testCase.description)
+		assert.EqualValues(t, testCase.expectOtherType, stats.Type, testCase.description)
+	}
+}
+
+func TestCache_Invalidate_inFlight(t *testing.T) {
+	var testCases = []struct {
+		description string
+		fill        func(ctx context.Context, aCache *afs.Cache, db *sql.DB) error
+		expectHit   bool
+	}{
+		{
+			description: "entry invalidated before population",
+			fill: func(ctx context.Context, aCache *afs.Cache, db *sql.DB) error {
+				if _, err := aCache.Invalidate(ctx, "t_afs_flight"); err != nil {
+					return err
+				}
+				return fillEntry(ctx, aCache, func() error { return nil })
+			},
+			expectHit: true,
+		},
+		{
+			description: "entry invalidated during population",
+			fill: func(ctx context.Context, aCache *afs.Cache, db *sql.DB) error {
+				return fillEntry(ctx, aCache, func() error {
+					_, err := aCache.Invalidate(ctx, "t_afs_flight")
+					return err
+				})
+			},
+		},
+		{
+			description: "indexed SQL invalidated during population",
+			fill: func(ctx context.Context, aCache *afs.Cache, db *sql.DB) error {
+				count, err := aCache.IndexBy(ctx, &invalidatingDB{DB: db, invalidate: func(ctx context.Context) error {
+					_, err := aCache.Invalidate(ctx, "t_afs_flight")
+					return err
+				}}, "", "SELECT id, name FROM t_afs_flight", nil)
+				if err == nil && count != 0 {
+					return fmt.Errorf("expected discarded entry, but had: %v", count)
+				}
+				return err
+			},
+		},
+	}
+	db, err := sql.Open("sqlite3", "/tmp/sqllite.db")
+	if !assert.Nil(t, err) {
+		return
+	}
+	for _, SQL := range []string{
+		"DROP TABLE IF EXISTS t_afs_flight",
+		"CREATE TABLE t_afs_flight (id INTEGER PRIMARY KEY, name TEXT)",
+		"INSERT INTO t_afs_flight (id, name) VALUES(1, 'a')",
+	} {
+		if _, err = db.Exec(SQL); !assert.Nil(t, err) {
+			return
+		}
+	}
+	for _, testCase := range testCases {
+		aCache, err := afs.NewCache(t.TempDir(), time.Minute, "v1", option.NewStream(64*1024, 1024))
+		if !assert.Nil(t, err, testCase.description) {
+			continue
+		}
+		ctx := context.TODO()
+		if !assert.Nil(t, testCase.fill(ctx, aCache, db), testCase.description) {
+			continue
+		}
+		entry, err := aCache.Get(ctx, "SELECT id, name FROM t_afs_flight", nil)
+		if !assert.Nil(t, err, testCase.description) {
+			continue
+		}
+		assert.EqualValues(t, testCase.expectHit, entry.Has(), testCase.description)
+		_ = aCache.Rollback(ctx, entry)
+	}
+}
+
+//removeShards removes indexed SQL shards folders, markers are kept
+func removeShards(ctx context.Context, URL string) error {
+	fs := afsService.New()
+	objects, err := fs.List(ctx, URL)
+	if err != nil {
+		return err
+	}
+	for _, object := range objects[1:] { //the first object is the listed folder
+		if !object.IsDir() {
+			continue
+		}
+		if err = fs.Delete(ctx, object.URL()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+//fillEntry populates SQL entry, populated is called before entry is closed
+func fillEntry(ctx context.Context, aCache *afs.Cache, populated func() error) error {
+	entry, err := aCache.Get(ctx, "SELECT id, name FROM t_afs_flight", nil)
+	if err != nil {
+		return err
+	}
+	if entry.Has() {
+		return fmt.Errorf("expected cache miss")
+	}
+	if err = aCache.AddValues(ctx, entry, []interface{}{1, "a"}); err != nil {
+		return err
+	}
+	if err = populated(); err != nil {
+		return err
+	}
+	return aCache.Close(ctx, entry)
+}
+
+//invalidatingDB calls invalidate once source query started
+type invalidatingDB struct {
+	*sql.DB
+	invalidate func(ctx context.Context) error
+}
+
+func (d *invalidatingDB) QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error) {
+	rows, err := d.DB.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	return rows, d.invalidate(ctx)
+}
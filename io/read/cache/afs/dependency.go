@@ -0,0 +1,166 @@
+package afs
+
+import (
+	"bytes"
+	"context"
+	"github.com/viant/afs/option"
+	"github.com/viant/sqlx/io/read/cache/ast"
+	"github.com/viant/sqlx/io/read/cache/hash"
+	"github.com/viant/sqlx/io/telemetry"
+	"strconv"
+	"strings"
+	"time"
+)
+
+//tablesFolder stores table dependency files, dependency file content is location of entry, marker or shards folder depending on table
+const tablesFolder = "_tables"
+
+//Invalidate removes entries and indexed SQL results depending on any of supplied tables, returns removed locations count,
+//table invalidation time is written before dependencies are removed, so that entries populated concurrently are discarded, see discardStale
+func (c *Cache) Invalidate(ctx context.Context, tables ...string) (count int, err error) {
+	ctx, span := telemetry.Start(ctx, c.tracer, "sqlx.cache.afs.Invalidate", telemetry.Table.String(strings.Join(tables, ",")))
+	defer func() { telemetry.End(span, err) }()
+	for _, table := range tables {
+		table = ast.TableName(table)
+		invalidated := strconv.FormatInt(time.Now().UnixNano(), 10)
+		if err = c.afs.Upload(ctx, c.invalidatedURL(table), 0644, strings.NewReader(invalidated), &option.SkipChecksum{Skip: true}); err != nil {
+			return count, err
+		}
+
+		tableURL := c.tableURL(table)
+		if ok, _ := c.afs.Exists(ctx, tableURL); !ok {
+			continue
+		}
+
+		objects, err := c.afs.List(ctx, tableURL)
+		if err != nil {
+			return count, err
+		}
+
+		for _, object := range objects {
+			if object.IsDir() {
+				continue
+			}
+
+			URL, err := c.afs.DownloadWithURL(ctx, object.URL())
+			if err != nil {
+				return count, err
+			}
+
+			if ok, _ := c.afs.Exists(ctx, string(URL)); ok {
+				if err = c.afs.Delete(ctx, string(URL)); err != nil {
+					return count, err
+				}
+				count++
+			}
+
+			if err = c.afs.Delete(ctx, object.URL()); err != nil {
+				return count, err
+			}
+		}
+	}
+
+	return count, nil
+}
+
+//addDependencies writes dependency file of URL per table
+func (c *Cache) addDependencies(ctx context.Context, tables []string, URL string) error {
+	if len(tables) == 0 {
+		return nil
+	}
+
+	for _, table := range tables {
+		dependencyURL, err := c.dependencyURL(table, URL)
+		if err != nil {
+			return err
+		}
+
+		if err = c.afs.Upload(ctx, dependencyURL, 0644, bytes.NewReader([]byte(URL)), &option.SkipChecksum{Skip: true}); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+//discardStale removes URLs and their dependency files if any of tables was invalidated since started (unix nano),
+//it has to be called once dependencies are added, returns true if URLs were discarded
+func (c *Cache) discardStale(ctx context.Context, tables []string, started int64, URLs ...string) (bool, error) {
+	stale := false
+	for _, table := range tables {
+		URL := c.invalidatedURL(table)
+		if ok, _ := c.afs.Exists(ctx, URL); !ok {
+			continue
+		}
+
+		data, err := c.afs.DownloadWithURL(ctx, URL)
+		if err != nil {
+			return false, err
+		}
+
+		if invalidated, _ := strconv.ParseInt(string(data), 10, 64); invalidated >= started {
+			stale = true
+			break
+		}
+	}
+
+	if !stale {
+		return false, nil
+	}
+
+	for _, URL := range URLs {
+		if err := c.deleteDependencies(ctx, tables, URL); err != nil {
+			return true, err
+		}
+
+		if ok, _ := c.afs.Exists(ctx, URL); !ok {
+			continue
+		}
+
+		if err := c.afs.Delete(ctx, URL); err != nil {
+			return true, err
+		}
+	}
+
+	return true, nil
+}
+
+//deleteDependencies removes dependency files of URL
+func (c *Cache) deleteDependencies(ctx context.Context, tables []string, URL string) error {
+	for _, table := range tables {
+		dependencyURL, err := c.dependencyURL(table, URL)
+		if err != nil {
+			return err
+		}
+
+		if ok, _ := c.afs.Exists(ctx, dependencyURL); !ok {
+			continue
+		}
+
+		if err = c.afs.Delete(ctx, dependencyURL); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+//dependencyURL returns URL of table dependency file of URL
+func (c *Cache) dependencyURL(table string, URL string) (string, error) {
+	key, err := hash.GenerateWithMarshal("", "", "", []byte(URL))
+	if err != nil {
+		return "", err
+	}
+
+	return c.tableURL(table) + "/" + key + c.extension, nil
+}
+
+//invalidatedURL returns URL of table invalidation time file
+func (c *Cache) invalidatedURL(table string) string {
+	return c.tableURL(table) + ".invalidated"
+}
+
+//tableURL returns URL of table dependency files folder
+func (c *Cache) tableURL(table string) string {
+	return c.storage + tablesFolder + "/" + table
+}
@@ -0,0 +1,239 @@
+package afs
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"github.com/viant/afs/option"
+	"github.com/viant/sqlx"
+	"github.com/viant/sqlx/io"
+	"github.com/viant/sqlx/io/read/cache"
+	"github.com/viant/sqlx/io/read/cache/ast"
+	"github.com/viant/sqlx/io/read/cache/hash"
+	"github.com/viant/sqlx/io/telemetry"
+	goIo "io"
+	"strings"
+	"time"
+)
+
+//IndexBy writes SQL results shard file per column value, shards are used by Get with cache.ParmetrizedQuery matching the SQL,
+//empty column writes SQL results as a regular entry
+func (c *Cache) IndexBy(ctx context.Context, db sqlx.Executor, column, SQL string, args []interface{}) (count int, err error) {
+	ctx, span := telemetry.Start(ctx, c.tracer, "sqlx.cache.afs.IndexBy", telemetry.Statement.String(SQL))
+	defer func() { telemetry.End(span, err) }()
+	if args == nil && column != "" {
+		args = []interface{}{} //matcher args are initialized with empty slice, whole result is matched with reader args
+	}
+
+	started := time.Now().UnixNano()
+	rows, err := db.QueryContext(ctx, SQL, args...)
+	if err != nil {
+		return 0, err
+	}
+
+	defer func() {
+		_ = rows.Close()
+	}()
+
+	columnTypes, err := rows.ColumnTypes()
+	if err != nil {
+		return 0, err
+	}
+
+	fields, err := cache.ColumnsToFields(io.TypesToColumns(columnTypes))
+	if err != nil {
+		return 0, err
+	}
+
+	var values = make(chan *cache.Indexed, 512)
+	var fetchErr error
+	go func() {
+		fetchErr = cache.IndexRows(fields, column, rows, values, false)
+		close(values)
+	}()
+
+	argsMarshal, err := json.Marshal(args)
+	if err != nil {
+		return 0, err
+	}
+
+	key, err := hash.GenerateWithMarshal(SQL, "", "", argsMarshal)
+	if err != nil {
+		return 0, err
+	}
+
+	meta := &cache.Meta{SQL: SQL, Args: argsMarshal, Signature: c.signature, Fields: fields, Tables: ast.Tables(SQL), ExpiryTimeMs: int(cache.Now().Add(c.ttl).UnixMilli())}
+	var valueKeys [][]byte
+	for indexed := range values {
+		if err != nil || (column != "" && indexed.ColumnValue == nil) {
+			continue
+		}
+
+		URL := c.storage + key + c.extension
+		if column != "" {
+			var valueKey string
+			if valueKey, err = shardKey(indexed.ColumnValue); err != nil {
+				continue
+			}
+			URL = c.shardsURL(key, column) + "/" + valueKey + c.extension
+			valueKeys = append(valueKeys, []byte(valueKey))
+		}
+
+		if err = c.upload(ctx, URL, meta, indexed.Data.Bytes()); err == nil {
+			count++
+		}
+	}
+
+	if fetchErr != nil {
+		return 0, fetchErr
+	}
+
+	if err != nil {
+		return 0, err
+	}
+
+	URLs := []string{c.storage + key + c.extension}
+	if column != "" {
+		if err = c.upload(ctx, c.markerURL(key, column), meta, bytes.Join(valueKeys, []byte("\n"))); err != nil { //marker data lines are shard keys
+			return 0, err
+		}
+		count++
+		URLs = []string{c.markerURL(key, column), c.shardsURL(key, column)}
+	}
+
+	for _, URL := range URLs {
+		if err = c.addDependencies(ctx, meta.Tables, URL); err != nil {
+			return 0, err
+		}
+	}
+
+	if stale, err := c.discardStale(ctx, meta.Tables, started, URLs...); stale || err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
+//upload writes meta line followed by data lines
+func (c *Cache) upload(ctx context.Context, URL string, meta *cache.Meta, data []byte) error {
+	metaMarshal, err := json.Marshal(meta)
+	if err != nil {
+		return err
+	}
+
+	if len(data) > 0 {
+		metaMarshal = append(append(metaMarshal, '\n'), data...)
+	}
+
+	return c.afs.Upload(ctx, URL, 0644, bytes.NewReader(metaMarshal), &option.SkipChecksum{Skip: true})
+}
+
+//markerURL returns URL of indexed SQL marker file
+func (c *Cache) markerURL(key string, column string) string {
+	return c.storage + strings.ToLower(column) + "/" + key + c.extension
+}
+
+//shardKey returns indexed SQL column value shard key
+func shardKey(columnValue interface{}) (string, error) {
+	valueMarshal, err := json.Marshal(columnValue)
+	if err != nil {
+		return "", err
+	}
+
+	return hash.GenerateWithMarshal("", "", "", valueMarshal)
+}
+
+//shardsURL returns URL of indexed SQL column value shards folder
+func (c *Cache) shardsURL(key string, column string) string {
+	return c.storage + strings.ToLower(column) + "/" + key
+}
+
+//readWarmup sets entry reader of shards matching matcher In values, returns false if matcher SQL was not indexed by matcher column
+//or any indexed value shard is missing, expired or not matching, partial result is a miss
+func (c *Cache) readWarmup(ctx context.Context, entry *cache.Entry, matcher *cache.ParmetrizedQuery) (bool, error) {
+	if matcher == nil || matcher.By == "" {
+		return false, nil
+	}
+
+	matcher.Init()
+	argsMarshal, err := matcher.MarshalArgs()
+	if err != nil {
+		return false, err
+	}
+
+	key, err := hash.GenerateWithMarshal(matcher.SQL, "", "", argsMarshal)
+	if err != nil {
+		return false, err
+	}
+
+	markerMeta := &cache.Meta{SQL: matcher.SQL, Args: argsMarshal, Signature: c.signature}
+	marker, err := c.openShard(ctx, c.markerURL(key, matcher.By), markerMeta)
+	if marker == nil || err != nil {
+		return false, err
+	}
+
+	markerData, err := goIo.ReadAll(marker)
+	_ = marker.Close()
+	if err != nil {
+		return false, err
+	}
+
+	indexed := map[string]bool{}
+	for _, valueKey := range bytes.Split(markerData, []byte("\n")) {
+		indexed[string(valueKey)] = true
+	}
+
+	multiReader := NewMultiReader(matcher)
+	for _, columnValue := range matcher.In {
+		valueKey, err := shardKey(columnValue)
+		if err != nil {
+			_ = multiReader.Close()
+			return false, err
+		}
+
+		if !indexed[valueKey] { //no rows for value
+			continue
+		}
+
+		shard, err := c.openShard(ctx, c.shardsURL(key, matcher.By)+"/"+valueKey+c.extension, &cache.Meta{SQL: matcher.SQL, Args: argsMarshal, Signature: c.signature})
+		if shard == nil || err != nil {
+			_ = multiReader.Close()
+			return false, err
+		}
+
+		multiReader.AddReader(shard)
+	}
+
+	entry.Meta.Type = markerMeta.Type
+	entry.Meta.Fields = markerMeta.Fields
+	entry.SetReader(multiReader, multiReader)
+	return true, nil
+}
+
+//openShard returns shard reader positioned after valid shard meta or nil, expired or not matching shard is removed
+func (c *Cache) openShard(ctx context.Context, URL string, meta *cache.Meta) (*Reader, error) {
+	if ok, err := c.afs.Exists(ctx, URL); !ok || err != nil {
+		return nil, nil
+	}
+
+	afsReader, err := c.afs.OpenURL(ctx, URL, c.stream)
+	if isRateError(err) || isPreConditionError(err) {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	reader := &Reader{Reader: bufio.NewReader(afsReader), closer: afsReader}
+	metaCorrect, err := c.checkMeta(reader, meta)
+	if !metaCorrect || err != nil {
+		_ = reader.Close()
+		if err != nil {
+			return nil, err
+		}
+		return nil, c.afs.Delete(ctx, URL)
+	}
+
+	return reader, nil
+}
@@ -0,0 +1,123 @@
+package afs
+
+import (
+	"bufio"
+	"bytes"
+	"github.com/viant/sqlx/io/read/cache"
+	"io"
+	"sync"
+)
+
+type (
+	//Reader represents shard file reader
+	Reader struct {
+		*bufio.Reader
+		closer io.Closer
+	}
+
+	//MultiReader represents shards reader, matcher Offset and Limit are applied per shard
+	MultiReader struct {
+		matcher       *cache.ParmetrizedQuery
+		mux           sync.Mutex
+		readers       []*Reader
+		consumed      []*Reader
+		buffer        bytes.Buffer
+		readSoFar     int
+		currentReader *Reader
+	}
+)
+
+func (r *Reader) Close() error {
+	return r.closer.Close()
+}
+
+func NewMultiReader(matcher *cache.ParmetrizedQuery) *MultiReader {
+	return &MultiReader{
+		matcher: matcher,
+	}
+}
+
+func (m *MultiReader) AddReader(reader *Reader) {
+	m.mux.Lock()
+	m.readers = append(m.readers, reader)
+	m.mux.Unlock()
+}
+
+func (m *MultiReader) ReadLine() ([]byte, bool, error) {
+	for {
+		if m.currentReader == nil {
+			if m.currentReader = m.nextReader(); m.currentReader == nil {
+				return nil, false, io.EOF
+			}
+		}
+
+		if m.matcher.Limit <= 0 || m.readSoFar < m.matcher.Limit {
+			line, err := cache.ReadLine(m.currentReader)
+			if err == nil {
+				m.readSoFar++
+				return line, false, nil
+			}
+
+			if err != io.EOF {
+				return nil, false, err
+			}
+		}
+
+		m.currentReader = nil
+	}
+}
+
+// nextReader returns the next shard reader positioned after matcher Offset lines
+func (m *MultiReader) nextReader() *Reader {
+	m.mux.Lock()
+	defer m.mux.Unlock()
+	for len(m.readers) > 0 {
+		reader := m.readers[0]
+		m.readers = m.readers[1:]
+		m.consumed = append(m.consumed, reader)
+		m.readSoFar = 0
+		var err error
+		for i := 0; i < m.matcher.Offset && err == nil; i++ {
+			_, err = cache.ReadLine(reader)
+		}
+
+		if err == nil {
+			return reader
+		}
+	}
+
+	return nil
+}
+
+func (m *MultiReader) Read(p []byte) (int, error) {
+	for m.buffer.Len() < len(p) {
+		line, _, err := m.ReadLine()
+		if err != nil {
+			break
+		}
+
+		m.buffer.Write(line)
+		m.buffer.WriteByte('\n')
+	}
+
+	if m.buffer.Len() == 0 {
+		return 0, io.EOF
+	}
+
+	return m.buffer.Read(p)
+}
+
+func (m *MultiReader) Close() error {
+	m.mux.Lock()
+	defer m.mux.Unlock()
+	var err error
+	for _, reader := range append(m.consumed, m.readers...) {
+		if closeErr := reader.Close(); closeErr != nil {
+			err = closeErr
+		}
+	}
+
+	m.consumed = nil
+	m.readers = nil
+	return err
+}
@@ -0,0 +1,68 @@
+package ast
+
+import "strings"
+
+//unchunkableKeywords represents top level keywords making query results depend on all rows
+var unchunkableKeywords = map[string]bool{
+	"distinct": true, "top": true, "group": true, "having": true, "window": true, "qualify": true, "over": true, "order": true,
+	"limit": true, "offset": true, "fetch": true, "rownum": true, "connect": true,
+}
+
+//aggregateFunctions represents aggregate functions collapsing query rows
+var aggregateFunctions = map[string]bool{
+	"count": true, "sum": true, "avg": true, "min": true, "max": true, "group_concat": true, "string_agg": true, "array_agg": true,
+	"listagg": true, "json_agg": true, "json_arrayagg": true, "stddev": true, "variance": true, "bool_and": true, "bool_or": true,
+}
+
+//IsChunkable returns true if query run for chunks of slice argument bound to placeholder at offset returns the same rows as a single query,
+//placeholder has to be the only item of column IN (?) predicate of the top level WHERE clause combined with other criteria by AND,
+//query can not use DISTINCT, aggregates, grouping, window functions, ordering, limit or compound (UNION, INTERSECT, EXCEPT) statements
+func IsChunkable(SQL string, offset int) bool {
+	tokens := sqlTokens(SQL)
+	depth := 0
+	where, found := false, false
+	for i, token := range tokens {
+		switch token.code {
+		case sqlOpenToken:
+			depth++
+			continue
+		case sqlCloseToken:
+			depth--
+			continue
+		case sqlOtherToken:
+			if token.pos == offset {
+				found = where && depth == 1 && isInList(tokens, i)
+			}
+			continue
+		case sqlNameToken:
+		default:
+			continue
+		}
+		if depth > 0 {
+			continue
+		}
+		keyword := strings.ToLower(token.text)
+		switch {
+		case unchunkableKeywords[keyword], compoundKeywords[keyword]:
+			return false
+		case aggregateFunctions[keyword] && i+1 < len(tokens) && tokens[i+1].code == sqlOpenToken:
+			return false
+		case keyword == "where":
+			where = true
+		case keyword == "or" && where:
+			return false
+		}
+	}
+	return found
+}
+
+//isInList returns true if token at index is the only item of IN list
+func isInList(tokens []*sqlToken, index int) bool {
+	if index < 2 || index+1 >= len(tokens) {
+		return false
+	}
+	if tokens[index-1].code != sqlOpenToken || tokens[index+1].code != sqlCloseToken || !strings.EqualFold(tokens[index-2].text, "in") {
+		return false
+	}
+	return index < 3 || !strings.EqualFold(tokens[index-3].text, "not")
+}
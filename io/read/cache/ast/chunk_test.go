@@ -0,0 +1,81 @@
+package ast
+
+import (
+	"github.com/stretchr/testify/assert"
+	"strings"
+	"testing"
+)
+
+func TestIsChunkable(t *testing.T) {
+	var testCases = []struct {
+		description string
+		SQL         string //slice argument is bound to the last placeholder
+		expect      bool
+	}{
+		{
+			description: "in list",
+			SQL:         "SELECT id, name FROM foo WHERE id IN (?)",
+			expect:      true,
+		},
+		{
+			description: "in list with other criteria and subquery",
+			SQL:         "SELECT f.id FROM foo f JOIN bar b ON b.id = f.id WHERE f.name = ? AND (f.kind = 1 OR f.kind = 2) AND f.id IN (SELECT id FROM baz ORDER BY id) AND f.id IN (?)",
+			expect:      true,
+		},
+		{
+			description: "not in list",
+			SQL:         "SELECT id FROM foo WHERE id NOT IN (?)",
+		},
+		{
+			description: "placeholder among list items",
+			SQL:         "SELECT id FROM foo WHERE id IN (1, ?)",
+		},
+		{
+			description: "or criterion",
+			SQL:         "SELECT id FROM foo WHERE name = ? OR id IN (?)",
+		},
+		{
+			description: "nested or criterion",
+			SQL:         "SELECT id FROM foo WHERE (name = ? OR id IN (?))",
+		},
+		{
+			description: "order by",
+			SQL:         "SELECT id FROM foo WHERE id IN (?) ORDER BY id",
+		},
+		{
+			description: "limit",
+			SQL:         "SELECT id FROM foo WHERE id IN (?) LIMIT 10",
+		},
+		{
+			description: "distinct",
+			SQL:         "SELECT DISTINCT name FROM foo WHERE id IN (?)",
+		},
+		{
+			description: "aggregate",
+			SQL:         "SELECT COUNT(*) FROM foo WHERE id IN (?)",
+		},
+		{
+			description: "group by",
+			SQL:         "SELECT kind, id FROM foo WHERE id IN (?) GROUP BY kind, id",
+		},
+		{
+			description: "window function",
+			SQL:         "SELECT id, ROW_NUMBER() OVER (PARTITION BY kind) FROM foo WHERE id IN (?)",
+		},
+		{
+			description: "union",
+			SQL:         "SELECT id FROM foo UNION ALL SELECT id FROM bar WHERE id IN (?)",
+		},
+		{
+			description: "subquery placeholder",
+			SQL:         "SELECT id FROM foo WHERE id IN (SELECT id FROM bar WHERE id IN (?))",
+		},
+		{
+			description: "select list placeholder",
+			SQL:         "SELECT id IN (?) FROM foo",
+		},
+	}
+	for _, testCase := range testCases {
+		assert.Equal(t, testCase.expect, IsChunkable(testCase.SQL, strings.LastIndexByte(testCase.SQL, '?')), testCase.description)
+	}
+}
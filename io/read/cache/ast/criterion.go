@@ -0,0 +1,61 @@
+package ast
+
+import (
+	"fmt"
+	"strings"
+)
+
+//clauseKeywords represents keywords starting SELECT clauses following WHERE clause
+var clauseKeywords = map[string]bool{
+	"group": true, "having": true, "window": true, "qualify": true, "order": true, "limit": true, "offset": true, "fetch": true, "for": true,
+}
+
+//compoundKeywords represents keywords combining SELECT statements
+var compoundKeywords = map[string]bool{"union": true, "intersect": true, "except": true, "minus": true}
+
+//AddCriterion returns SQL with criterion added to the top level query WHERE clause, so that it applies before grouping, ordering and limit,
+//existing WHERE clause is enclosed in parenthesis, subqueries are left intact, compound (UNION, INTERSECT, EXCEPT) queries are not supported
+func AddCriterion(SQL string, criterion string) (string, error) {
+	SQL = strings.TrimRight(SQL, "; \t\r\n")
+	tokens := sqlTokens(SQL)
+	depth := 0
+	from, where, end := false, -1, len(SQL)
+	for _, token := range tokens {
+		switch token.code {
+		case sqlOpenToken:
+			depth++
+			continue
+		case sqlCloseToken:
+			depth--
+			continue
+		case sqlNameToken:
+		default:
+			continue
+		}
+		if depth > 0 {
+			continue
+		}
+		keyword := strings.ToLower(token.text)
+		switch {
+		case compoundKeywords[keyword]:
+			return "", fmt.Errorf("failed to add criterion: unsupported compound query %v", token.text)
+		case keyword == "from":
+			from = true
+		case keyword == "where" && from && where == -1 && end == len(SQL):
+			where = token.pos + len(token.text)
+		case clauseKeywords[keyword] && from && end == len(SQL):
+			end = token.pos
+		}
+	}
+	if !from {
+		return "", fmt.Errorf("failed to add criterion: missing FROM clause in %v", SQL)
+	}
+	tail := ""
+	if end < len(SQL) {
+		tail = " " + SQL[end:]
+	}
+	if where == -1 {
+		return strings.TrimRight(SQL[:end], " \t\r\n") + " WHERE " + criterion + tail, nil
+	}
+	return SQL[:where] + " " + criterion + " AND (" + strings.TrimSpace(SQL[where:end]) + ")" + tail, nil
+}
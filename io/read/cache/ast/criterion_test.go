@@ -0,0 +1,67 @@
+package ast
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestAddCriterion(t *testing.T) {
+	var testCases = []struct {
+		description string
+		SQL         string
+		expect      string
+		expectErr   bool
+	}{
+		{
+			description: "without where clause",
+			SQL:         "SELECT id, name FROM foo",
+			expect:      "SELECT id, name FROM foo WHERE deleted IS NULL",
+		},
+		{
+			description: "existing where clause",
+			SQL:         "SELECT id FROM foo WHERE id = ? OR name = ?",
+			expect:      "SELECT id FROM foo WHERE deleted IS NULL AND (id = ? OR name = ?)",
+		},
+		{
+			description: "order by and limit",
+			SQL:         "SELECT id FROM foo WHERE id > ? ORDER BY id DESC LIMIT 2",
+			expect:      "SELECT id FROM foo WHERE deleted IS NULL AND (id > ?) ORDER BY id DESC LIMIT 2",
+		},
+		{
+			description: "order by and fetch without where clause",
+			SQL:         "SELECT id FROM foo ORDER BY id OFFSET 2 ROWS FETCH FIRST 2 ROWS ONLY;",
+			expect:      "SELECT id FROM foo WHERE deleted IS NULL ORDER BY id OFFSET 2 ROWS FETCH FIRST 2 ROWS ONLY",
+		},
+		{
+			description: "group by",
+			SQL:         "SELECT kind, COUNT(*) FROM foo GROUP BY kind HAVING COUNT(*) > 1",
+			expect:      "SELECT kind, COUNT(*) FROM foo WHERE deleted IS NULL GROUP BY kind HAVING COUNT(*) > 1",
+		},
+		{
+			description: "subqueries, literals and comments",
+			SQL:         "SELECT id, 'WHERE' FROM foo /* ORDER BY */ WHERE id IN (SELECT id FROM bar WHERE x = 1 ORDER BY id) ORDER BY id",
+			expect:      "SELECT id, 'WHERE' FROM foo /* ORDER BY */ WHERE deleted IS NULL AND (id IN (SELECT id FROM bar WHERE x = 1 ORDER BY id)) ORDER BY id",
+		},
+		{
+			description: "compound query",
+			SQL:         "SELECT id FROM foo UNION SELECT id FROM bar",
+			expectErr:   true,
+		},
+		{
+			description: "missing from clause",
+			SQL:         "SELECT 1",
+			expectErr:   true,
+		},
+	}
+	for _, testCase := range testCases {
+		actual, err := AddCriterion(testCase.SQL, "deleted IS NULL")
+		if testCase.expectErr {
+			assert.NotNil(t, err, testCase.description)
+			continue
+		}
+		if !assert.Nil(t, err, testCase.description) {
+			continue
+		}
+		assert.EqualValues(t, testCase.expect, actual, testCase.description)
+	}
+}
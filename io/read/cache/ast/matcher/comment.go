@@ -0,0 +1,29 @@
+package matcher
+
+import (
+	"bytes"
+	"github.com/viant/parsly"
+)
+
+type comment struct {
+	begin []byte
+	end   []byte
+}
+
+//Match matches comment, unterminated comment spans till the end of input
+func (c *comment) Match(cursor *parsly.Cursor) int {
+	input := cursor.Input[cursor.Pos:]
+	if !bytes.HasPrefix(input, c.begin) {
+		return 0
+	}
+	index := bytes.Index(input[len(c.begin):], c.end)
+	if index == -1 {
+		return len(input)
+	}
+	return len(c.begin) + index + len(c.end)
+}
+
+//NewComment creates a comment matcher
+func NewComment(begin, end string) *comment {
+	return &comment{begin: []byte(begin), end: []byte(end)}
+}
@@ -0,0 +1,57 @@
+package matcher
+
+import (
+	"bytes"
+	"github.com/viant/parsly"
+)
+
+type name struct {
+}
+
+//Match matches optionally qualified SQL name, i.e. foo, schema.foo, "Foo", `foo` or [foo]
+func (n *name) Match(cursor *parsly.Cursor) int {
+	input := cursor.Input
+	pos := cursor.Pos
+	for {
+		matched := n.matchSegment(input[pos:])
+		if matched == 0 {
+			break
+		}
+		pos += matched
+		if pos+1 >= len(input) || input[pos] != '.' || n.matchSegment(input[pos+1:]) == 0 {
+			break
+		}
+		pos++
+	}
+	return pos - cursor.Pos
+}
+
+func (n *name) matchSegment(input []byte) int {
+	switch input[0] {
+	case '"', '`':
+		if index := bytes.IndexByte(input[1:], input[0]); index != -1 {
+			return index + 2
+		}
+		return 0
+	case '[':
+		if index := bytes.IndexByte(input[1:], ']'); index != -1 {
+			return index + 2
+		}
+		return 0
+	}
+	if !IsLetter(input[0]) && input[0] != '_' {
+		return 0
+	}
+	i := 1
+	for ; i < len(input); i++ {
+		if b := input[i]; !IsLetter(b) && b != '_' && b != '$' && (b < '0' || b > '9') {
+			break
+		}
+	}
+	return i
+}
+
+//NewName creates a SQL name matcher
+func NewName() *name {
+	return &name{}
+}
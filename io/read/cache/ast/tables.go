@@ -0,0 +1,188 @@
+package ast
+
+import (
+	"github.com/viant/parsly"
+	"github.com/viant/parsly/matcher"
+	matcher2 "github.com/viant/sqlx/io/read/cache/ast/matcher"
+	"strings"
+)
+
+const (
+	sqlWhitespaceToken int = iota
+	sqlLiteralToken
+	sqlCommentToken
+	sqlNameToken
+	sqlOpenToken
+	sqlCloseToken
+	sqlCommaToken
+	sqlOtherToken
+)
+
+var sqlWhitespaceMatcher = parsly.NewToken(sqlWhitespaceToken, "Whitespace", matcher.NewWhiteSpace())
+var sqlLiteralMatcher = parsly.NewToken(sqlLiteralToken, "Literal", matcher.NewQuote('\'', '\''))
+var sqlCommentMatcher = parsly.NewToken(sqlCommentToken, "Comment", &comments{matcher2.NewComment("--", "\n"), matcher2.NewComment("/*", "*/")})
+var sqlNameMatcher = parsly.NewToken(sqlNameToken, "Name", matcher2.NewName())
+var sqlOpenMatcher = parsly.NewToken(sqlOpenToken, "(", matcher.NewByte('('))
+var sqlCloseMatcher = parsly.NewToken(sqlCloseToken, ")", matcher.NewByte(')'))
+var sqlCommaMatcher = parsly.NewToken(sqlCommaToken, ",", matcher.NewByte(','))
+
+//keywords represents SQL keywords ending table reference
+var keywords = map[string]bool{
+	"select": true, "where": true, "join": true, "inner": true, "left": true, "right": true, "full": true, "outer": true,
+	"cross": true, "natural": true, "lateral": true, "on": true, "using": true, "group": true, "order": true, "limit": true,
+	"offset": true, "union": true, "intersect": true, "except": true, "having": true, "window": true, "fetch": true,
+	"for": true, "as": true, "straight_join": true, "in": true, "exists": true, "any": true, "all": true, "some": true,
+	"not": true, "and": true, "or": true, "from": true, "with": true, "recursive": true, "values": true,
+}
+
+type (
+	comments []parsly.Matcher
+
+	sqlToken struct {
+		code int
+		text string
+		pos  int
+	}
+)
+
+//Match matches any comment
+func (c comments) Match(cursor *parsly.Cursor) int {
+	for _, candidate := range c {
+		if matched := candidate.Match(cursor); matched > 0 {
+			return matched
+		}
+	}
+	return 0
+}
+
+//Tables returns names of tables referenced by SQL FROM and JOIN clauses in order of appearance, see TableName,
+//subqueries are inspected, common table expressions, table functions and FROM used within function call are skipped
+func Tables(SQL string) []string {
+	tokens := sqlTokens(SQL)
+	var result []string
+	var unique = map[string]bool{}
+	var cteNames = map[string]bool{}
+	var functionCalls []bool //parenthesis stack, true if parenthesis opens function call
+	for i, token := range tokens {
+		switch token.code {
+		case sqlOpenToken:
+			functionCalls = append(functionCalls, i > 0 && tokens[i-1].code == sqlNameToken && !keywords[strings.ToLower(tokens[i-1].text)])
+			continue
+		case sqlCloseToken:
+			if len(functionCalls) > 0 {
+				functionCalls = functionCalls[:len(functionCalls)-1]
+			}
+			continue
+		case sqlNameToken:
+		default:
+			continue
+		}
+
+		if isCTE(tokens, i) {
+			cteNames[TableName(token.text)] = true
+			continue
+		}
+
+		keyword := strings.ToLower(token.text)
+		if keyword != "from" && keyword != "join" {
+			continue
+		}
+		if len(functionCalls) > 0 && functionCalls[len(functionCalls)-1] {
+			continue
+		}
+
+		for _, table := range tableReferences(tokens[i+1:], keyword == "from") {
+			if !unique[table] {
+				unique[table] = true
+				result = append(result, table)
+			}
+		}
+	}
+
+	var filtered = result[:0]
+	for _, table := range result {
+		if !cteNames[table] {
+			filtered = append(filtered, table)
+		}
+	}
+	return filtered
+}
+
+//TableName returns lower case unquoted table name without schema qualifier
+func TableName(name string) string {
+	name = strings.TrimSpace(name)
+	if size := len(name); size > 1 {
+		switch name[size-1] {
+		case '"', '`':
+			if index := strings.LastIndexByte(name[:size-1], name[size-1]); index != -1 {
+				return strings.ToLower(name[index+1 : size-1])
+			}
+		case ']':
+			if index := strings.LastIndexByte(name, '['); index != -1 {
+				return strings.ToLower(name[index+1 : size-1])
+			}
+		}
+	}
+	if index := strings.LastIndexByte(name, '.'); index != -1 {
+		name = name[index+1:]
+	}
+	return strings.ToLower(name)
+}
+
+//tableReferences returns names of tables referenced after FROM or JOIN, list allows comma separated references
+func tableReferences(tokens []*sqlToken, list bool) []string {
+	var result []string
+	for i := 0; i < len(tokens); {
+		token := tokens[i]
+		if token.code != sqlNameToken || keywords[strings.ToLower(token.text)] {
+			break
+		}
+		if i+1 < len(tokens) && tokens[i+1].code == sqlOpenToken { //table function
+			break
+		}
+		result = append(result, TableName(token.text))
+		i++
+		if i < len(tokens) && strings.EqualFold(tokens[i].text, "as") {
+			i++
+		}
+		if i < len(tokens) && tokens[i].code == sqlNameToken && !keywords[strings.ToLower(tokens[i].text)] { //alias
+			i++
+		}
+		if !list || i >= len(tokens) || tokens[i].code != sqlCommaToken {
+			break
+		}
+		i++
+	}
+	return result
+}
+
+//isCTE returns true if name token at index is followed by AS (, and preceded by WITH, RECURSIVE or comma
+func isCTE(tokens []*sqlToken, index int) bool {
+	if index == 0 || index+2 >= len(tokens) {
+		return false
+	}
+	if !strings.EqualFold(tokens[index+1].text, "as") || tokens[index+2].code != sqlOpenToken {
+		return false
+	}
+	previous := tokens[index-1]
+	return previous.code == sqlCommaToken || strings.EqualFold(previous.text, "with") || strings.EqualFold(previous.text, "recursive")
+}
+
+//sqlTokens returns SQL name, parenthesis, comma and other tokens, whitespaces, literals and comments are skipped
+func sqlTokens(SQL string) []*sqlToken {
+	var result []*sqlToken
+	cursor := parsly.NewCursor("", []byte(SQL), 0)
+	for cursor.HasMore() {
+		pos := cursor.Pos
+		matched := cursor.MatchAny(sqlWhitespaceMatcher, sqlCommentMatcher, sqlLiteralMatcher, sqlNameMatcher, sqlOpenMatcher, sqlCloseMatcher, sqlCommaMatcher)
+		switch matched.Code {
+		case sqlWhitespaceToken, sqlLiteralToken, sqlCommentToken:
+		case parsly.Invalid:
+			result = append(result, &sqlToken{code: sqlOtherToken, text: SQL[cursor.Pos : cursor.Pos+1], pos: pos})
+			cursor.Pos++
+		default:
+			result = append(result, &sqlToken{code: matched.Code, text: matched.Text(cursor), pos: pos})
+		}
+	}
+	return result
+}
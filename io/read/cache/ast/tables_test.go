@@ -0,0 +1,52 @@
+package ast
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestTables(t *testing.T) {
+	var testCases = []struct {
+		description string
+		SQL         string
+		expect      []string
+	}{
+		{
+			description: "single table",
+			SQL:         "SELECT id, name FROM foo WHERE id = ?",
+			expect:      []string{"foo"},
+		},
+		{
+			description: "joins, aliases and qualified names",
+			SQL:         "SELECT * FROM sales.Orders o JOIN `items` AS i ON o.id = i.order_id LEFT JOIN \"Public\".\"Stock\" s ON s.id = i.stock_id",
+			expect:      []string{"orders", "items", "stock"},
+		},
+		{
+			description: "comma separated tables",
+			SQL:         "SELECT * FROM foo f, bar AS b, baz WHERE f.id = b.id",
+			expect:      []string{"foo", "bar", "baz"},
+		},
+		{
+			description: "subqueries",
+			SQL:         "SELECT * FROM (SELECT id FROM foo) t JOIN bar b ON b.id = t.id WHERE t.id IN (SELECT id FROM baz) AND EXISTS (SELECT 1 FROM foo)",
+			expect:      []string{"foo", "bar", "baz"},
+		},
+		{
+			description: "common table expressions",
+			SQL:         "WITH recent AS (SELECT * FROM orders), top AS (SELECT * FROM recent JOIN items ON 1 = 1) SELECT * FROM top",
+			expect:      []string{"orders", "items"},
+		},
+		{
+			description: "literals, comments and function calls",
+			SQL:         "SELECT 'FROM x', EXTRACT(YEAR FROM created) /* FROM y */ FROM foo -- JOIN z\nJOIN generate_series(1, 3) g ON 1 = 1",
+			expect:      []string{"foo"},
+		},
+		{
+			description: "no tables",
+			SQL:         "SELECT 1",
+		},
+	}
+	for _, testCase := range testCases {
+		assert.EqualValues(t, testCase.expect, Tables(testCase.SQL), testCase.description)
+	}
+}
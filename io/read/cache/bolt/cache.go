@@ -0,0 +1,646 @@
+package bolt
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"github.com/viant/sqlx"
+	"github.com/viant/sqlx/io"
+	"github.com/viant/sqlx/io/read/cache"
+	"github.com/viant/sqlx/io/read/cache/ast"
+	"github.com/viant/sqlx/io/read/cache/hash"
+	"github.com/viant/sqlx/io/telemetry"
+	"github.com/viant/sqlx/option"
+	"go.etcd.io/bbolt"
+	goIo "io"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+//entriesBucket stores cache entries, entry value uses afs cache encoding: meta JSON line followed by JSON array row lines
+var entriesBucket = []byte("entries")
+
+//tablesBucket stores table dependencies, dependency key is table name followed by # and entry key
+var tablesBucket = []byte("tables")
+
+type (
+	//Cache represents embedded key/value store cache
+	Cache struct {
+		typeHolder *cache.ScanTypeHolder
+		recorder   cache.Recorder
+		tracer     option.Tracer
+		db         *bbolt.DB
+		ttl        time.Duration
+		signature  string
+
+		mux     sync.Mutex
+		pending map[string]*buffer //data of entries being populated
+		done    chan bool
+	}
+
+	//CompactionInterval represents expired entries removal interval option, default ttl, negative value disables background compaction
+	CompactionInterval time.Duration
+
+	//buffer represents entry data writer
+	buffer struct {
+		bytes.Buffer
+		tables []string
+	}
+
+	//reader represents entry data reader
+	reader struct {
+		*bufio.Reader
+	}
+)
+
+//NewCache creates cache stored in file at path, ttl defines entry expiry, non-positive ttl disables expiry and default background compaction,
+//entries are then kept till deleted or invalidated, supported options: cache.Recorder, option.Tracer and CompactionInterval
+func NewCache(path string, ttl time.Duration, signature string, options ...interface{}) (*Cache, error) {
+	interval := ttl
+	result := &Cache{
+		ttl:       ttl,
+		signature: signature,
+		pending:   map[string]*buffer{},
+		done:      make(chan bool),
+	}
+	for _, anOption := range options {
+		switch actual := anOption.(type) {
+		case cache.Recorder:
+			result.recorder = actual
+		case option.Tracer:
+			result.tracer = actual
+		case CompactionInterval:
+			interval = time.Duration(actual)
+		}
+	}
+	db, err := bbolt.Open(path, 0644, &bbolt.Options{Timeout: time.Second})
+	if err != nil {
+		return nil, err
+	}
+	if err = db.Update(func(tx *bbolt.Tx) error {
+		if _, err := tx.CreateBucketIfNotExists(entriesBucket); err != nil {
+			return err
+		}
+		_, err := tx.CreateBucketIfNotExists(tablesBucket)
+		return err
+	}); err != nil {
+		_ = db.Close()
+		return nil, err
+	}
+	result.db = db
+	if interval > 0 {
+		go result.compactPeriodically(interval)
+	}
+	return result, nil
+}
+
+//Get returns cache entry, entry has reader if data was cached, or writer if SQL results have to be cached,
+//nil entry is returned if the entry is being populated by other query
+func (c *Cache) Get(ctx context.Context, SQL string, args []interface{}, options ...interface{}) (entry *cache.Entry, err error) {
+	_, span := telemetry.Start(ctx, c.tracer, "sqlx.cache.bolt.Get", telemetry.Statement.String(SQL))
+	var matcher *cache.ParmetrizedQuery
+	var cacheStats *cache.Stats
+	var refresh bool
+	for _, anOption := range options {
+		switch actual := anOption.(type) {
+		case *cache.ParmetrizedQuery:
+			matcher = actual
+		case *cache.Stats:
+			cacheStats = actual
+		case cache.Refresh:
+			refresh = bool(actual)
+		}
+	}
+	if cacheStats == nil {
+		cacheStats = &cache.Stats{}
+	}
+	cacheStats.Init()
+	defer func() {
+		telemetry.End(span, err, telemetry.CacheHit.Bool(entry != nil && entry.Has()), telemetry.CacheType.String(string(cacheStats.Type)))
+	}()
+	key, err := hash.GenerateURL(SQL, "", "", args)
+	if err != nil {
+		return nil, err
+	}
+	argsMarshal, err := json.Marshal(args)
+	if err != nil {
+		return nil, err
+	}
+	entry = &cache.Entry{Meta: cache.Meta{SQL: SQL, Args: argsMarshal, URL: key, Signature: c.signature, Tables: ast.Tables(SQL)}}
+	cacheStats.Key = key
+
+	c.mux.Lock()
+	defer c.mux.Unlock()
+	if _, ok := c.pending[key]; ok {
+		return nil, nil
+	}
+	if !refresh {
+		aReader, err := c.lookup(key, &entry.Meta)
+		if err != nil {
+			return nil, err
+		}
+		if aReader != nil {
+			entry.SetReader(aReader, aReader)
+			cacheStats.Type = cache.TypeReadSingle
+			cacheStats.RecordsCounter = 1
+			return entry, nil
+		}
+		aReader, count, err := c.lookupIndexed(matcher, &entry.Meta)
+		if err != nil {
+			return nil, err
+		}
+		if aReader != nil {
+			entry.SetReader(aReader, aReader)
+			cacheStats.Type = cache.TypeReadMulti
+			cacheStats.RecordsCounter = count
+			return entry, nil
+		}
+	}
+	data := &buffer{tables: entry.Meta.Tables}
+	c.pending[key] = data
+	entry.Meta.ExpiryTimeMs = c.expiryTimeMs()
+	entry.SetWriter(cache.NewLineWriter(data), data)
+	cacheStats.Type = cache.TypeWrite
+	if entry.Meta.ExpiryTimeMs > 0 {
+		expiresAt := time.UnixMilli(int64(entry.Meta.ExpiryTimeMs))
+		cacheStats.ExpiryTime = &expiresAt
+	}
+	return entry, nil
+}
+
+//lookup returns reader positioned after valid entry meta or nil, expired or not matching entry is removed
+func (c *Cache) lookup(key string, entryMeta *cache.Meta) (*reader, error) {
+	value, err := c.value(key)
+	if value == nil || err != nil {
+		return nil, err
+	}
+	aReader := &reader{Reader: bufio.NewReader(bytes.NewReader(value))}
+	metaCorrect, err := c.checkMeta(aReader, entryMeta)
+	if !metaCorrect || err != nil {
+		return nil, c.delete(key)
+	}
+	return aReader, nil
+}
+
+//lookupIndexed returns reader of data indexed by matcher column for matcher values, with matcher Offset and Limit applied per value
+func (c *Cache) lookupIndexed(matcher *cache.ParmetrizedQuery, entryMeta *cache.Meta) (*reader, int, error) {
+	if matcher == nil || matcher.By == "" {
+		return nil, 0, nil
+	}
+	matcher.Init()
+	argsMarshal, err := matcher.MarshalArgs()
+	if err != nil {
+		return nil, 0, err
+	}
+	key, err := hash.GenerateWithMarshal(matcher.SQL, "", "", argsMarshal)
+	if err != nil {
+		return nil, 0, err
+	}
+	markerMeta := &cache.Meta{SQL: matcher.SQL, Args: argsMarshal, Signature: c.signature}
+	marker, err := c.lookup(columnKey(matcher.By, key), markerMeta)
+	if marker == nil || err != nil {
+		return nil, 0, err
+	}
+	markerData, err := goIo.ReadAll(marker)
+	if err != nil {
+		return nil, 0, err
+	}
+	indexed := map[string]bool{} //marker data lines are column value keys
+	for _, valueKey := range bytes.Split(markerData, []byte("\n")) {
+		indexed[string(valueKey)] = true
+	}
+	var result [][]byte
+	count := 0
+	for _, value := range matcher.In {
+		valueMarshal, err := json.Marshal(value)
+		if err != nil {
+			return nil, 0, err
+		}
+		valueKey := columnValueKey(matcher.By, valueMarshal, key)
+		if !indexed[valueKey] { //no rows for value
+			continue
+		}
+		valueReader, err := c.lookup(valueKey, &cache.Meta{SQL: matcher.SQL, Args: argsMarshal, Signature: c.signature})
+		if valueReader == nil || err != nil { //expired, not matching or removed, partial result is a miss
+			return nil, 0, err
+		}
+		data, err := goIo.ReadAll(valueReader)
+		if err != nil {
+			return nil, 0, err
+		}
+		count++
+		if len(data) == 0 {
+			continue
+		}
+		lines := bytes.Split(data, []byte("\n"))
+		if matcher.Offset >= len(lines) {
+			continue
+		}
+		lines = lines[matcher.Offset:]
+		if matcher.Limit > 0 && matcher.Limit < len(lines) {
+			lines = lines[:matcher.Limit]
+		}
+		result = append(result, lines...)
+	}
+	entryMeta.Type = markerMeta.Type
+	entryMeta.Fields = markerMeta.Fields
+	return &reader{Reader: bufio.NewReader(bytes.NewReader(bytes.Join(result, []byte("\n"))))}, count, nil
+}
+
+func (c *Cache) checkMeta(dataReader cache.LineReader, entryMeta *cache.Meta) (bool, error) {
+	data, err := cache.ReadLine(dataReader)
+	if err != nil {
+		return false, nil
+	}
+	meta := cache.Meta{}
+	if err = json.Unmarshal(data, &meta); err != nil {
+		return false, nil
+	}
+	if c.expired(meta) || c.wrongSignature(meta, entryMeta) || c.wrongSQL(meta, entryMeta) || c.wrongArgs(meta, entryMeta) {
+		return false, nil
+	}
+	entryMeta.Type = meta.Type
+	entryMeta.Fields = meta.Fields
+	entryMeta.ExpiryTimeMs = meta.ExpiryTimeMs
+	for _, field := range entryMeta.Fields {
+		if err = field.Init(); err != nil {
+			return false, err
+		}
+	}
+	return true, nil
+}
+
+func (c *Cache) wrongArgs(meta cache.Meta, entryMeta *cache.Meta) bool {
+	return !bytes.Equal(meta.Args, entryMeta.Args)
+}
+
+func (c *Cache) wrongSQL(meta cache.Meta, entryMeta *cache.Meta) bool {
+	return meta.SQL != entryMeta.SQL
+}
+
+func (c *Cache) wrongSignature(meta cache.Meta, entryMeta *cache.Meta) bool {
+	return meta.Signature != entryMeta.Signature
+}
+
+func (c *Cache) expired(meta cache.Meta) bool {
+	return meta.ExpiryTimeMs > 0 && int(cache.Now().UnixMilli()) > meta.ExpiryTimeMs
+}
+
+//expiryTimeMs returns new entry expiry time, zero (no expiry) for non-positive ttl, entry is then kept till deleted or invalidated
+func (c *Cache) expiryTimeMs() int {
+	if c.ttl <= 0 {
+		return 0
+	}
+	return int(cache.Now().Add(c.ttl).UnixMilli())
+}
+
+//value returns copy of stored value or nil
+func (c *Cache) value(key string) ([]byte, error) {
+	var result []byte
+	err := c.db.View(func(tx *bbolt.Tx) error {
+		if value := tx.Bucket(entriesBucket).Get([]byte(key)); value != nil {
+			result = append([]byte{}, value...)
+		}
+		return nil
+	})
+	return result, err
+}
+
+//put stores values with dependencies on tables
+func (c *Cache) put(values map[string][]byte, tables []string) error {
+	return c.db.Update(func(tx *bbolt.Tx) error {
+		bucket := tx.Bucket(entriesBucket)
+		dependencies := tx.Bucket(tablesBucket)
+		for key, value := range values {
+			if err := bucket.Put([]byte(key), value); err != nil {
+				return err
+			}
+			for _, table := range tables {
+				if err := dependencies.Put(dependencyKey(table, key), nil); err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	})
+}
+
+func (c *Cache) delete(key string) error {
+	return c.db.Update(func(tx *bbolt.Tx) error {
+		return tx.Bucket(entriesBucket).Delete([]byte(key))
+	})
+}
+
+//Compact removes expired and invalid entries with dangling table dependencies, returns removed entries count
+func (c *Cache) Compact(ctx context.Context) (count int, err error) {
+	_, span := telemetry.Start(ctx, c.tracer, "sqlx.cache.bolt.Compact")
+	defer func() { telemetry.End(span, err) }()
+	err = c.db.Update(func(tx *bbolt.Tx) error {
+		bucket := tx.Bucket(entriesBucket)
+		dependencies := tx.Bucket(tablesBucket)
+		var expired [][]byte
+		_ = bucket.ForEach(func(key, value []byte) error {
+			meta := cache.Meta{}
+			if index := bytes.IndexByte(value, '\n'); index != -1 {
+				value = value[:index]
+			}
+			if json.Unmarshal(value, &meta) != nil || c.expired(meta) {
+				expired = append(expired, append([]byte{}, key...))
+			}
+			return nil
+		})
+		for _, key := range expired {
+			if err := bucket.Delete(key); err != nil {
+				return err
+			}
+		}
+		count = len(expired)
+		var dangling [][]byte
+		_ = dependencies.ForEach(func(key, _ []byte) error {
+			if index := bytes.IndexByte(key, '#'); index == -1 || bucket.Get(key[index+1:]) == nil {
+				dangling = append(dangling, append([]byte{}, key...))
+			}
+			return nil
+		})
+		for _, key := range dangling {
+			if err := dependencies.Delete(key); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	return count, err
+}
+
+//Invalidate removes entries depending on any of supplied tables, entries being populated are not cached, returns removed entries count
+func (c *Cache) Invalidate(ctx context.Context, tables ...string) (count int, err error) {
+	_, span := telemetry.Start(ctx, c.tracer, "sqlx.cache.bolt.Invalidate", telemetry.Table.String(strings.Join(tables, ",")))
+	defer func() { telemetry.End(span, err) }()
+	c.mux.Lock()
+	defer c.mux.Unlock()
+	var names = make([]string, len(tables))
+	for i, table := range tables {
+		names[i] = ast.TableName(table)
+		for key, data := range c.pending {
+			if data.dependsOn(names[i]) {
+				delete(c.pending, key)
+			}
+		}
+	}
+	err = c.db.Update(func(tx *bbolt.Tx) error {
+		bucket := tx.Bucket(entriesBucket)
+		dependencies := tx.Bucket(tablesBucket)
+		var keys [][]byte
+		for _, name := range names {
+			prefix := dependencyKey(name, "")
+			cursor := dependencies.Cursor()
+			for key, _ := cursor.Seek(prefix); key != nil && bytes.HasPrefix(key, prefix); key, _ = cursor.Next() {
+				keys = append(keys, append([]byte{}, key...))
+			}
+		}
+		for _, key := range keys {
+			entryKey := key[bytes.IndexByte(key, '#')+1:]
+			if bucket.Get(entryKey) != nil {
+				if err := bucket.Delete(entryKey); err != nil {
+					return err
+				}
+				count++
+			}
+			if err := dependencies.Delete(key); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	return count, err
+}
+
+func (c *Cache) compactPeriodically(interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-c.done:
+			return
+		case <-ticker.C:
+			_, _ = c.Compact(context.Background())
+		}
+	}
+}
+
+//Shutdown stops background compaction and closes store
+func (c *Cache) Shutdown() error {
+	close(c.done)
+	return c.db.Close()
+}
+
+func (c *Cache) AddValues(ctx context.Context, entry *cache.Entry, values []interface{}) error {
+	if c.recorder != nil {
+		c.recorder.AddValues(values)
+	}
+	data, err := json.Marshal(values)
+	if err != nil {
+		return err
+	}
+	return entry.Write(data)
+}
+
+func (c *Cache) AssignRows(entry *cache.Entry, rows *sql.Rows) error {
+	return entry.AssignRows(rows)
+}
+
+func (c *Cache) UpdateType(ctx context.Context, entry *cache.Entry, values []interface{}) (bool, error) {
+	c.ensureTypeHolder(values)
+	if !c.typeHolder.Match(entry) {
+		return false, c.Delete(ctx, entry)
+	}
+	return true, nil
+}
+
+func (c *Cache) ensureTypeHolder(values []interface{}) {
+	c.mux.Lock()
+	defer c.mux.Unlock()
+	if c.typeHolder != nil {
+		return
+	}
+	c.typeHolder = &cache.ScanTypeHolder{}
+	c.typeHolder.InitType(values)
+}
+
+func (c *Cache) scanner(e *cache.Entry) cache.ScannerFn {
+	return cache.NewScanner(c.typeHolder, c.recorder).New(e)
+}
+
+//Close stores populated entry with its meta
+func (c *Cache) Close(ctx context.Context, entry *cache.Entry) (err error) {
+	_, span := telemetry.Start(ctx, c.tracer, "sqlx.cache.bolt.Close", telemetry.CacheKey.String(entry.Meta.URL))
+	defer func() { telemetry.End(span, err) }()
+	if entry.Has() {
+		return entry.Close()
+	}
+	if err = entry.Close(); err != nil {
+		_ = c.Delete(ctx, entry)
+		return err
+	}
+	c.mux.Lock()
+	defer c.mux.Unlock()
+	data, ok := c.pending[entry.Meta.URL]
+	if !ok {
+		return nil
+	}
+	delete(c.pending, entry.Meta.URL)
+	value, err := encode(&entry.Meta, data.Bytes())
+	if err != nil {
+		return err
+	}
+	return c.put(map[string][]byte{entry.Meta.URL: value}, entry.Meta.Tables)
+}
+
+//Delete removes cache entry
+func (c *Cache) Delete(ctx context.Context, entry *cache.Entry) (err error) {
+	_, span := telemetry.Start(ctx, c.tracer, "sqlx.cache.bolt.Delete", telemetry.CacheKey.String(entry.Meta.URL))
+	defer func() { telemetry.End(span, err) }()
+	c.mux.Lock()
+	delete(c.pending, entry.Meta.URL)
+	c.mux.Unlock()
+	return c.delete(entry.Meta.URL)
+}
+
+//Rollback discards populated entry data
+func (c *Cache) Rollback(ctx context.Context, entry *cache.Entry) error {
+	entry.WriteCloser = nil
+	return c.Delete(ctx, entry)
+}
+
+//IndexBy caches SQL results indexed by column value for cache.ParmetrizedQuery lookups, empty column caches SQL results as a single entry
+func (c *Cache) IndexBy(ctx context.Context, db sqlx.Executor, column, SQL string, args []interface{}) (count int, err error) {
+	ctx, span := telemetry.Start(ctx, c.tracer, "sqlx.cache.bolt.IndexBy", telemetry.Statement.String(SQL))
+	defer func() { telemetry.End(span, err) }()
+	if args == nil && column != "" {
+		args = []interface{}{} //matcher args are initialized with empty slice, whole result is matched with reader args
+	}
+	rows, err := db.QueryContext(ctx, SQL, args...)
+	if err != nil {
+		return 0, err
+	}
+	defer func() {
+		_ = rows.Close()
+	}()
+	columnTypes, err := rows.ColumnTypes()
+	if err != nil {
+		return 0, err
+	}
+	fields, err := cache.ColumnsToFields(io.TypesToColumns(columnTypes))
+	if err != nil {
+		return 0, err
+	}
+	values := make(chan *cache.Indexed, 512)
+	var fetchErr error
+	go func() {
+		fetchErr = cache.IndexRows(fields, column, rows, values, false)
+		close(values)
+	}()
+	argsMarshal, err := json.Marshal(args)
+	if err != nil {
+		return 0, err
+	}
+	key, err := hash.GenerateWithMarshal(SQL, "", "", argsMarshal)
+	if err != nil {
+		return 0, err
+	}
+	meta := &cache.Meta{SQL: SQL, Args: argsMarshal, Signature: c.signature, Fields: fields, Tables: ast.Tables(SQL), ExpiryTimeMs: c.expiryTimeMs()}
+	var encodeErr error
+	batch := map[string][]byte{}
+	var valueKeys [][]byte
+	for indexed := range values {
+		if encodeErr != nil || (column != "" && indexed.ColumnValue == nil) {
+			continue
+		}
+		entryKey := key
+		if column != "" {
+			valueMarshal, err := json.Marshal(indexed.ColumnValue)
+			if err != nil {
+				encodeErr = err
+				continue
+			}
+			entryKey = columnValueKey(column, valueMarshal, key)
+			valueKeys = append(valueKeys, []byte(entryKey))
+		}
+		if batch[entryKey], encodeErr = encode(meta, indexed.Data.Bytes()); encodeErr == nil {
+			count++
+		}
+	}
+	if fetchErr != nil {
+		return 0, fetchErr
+	}
+	if encodeErr != nil {
+		return 0, encodeErr
+	}
+	if column != "" {
+		if batch[columnKey(column, key)], err = encode(meta, bytes.Join(valueKeys, []byte("\n"))); err != nil {
+			return 0, err
+		}
+		count++
+	}
+	if err = c.put(batch, meta.Tables); err != nil {
+		return 0, err
+	}
+	return count, nil
+}
+
+func (c *Cache) AsSource(ctx context.Context, entry *cache.Entry) (cache.Source, error) {
+	return &Source{entry: entry, cache: c}, nil
+}
+
+//encode returns meta line followed by data lines
+func encode(meta *cache.Meta, data []byte) ([]byte, error) {
+	metaMarshal, err := json.Marshal(meta)
+	if err != nil {
+		return nil, err
+	}
+	if len(data) == 0 {
+		return metaMarshal, nil
+	}
+	return append(append(metaMarshal, '\n'), data...), nil
+}
+
+func dependencyKey(table, key string) []byte {
+	return []byte(table + "#" + key)
+}
+
+func columnKey(column, key string) string {
+	return strings.ToLower(column) + "#" + key
+}
+
+func columnValueKey(column string, valueMarshal []byte, key string) string {
+	return strings.ToLower(column) + "#" + strconv.Quote(string(valueMarshal)) + "#" + key
+}
+
+//dependsOn returns true if buffered entry depends on table
+func (b *buffer) dependsOn(table string) bool {
+	for _, candidate := range b.tables {
+		if candidate == table {
+			return true
+		}
+	}
+	return false
+}
+
+//Flush flushes buffer
+func (b *buffer) Flush() error {
+	return nil
+}
+
+//Close closes buffer
+func (b *buffer) Close() error {
+	return nil
+}
+
+//Close closes reader
+func (r *reader) Close() error {
+	return nil
+}
@@ -0,0 +1,357 @@
+package bolt_test
+
+import (
+	"context"
+	"database/sql"
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/assert"
+	"github.com/viant/sqlx/io/read"
+	"github.com/viant/sqlx/io/read/cache"
+	"github.com/viant/sqlx/io/read/cache/bolt"
+	"github.com/viant/sqlx/option"
+	"os"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestCache_Get(t *testing.T) {
+	type warmup struct {
+		column string
+		SQL    string
+	}
+	var testCases = []struct {
+		description     string
+		signature       string
+		populate        string
+		warmup          *warmup
+		matcher         *cache.ParmetrizedQuery
+		noTTL           bool
+		elapsed         time.Duration
+		skipCompact     bool
+		query           string
+		expect          []int
+		expectType      cache.Type
+		expectCompacted int
+	}{
+		{
+			description: "cached query reopened",
+			populate:    "SELECT id, name FROM t_bolt ORDER BY id",
+			query:       "SELECT id, name FROM t_bolt ORDER BY id",
+			expect:      []int{1, 2, 3},
+			expectType:  cache.TypeReadSingle,
+		},
+		{
+			description:     "expired entry compacted",
+			populate:        "SELECT id, name FROM t_bolt ORDER BY id",
+			elapsed:         2 * time.Minute,
+			query:           "SELECT id, name FROM t_bolt ORDER BY id",
+			expect:          []int{1},
+			expectType:      cache.TypeWrite,
+			expectCompacted: 1,
+		},
+		{
+			description: "entry without ttl does not expire",
+			populate:    "SELECT id, name FROM t_bolt ORDER BY id",
+			noTTL:       true,
+			elapsed:     24 * time.Hour,
+			query:       "SELECT id, name FROM t_bolt ORDER BY id",
+			expect:      []int{1, 2, 3},
+			expectType:  cache.TypeReadSingle,
+		},
+		{
+			description: "signature mismatch",
+			signature:   "v2",
+			populate:    "SELECT id, name FROM t_bolt ORDER BY id",
+			query:       "SELECT id, name FROM t_bolt ORDER BY id",
+			expect:      []int{1},
+			expectType:  cache.TypeWrite,
+		},
+		{
+			description: "indexed warmup with limit",
+			warmup:      &warmup{column: "name", SQL: "SELECT id, name FROM t_bolt ORDER BY id"},
+			matcher: &cache.ParmetrizedQuery{
+				SQL:   "SELECT id, name FROM t_bolt ORDER BY id",
+				By:    "name",
+				In:    []interface{}{"a", "c"},
+				Limit: 1,
+			},
+			query:      "SELECT id, name FROM t_bolt WHERE name IN ('a', 'c') ORDER BY id",
+			expect:     []int{1, 3},
+			expectType: cache.TypeReadMulti,
+		},
+		{
+			description: "expired indexed warmup compacted",
+			warmup:      &warmup{column: "name", SQL: "SELECT id, name FROM t_bolt ORDER BY id"},
+			matcher: &cache.ParmetrizedQuery{
+				SQL: "SELECT id, name FROM t_bolt ORDER BY id",
+				By:  "name",
+				In:  []interface{}{"a", "c"},
+			},
+			elapsed:         2 * time.Minute,
+			query:           "SELECT id, name FROM t_bolt WHERE name IN ('a', 'c') ORDER BY id",
+			expect:          []int{1},
+			expectType:      cache.TypeWrite,
+			expectCompacted: 3,
+		},
+		{
+			description: "indexed warmup without ttl does not expire",
+			warmup:      &warmup{column: "name", SQL: "SELECT id, name FROM t_bolt ORDER BY id"},
+			matcher: &cache.ParmetrizedQuery{
+				SQL: "SELECT id, name FROM t_bolt ORDER BY id",
+				By:  "name",
+				In:  []interface{}{"a", "c"},
+			},
+			noTTL:      true,
+			elapsed:    24 * time.Hour,
+			query:      "SELECT id, name FROM t_bolt WHERE name IN ('a', 'c') ORDER BY id",
+			expect:     []int{1, 2, 3},
+			expectType: cache.TypeReadMulti,
+		},
+		{
+			description: "indexed warmup with value without rows",
+			warmup:      &warmup{column: "name", SQL: "SELECT id, name FROM t_bolt ORDER BY id"},
+			matcher: &cache.ParmetrizedQuery{
+				SQL: "SELECT id, name FROM t_bolt ORDER BY id",
+				By:  "name",
+				In:  []interface{}{"a", "x"},
+			},
+			query:      "SELECT id, name FROM t_bolt WHERE name IN ('a', 'x') ORDER BY id",
+			expect:     []int{1, 2},
+			expectType: cache.TypeReadMulti,
+		},
+		{
+			description: "expired indexed warmup not compacted",
+			warmup:      &warmup{column: "name", SQL: "SELECT id, name FROM t_bolt ORDER BY id"},
+			matcher: &cache.ParmetrizedQuery{
+				SQL: "SELECT id, name FROM t_bolt ORDER BY id",
+				By:  "name",
+				In:  []interface{}{"a", "c"},
+			},
+			elapsed:     2 * time.Minute,
+			skipCompact: true,
+			query:       "SELECT id, name FROM t_bolt WHERE name IN ('a', 'c') ORDER BY id",
+			expect:      []int{1},
+			expectType:  cache.TypeWrite,
+		},
+	}
+	db, err := sql.Open("sqlite3", "/tmp/sqllite.db")
+	if !assert.Nil(t, err) {
+		return
+	}
+	location := "/tmp/sqlx_bolt_cache.db"
+	defer func() {
+		cache.Now = time.Now
+		_ = os.Remove(location)
+	}()
+	for _, testCase := range testCases {
+		for _, SQL := range []string{
+			"DROP TABLE IF EXISTS t_bolt",
+			"CREATE TABLE t_bolt (id INTEGER PRIMARY KEY, name TEXT)",
+			"INSERT INTO t_bolt (id, name) VALUES(1, 'a'), (2, 'a'), (3, 'c')",
+		} {
+			if _, err = db.Exec(SQL); !assert.Nil(t, err, testCase.description) {
+				return
+			}
+		}
+		_ = os.Remove(location)
+		now := time.Now()
+		cache.Now = func() time.Time { return now }
+		ttl := time.Minute
+		if testCase.noTTL {
+			ttl = 0
+		}
+		aCache, err := bolt.NewCache(location, ttl, "v1", bolt.CompactionInterval(-1))
+		if !assert.Nil(t, err, testCase.description) {
+			continue
+		}
+		if testCase.warmup != nil {
+			_, err = aCache.IndexBy(context.TODO(), db, testCase.warmup.column, testCase.warmup.SQL, nil)
+			assert.Nil(t, err, testCase.description)
+		}
+		if testCase.populate != "" {
+			_, err = queryIds(db, testCase.populate, aCache)
+			assert.Nil(t, err, testCase.description)
+		}
+		if !assert.Nil(t, aCache.Shutdown(), testCase.description) {
+			continue
+		}
+		signature := testCase.signature
+		if signature == "" {
+			signature = "v1"
+		}
+		if aCache, err = bolt.NewCache(location, ttl, signature, bolt.CompactionInterval(-1)); !assert.Nil(t, err, testCase.description) {
+			continue
+		}
+		if _, err = db.Exec("DELETE FROM t_bolt WHERE id > 1"); !assert.Nil(t, err, testCase.description) {
+			continue
+		}
+		cache.Now = func() time.Time { return now.Add(testCase.elapsed) }
+		if !testCase.skipCompact {
+			compacted, err := aCache.Compact(context.TODO())
+			assert.Nil(t, err, testCase.description)
+			assert.EqualValues(t, testCase.expectCompacted, compacted, testCase.description)
+		}
+
+		stats := &cache.Stats{}
+		options := []option.Option{aCache, stats}
+		if testCase.matcher != nil {
+			options = append(options, testCase.matcher)
+		}
+		actual, err := queryIds(db, testCase.query, options...)
+		assert.Nil(t, err, testCase.description)
+		assert.EqualValues(t, testCase.expect, actual, testCase.description)
+		assert.EqualValues(t, testCase.expectType, stats.Type, testCase.description)
+		assert.Nil(t, aCache.Shutdown(), testCase.description)
+	}
+}
+
+func queryIds(db *sql.DB, SQL string, options ...option.Option) ([]int, error) {
+	type record struct {
+		Id   int    `sqlx:"id"`
+		Name string `sqlx:"name"`
+	}
+	reader, err := read.New(context.TODO(), db, SQL, func() interface{} { return &record{} }, options...)
+	if err != nil {
+		return nil, err
+	}
+	defer reader.Close()
+	var result = []int{}
+	err = reader.QueryAll(context.TODO(), func(row interface{}) error {
+		result = append(result, row.(*record).Id)
+		return nil
+	})
+	return result, err
+}
+
+func TestCache_concurrentReaders(t *testing.T) {
+	db, err := sql.Open("sqlite3", "/tmp/sqllite.db")
+	if !assert.Nil(t, err) {
+		return
+	}
+	for _, SQL := range []string{
+		"DROP TABLE IF EXISTS t_bolt_concurrent",
+		"CREATE TABLE t_bolt_concurrent (id INTEGER PRIMARY KEY, name TEXT)",
+		"INSERT INTO t_bolt_concurrent (id, name) VALUES(1, 'a'), (2, 'b'), (3, 'c')",
+	} {
+		if _, err = db.Exec(SQL); !assert.Nil(t, err) {
+			return
+		}
+	}
+	location := "/tmp/sqlx_bolt_concurrent.db"
+	_ = os.Remove(location)
+	defer os.Remove(location)
+	aCache, err := bolt.NewCache(location, time.Minute, "v1")
+	if !assert.Nil(t, err) {
+		return
+	}
+	defer aCache.Shutdown()
+	wg := sync.WaitGroup{}
+	for i := 0; i < 30; i++ {
+		wg.Add(1)
+		go func(limit int) {
+			defer wg.Done()
+			actual, err := queryIds(db, "SELECT id, name FROM t_bolt_concurrent WHERE id <= "+strconv.Itoa(limit)+" ORDER BY id", aCache)
+			assert.Nil(t, err)
+			assert.Equal(t, limit, len(actual))
+		}(i%3 + 1)
+	}
+	wg.Wait()
+	for limit := 1; limit <= 3; limit++ {
+		stats := &cache.Stats{}
+		actual, err := queryIds(db, "SELECT id, name FROM t_bolt_concurrent WHERE id <= "+strconv.Itoa(limit)+" ORDER BY id", aCache, stats)
+		assert.Nil(t, err)
+		assert.Equal(t, limit, len(actual))
+		assert.EqualValues(t, cache.TypeReadSingle, stats.Type)
+	}
+}
+
+func TestCache_Invalidate(t *testing.T) {
+	var testCases = []struct {
+		description     string
+		tables          []string
+		expectCount     int
+		expectType      cache.Type
+		expectWarmup    cache.Type
+		expectOtherType cache.Type
+	}{
+		{
+			description:     "modified table entries and shards",
+			tables:          []string{"main.T_BOLT_INVALIDATE"},
+			expectCount:     4,
+			expectType:      cache.TypeWrite,
+			expectWarmup:    cache.TypeWrite,
+			expectOtherType: cache.TypeReadSingle,
+		},
+		{
+			description:     "other table entry",
+			tables:          []string{"t_bolt_other"},
+			expectCount:     1,
+			expectType:      cache.TypeReadSingle,
+			expectWarmup:    cache.TypeReadMulti,
+			expectOtherType: cache.TypeWrite,
+		},
+	}
+	db, err := sql.Open("sqlite3", "/tmp/sqllite.db")
+	if !assert.Nil(t, err) {
+		return
+	}
+	for _, SQL := range []string{
+		"DROP TABLE IF EXISTS t_bolt_invalidate",
+		"CREATE TABLE t_bolt_invalidate (id INTEGER PRIMARY KEY, name TEXT)",
+		"INSERT INTO t_bolt_invalidate (id, name) VALUES(1, 'a'), (2, 'b')",
+		"DROP TABLE IF EXISTS t_bolt_other",
+		"CREATE TABLE t_bolt_other (id INTEGER PRIMARY KEY, name TEXT)",
+	} {
+		if _, err = db.Exec(SQL); !assert.Nil(t, err) {
+			return
+		}
+	}
+	location := "/tmp/sqlx_bolt_invalidate.db"
+	defer func() {
+		_ = os.Remove(location)
+	}()
+	SQL := "SELECT id, name FROM t_bolt_invalidate ORDER BY id"
+	otherSQL := "SELECT id, name FROM t_bolt_other ORDER BY id"
+	matcher := &cache.ParmetrizedQuery{SQL: "SELECT id, name FROM t_bolt_invalidate t ORDER BY id", By: "name", In: []interface{}{"a"}}
+	for _, testCase := range testCases {
+		_ = os.Remove(location)
+		aCache, err := bolt.NewCache(location, time.Minute, "v1", bolt.CompactionInterval(-1))
+		if !assert.Nil(t, err, testCase.description) {
+			continue
+		}
+		_, err = aCache.IndexBy(context.TODO(), db, matcher.By, matcher.SQL, nil)
+		assert.Nil(t, err, testCase.description)
+		for _, SQL := range []string{SQL, otherSQL} {
+			_, err = queryIds(db, SQL, aCache)
+			assert.Nil(t, err, testCase.description)
+		}
+		if !assert.Nil(t, aCache.Shutdown(), testCase.description) {
+			continue
+		}
+		if aCache, err = bolt.NewCache(location, time.Minute, "v1", bolt.CompactionInterval(-1)); !assert.Nil(t, err, testCase.description) {
+			continue
+		}
+
+		count, err := aCache.Invalidate(context.TODO(), testCase.tables...)
+		assert.Nil(t, err, testCase.description)
+		assert.EqualValues(t, testCase.expectCount, count, testCase.description)
+
+		for _, query := range []struct {
+			SQL     string
+			options []option.Option
+			expect  cache.Type
+		}{
+			{SQL: SQL, expect: testCase.expectType},
+			{SQL: "SELECT id, name FROM t_bolt_invalidate WHERE name IN ('a')", options: []option.Option{matcher}, expect: testCase.expectWarmup},
+			{SQL: otherSQL, expect: testCase.expectOtherType},
+		} {
+			stats := &cache.Stats{}
+			_, err = queryIds(db, query.SQL, append(query.options, aCache, stats)...)
+			assert.Nil(t, err, testCase.description)
+			assert.EqualValues(t, query.expect, stats.Type, testCase.description+" "+query.SQL)
+		}
+		assert.Nil(t, aCache.Shutdown(), testCase.description)
+	}
+}
@@ -3,6 +3,7 @@ package cache
 import (
 	"context"
 	"database/sql"
+	"github.com/viant/sqlx"
 )
 
 type ScannerFn func(args ...interface{}) error
@@ -15,5 +16,5 @@ type Cache interface {
 	Close(ctx context.Context, entry *Entry) error
 	Delete(todo context.Context, entry *Entry) error
 	Rollback(ctx context.Context, entry *Entry) error
-	IndexBy(ctx context.Context, db *sql.DB, column, SQL string, args []interface{}) (int, error)
+	IndexBy(ctx context.Context, db sqlx.Executor, column, SQL string, args []interface{}) (int, error)
 }
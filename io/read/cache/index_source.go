@@ -0,0 +1,174 @@
+package cache
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+)
+
+type (
+	IndexSource interface {
+		Close() error
+		Index(value interface{}) *Indexed
+		ColumnIndex() int
+	}
+
+	UnorderedSource struct {
+		index       map[interface{}]int
+		indexed     []*Indexed
+		dest        chan *Indexed
+		columnIndex int
+	}
+
+	OrderedSource struct {
+		currentValue interface{}
+		indexed      *Indexed
+		dest         chan *Indexed
+		columnIndex  int
+	}
+
+	SingleSource struct {
+		indexed *Indexed
+		dest    chan *Indexed
+	}
+)
+
+func (u *UnorderedSource) ColumnIndex() int {
+	return u.columnIndex
+}
+
+func (o *OrderedSource) ColumnIndex() int {
+	return o.columnIndex
+}
+
+func (s *SingleSource) ColumnIndex() int {
+	return -1
+}
+
+func (s *SingleSource) Index(value interface{}) *Indexed {
+	return s.indexed
+}
+
+func (o *OrderedSource) Index(value interface{}) *Indexed {
+	if o.currentValue == nil {
+		o.currentValue = value
+		o.indexed = NewIndexed(value)
+	}
+	if o.currentValue != value {
+		index := *o.indexed
+		o.dest <- &index
+		o.currentValue = value
+		o.indexed = NewIndexed(value)
+	}
+	return o.indexed
+}
+
+func NewIndexSource(column string, ordered bool, fields []*Field, dest chan *Indexed) (IndexSource, error) {
+	if column == "" {
+		return NewSingleSource(dest), nil
+	}
+
+	columnLower := strings.ToLower(column)
+	columnIndex := -1
+	for i, field := range fields {
+		if strings.ToLower(field.Name()) == columnLower {
+			columnIndex = i
+			break
+		}
+	}
+
+	if columnIndex == -1 {
+		return nil, fmt.Errorf("not found column %v in the database response", column)
+	}
+
+	if ordered {
+		return NewOrderedSource(dest, columnIndex), nil
+	} else {
+		return NewUnorderedSource(dest, columnIndex), nil
+	}
+}
+
+func NewSingleSource(dest chan *Indexed) *SingleSource {
+	return &SingleSource{
+		indexed: NewIndexed(nil),
+		dest:    dest,
+	}
+}
+
+func (s *SingleSource) Close() error {
+	s.dest <- s.indexed
+	return nil
+}
+
+func NewUnorderedSource(dest chan *Indexed, index int) *UnorderedSource {
+	return &UnorderedSource{
+		index:       map[interface{}]int{},
+		dest:        dest,
+		columnIndex: index,
+	}
+}
+
+func (u *UnorderedSource) Close() error {
+	for i := range u.indexed {
+		u.dest <- u.indexed[i]
+	}
+
+	return nil
+}
+
+func (u *UnorderedSource) Index(columnValue interface{}) *Indexed {
+	argIndex, ok := u.index[columnValue]
+	if !ok {
+		argIndex = len(u.indexed)
+		u.index[columnValue] = argIndex
+		u.indexed = append(u.indexed, NewIndexed(columnValue))
+	}
+	return u.indexed[argIndex]
+}
+
+func NewOrderedSource(dest chan *Indexed, index int) *OrderedSource {
+	return &OrderedSource{
+		dest:        dest,
+		columnIndex: index,
+	}
+}
+
+func (o *OrderedSource) Close() error {
+	if o.indexed != nil {
+		o.dest <- o.indexed
+		o.indexed = nil
+	}
+	return nil
+}
+
+//IndexRows scans rows and indexes them by column value with IndexSource, empty column indexes all rows as a single value
+func IndexRows(fields []*Field, column string, rows *sql.Rows, dest chan *Indexed, ordered bool) error {
+	indexSource, err := NewIndexSource(column, ordered, fields, dest)
+	if err != nil {
+		return err
+	}
+
+	placeholders := NewPlaceholders(indexSource.ColumnIndex(), fields)
+	for rows.Next() {
+		if err = rows.Scan(placeholders.ScanPlaceholders()...); err != nil {
+			return err
+		}
+
+		columnValue, ok := placeholders.ColumnValue()
+		if !ok {
+			continue
+		}
+
+		indexed := indexSource.Index(columnValue)
+		indexed.Column = column
+		if err = indexed.StringifyData(placeholders.Values()); err != nil {
+			return err
+		}
+	}
+
+	if err = rows.Err(); err != nil {
+		return err
+	}
+
+	return indexSource.Close()
+}
@@ -0,0 +1,513 @@
+package lru
+
+import (
+	"bufio"
+	"bytes"
+	"container/list"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"github.com/viant/sqlx"
+	"github.com/viant/sqlx/io"
+	"github.com/viant/sqlx/io/read/cache"
+	"github.com/viant/sqlx/io/read/cache/ast"
+	"github.com/viant/sqlx/io/read/cache/hash"
+	"github.com/viant/sqlx/io/telemetry"
+	"github.com/viant/sqlx/option"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+type (
+	//Cache represents in-process cache bounded by data size, the least recently used entries are evicted first
+	Cache struct {
+		typeHolder *cache.ScanTypeHolder
+		recorder   cache.Recorder
+		tracer     option.Tracer
+		maxSize    int
+		ttl        time.Duration
+		signature  string
+
+		mux     sync.Mutex
+		size    int
+		items   map[string]*list.Element
+		lru     *list.List
+		pending map[string]*buffer         //data of entries being populated
+		tables  map[string]map[string]bool //keys of items depending on table
+	}
+
+	item struct {
+		key     string
+		meta    cache.Meta
+		data    []byte
+		indexed map[string]bool //column value item keys of indexed SQL marker
+	}
+
+	//buffer represents entry data writer
+	buffer struct {
+		bytes.Buffer
+		tables []string
+	}
+
+	//reader represents entry data reader
+	reader struct {
+		*bufio.Reader
+	}
+)
+
+//New creates in-process cache, maxSize limits cached data size in bytes, ttl defines entry expiry,
+//non-positive ttl disables expiry, entries are then evicted by size only, supported options: cache.Recorder and option.Tracer
+func New(maxSize int, ttl time.Duration, signature string, options ...interface{}) *Cache {
+	result := &Cache{
+		maxSize:   maxSize,
+		ttl:       ttl,
+		signature: signature,
+		items:     map[string]*list.Element{},
+		lru:       list.New(),
+		pending:   map[string]*buffer{},
+		tables:    map[string]map[string]bool{},
+	}
+	for _, anOption := range options {
+		switch actual := anOption.(type) {
+		case cache.Recorder:
+			result.recorder = actual
+		case option.Tracer:
+			result.tracer = actual
+		}
+	}
+	return result
+}
+
+//Get returns cache entry, entry has reader if data was cached, or writer if SQL results have to be cached,
+//nil entry is returned if the entry is being populated by other query
+func (c *Cache) Get(ctx context.Context, SQL string, args []interface{}, options ...interface{}) (entry *cache.Entry, err error) {
+	_, span := telemetry.Start(ctx, c.tracer, "sqlx.cache.lru.Get", telemetry.Statement.String(SQL))
+	var matcher *cache.ParmetrizedQuery
+	var cacheStats *cache.Stats
+	var refresh bool
+	for _, anOption := range options {
+		switch actual := anOption.(type) {
+		case *cache.ParmetrizedQuery:
+			matcher = actual
+		case *cache.Stats:
+			cacheStats = actual
+		case cache.Refresh:
+			refresh = bool(actual)
+		}
+	}
+	if cacheStats == nil {
+		cacheStats = &cache.Stats{}
+	}
+	cacheStats.Init()
+	defer func() {
+		telemetry.End(span, err, telemetry.CacheHit.Bool(entry != nil && entry.Has()), telemetry.CacheType.String(string(cacheStats.Type)))
+	}()
+	key, err := hash.GenerateURL(SQL, "", "", args)
+	if err != nil {
+		return nil, err
+	}
+	argsMarshal, err := json.Marshal(args)
+	if err != nil {
+		return nil, err
+	}
+	entry = &cache.Entry{Meta: cache.Meta{SQL: SQL, Args: argsMarshal, URL: key, Signature: c.signature, Tables: ast.Tables(SQL)}}
+	cacheStats.Key = key
+
+	c.mux.Lock()
+	defer c.mux.Unlock()
+	if !refresh {
+		if anItem := c.lookup(key, &entry.Meta); anItem != nil {
+			c.setReader(entry, anItem, anItem.data)
+			cacheStats.Type = cache.TypeReadSingle
+			cacheStats.RecordsCounter = 1
+			return entry, nil
+		}
+		if data, anItem, count := c.lookupIndexed(matcher); anItem != nil {
+			c.setReader(entry, anItem, data)
+			cacheStats.Type = cache.TypeReadMulti
+			cacheStats.RecordsCounter = count
+			return entry, nil
+		}
+	}
+	if _, ok := c.pending[key]; ok {
+		return nil, nil
+	}
+	data := &buffer{tables: entry.Meta.Tables}
+	c.pending[key] = data
+	entry.Meta.ExpiryTimeMs = c.expiryTimeMs()
+	entry.SetWriter(cache.NewLineWriter(data), data)
+	cacheStats.Type = cache.TypeWrite
+	if entry.Meta.ExpiryTimeMs > 0 {
+		expiresAt := time.UnixMilli(int64(entry.Meta.ExpiryTimeMs))
+		cacheStats.ExpiryTime = &expiresAt
+	}
+	return entry, nil
+}
+
+//lookup returns valid item or nil, expired or not matching item is removed
+func (c *Cache) lookup(key string, meta *cache.Meta) *item {
+	element, ok := c.items[key]
+	if !ok {
+		return nil
+	}
+	anItem := element.Value.(*item)
+	if c.expired(anItem.meta) || c.wrongSignature(anItem.meta, meta) || c.wrongSQL(anItem.meta, meta) || c.wrongArgs(anItem.meta, meta) {
+		c.remove(element)
+		return nil
+	}
+	c.lru.MoveToFront(element)
+	return anItem
+}
+
+//lookupIndexed returns data indexed by matcher column for matcher values, with matcher Offset and Limit applied per value
+func (c *Cache) lookupIndexed(matcher *cache.ParmetrizedQuery) ([]byte, *item, int) {
+	if matcher == nil || matcher.By == "" {
+		return nil, nil, 0
+	}
+	matcher.Init()
+	argsMarshal, err := matcher.MarshalArgs()
+	if err != nil {
+		return nil, nil, 0
+	}
+	key, err := hash.GenerateWithMarshal(matcher.SQL, "", "", argsMarshal)
+	if err != nil {
+		return nil, nil, 0
+	}
+	marker := c.lookup(columnKey(matcher.By, key), &cache.Meta{SQL: matcher.SQL, Args: argsMarshal, Signature: c.signature})
+	if marker == nil {
+		return nil, nil, 0
+	}
+	var result [][]byte
+	count := 0
+	for _, value := range matcher.In {
+		valueMarshal, err := json.Marshal(value)
+		if err != nil {
+			return nil, nil, 0
+		}
+		valueKey := columnValueKey(matcher.By, valueMarshal, key)
+		if !marker.indexed[valueKey] { //no rows for value
+			continue
+		}
+		valueItem := c.lookup(valueKey, &marker.meta)
+		if valueItem == nil { //evicted or expired, partial result is a miss
+			return nil, nil, 0
+		}
+		count++
+		data := valueItem.data
+		if len(data) == 0 {
+			continue
+		}
+		lines := bytes.Split(data, []byte("\n"))
+		if matcher.Offset >= len(lines) {
+			continue
+		}
+		lines = lines[matcher.Offset:]
+		if matcher.Limit > 0 && matcher.Limit < len(lines) {
+			lines = lines[:matcher.Limit]
+		}
+		result = append(result, lines...)
+	}
+	return bytes.Join(result, []byte("\n")), marker, count
+}
+
+func (c *Cache) setReader(entry *cache.Entry, anItem *item, data []byte) {
+	entry.Meta.Type = anItem.meta.Type
+	entry.Meta.Fields = anItem.meta.Fields
+	entry.Meta.ExpiryTimeMs = anItem.meta.ExpiryTimeMs
+	aReader := &reader{Reader: bufio.NewReader(bytes.NewReader(data))}
+	entry.SetReader(aReader, aReader)
+}
+
+func (c *Cache) wrongArgs(meta cache.Meta, entryMeta *cache.Meta) bool {
+	return !bytes.Equal(meta.Args, entryMeta.Args)
+}
+
+func (c *Cache) wrongSQL(meta cache.Meta, entryMeta *cache.Meta) bool {
+	return meta.SQL != entryMeta.SQL
+}
+
+func (c *Cache) wrongSignature(meta cache.Meta, entryMeta *cache.Meta) bool {
+	return meta.Signature != entryMeta.Signature
+}
+
+func (c *Cache) expired(meta cache.Meta) bool {
+	return meta.ExpiryTimeMs > 0 && int(cache.Now().UnixMilli()) > meta.ExpiryTimeMs
+}
+
+//expiryTimeMs returns new entry expiry time, zero (no expiry) for non-positive ttl, entry is then kept till evicted by size
+func (c *Cache) expiryTimeMs() int {
+	if c.ttl <= 0 {
+		return 0
+	}
+	return int(cache.Now().Add(c.ttl).UnixMilli())
+}
+
+//put adds item and evicts the least recently used items exceeding max size, item larger than max size is not cached
+func (c *Cache) put(anItem *item) {
+	if element, ok := c.items[anItem.key]; ok {
+		c.remove(element)
+	}
+	itemSize := anItem.size()
+	if c.maxSize > 0 && itemSize > c.maxSize {
+		return
+	}
+	c.items[anItem.key] = c.lru.PushFront(anItem)
+	c.size += itemSize
+	for _, table := range anItem.meta.Tables {
+		if _, ok := c.tables[table]; !ok {
+			c.tables[table] = map[string]bool{}
+		}
+		c.tables[table][anItem.key] = true
+	}
+	for c.maxSize > 0 && c.size > c.maxSize {
+		c.remove(c.lru.Back())
+	}
+}
+
+func (c *Cache) remove(element *list.Element) {
+	anItem := c.lru.Remove(element).(*item)
+	delete(c.items, anItem.key)
+	c.size -= anItem.size()
+	for _, table := range anItem.meta.Tables {
+		if delete(c.tables[table], anItem.key); len(c.tables[table]) == 0 {
+			delete(c.tables, table)
+		}
+	}
+}
+
+//Size returns cached data size in bytes
+func (c *Cache) Size() int {
+	c.mux.Lock()
+	defer c.mux.Unlock()
+	return c.size
+}
+
+//Len returns cached entries count
+func (c *Cache) Len() int {
+	c.mux.Lock()
+	defer c.mux.Unlock()
+	return c.lru.Len()
+}
+
+func (c *Cache) AddValues(ctx context.Context, entry *cache.Entry, values []interface{}) error {
+	if c.recorder != nil {
+		c.recorder.AddValues(values)
+	}
+	data, err := json.Marshal(values)
+	if err != nil {
+		return err
+	}
+	return entry.Write(data)
+}
+
+func (c *Cache) AssignRows(entry *cache.Entry, rows *sql.Rows) error {
+	return entry.AssignRows(rows)
+}
+
+func (c *Cache) UpdateType(ctx context.Context, entry *cache.Entry, values []interface{}) (bool, error) {
+	c.ensureTypeHolder(values)
+	if !c.typeHolder.Match(entry) {
+		return false, c.Delete(ctx, entry)
+	}
+	return true, nil
+}
+
+func (c *Cache) ensureTypeHolder(values []interface{}) {
+	c.mux.Lock()
+	defer c.mux.Unlock()
+	if c.typeHolder != nil {
+		return
+	}
+	c.typeHolder = &cache.ScanTypeHolder{}
+	c.typeHolder.InitType(values)
+}
+
+//Close stores populated entry data
+func (c *Cache) Close(ctx context.Context, entry *cache.Entry) (err error) {
+	_, span := telemetry.Start(ctx, c.tracer, "sqlx.cache.lru.Close", telemetry.CacheKey.String(entry.Meta.URL))
+	defer func() { telemetry.End(span, err) }()
+	if entry.Has() {
+		return entry.Close()
+	}
+	if err = entry.Close(); err != nil {
+		_ = c.Delete(ctx, entry)
+		return err
+	}
+	c.mux.Lock()
+	defer c.mux.Unlock()
+	data, ok := c.pending[entry.Meta.URL]
+	if !ok {
+		return nil
+	}
+	delete(c.pending, entry.Meta.URL)
+	c.put(&item{key: entry.Meta.URL, meta: entry.Meta, data: data.Bytes()})
+	return nil
+}
+
+//Delete removes cache entry
+func (c *Cache) Delete(ctx context.Context, entry *cache.Entry) (err error) {
+	_, span := telemetry.Start(ctx, c.tracer, "sqlx.cache.lru.Delete", telemetry.CacheKey.String(entry.Meta.URL))
+	defer func() { telemetry.End(span, err) }()
+	c.mux.Lock()
+	defer c.mux.Unlock()
+	delete(c.pending, entry.Meta.URL)
+	if element, ok := c.items[entry.Meta.URL]; ok {
+		c.remove(element)
+	}
+	return nil
+}
+
+//Invalidate removes entries depending on any of supplied tables, entries being populated are not cached, returns removed entries count
+func (c *Cache) Invalidate(ctx context.Context, tables ...string) (count int, err error) {
+	_, span := telemetry.Start(ctx, c.tracer, "sqlx.cache.lru.Invalidate", telemetry.Table.String(strings.Join(tables, ",")))
+	defer func() { telemetry.End(span, err) }()
+	c.mux.Lock()
+	defer c.mux.Unlock()
+	for _, table := range tables {
+		table = ast.TableName(table)
+		for key := range c.tables[table] {
+			if element, ok := c.items[key]; ok {
+				c.remove(element)
+				count++
+			}
+		}
+		for key, data := range c.pending {
+			if data.dependsOn(table) {
+				delete(c.pending, key)
+			}
+		}
+	}
+	return count, nil
+}
+
+//Rollback discards populated entry data
+func (c *Cache) Rollback(ctx context.Context, entry *cache.Entry) error {
+	entry.WriteCloser = nil
+	return c.Delete(ctx, entry)
+}
+
+//IndexBy caches SQL results indexed by column value for cache.ParmetrizedQuery lookups, empty column caches SQL results as a single entry
+func (c *Cache) IndexBy(ctx context.Context, db sqlx.Executor, column, SQL string, args []interface{}) (count int, err error) {
+	ctx, span := telemetry.Start(ctx, c.tracer, "sqlx.cache.lru.IndexBy", telemetry.Statement.String(SQL))
+	defer func() { telemetry.End(span, err) }()
+	if args == nil && column != "" {
+		args = []interface{}{} //matcher args are initialized with empty slice, whole result is matched with reader args
+	}
+	argsMarshal, err := json.Marshal(args)
+	if err != nil {
+		return 0, err
+	}
+	key, err := hash.GenerateWithMarshal(SQL, "", "", argsMarshal)
+	if err != nil {
+		return 0, err
+	}
+	rows, err := db.QueryContext(ctx, SQL, args...)
+	if err != nil {
+		return 0, err
+	}
+	defer func() {
+		_ = rows.Close()
+	}()
+	columnTypes, err := rows.ColumnTypes()
+	if err != nil {
+		return 0, err
+	}
+	fields, err := cache.ColumnsToFields(io.TypesToColumns(columnTypes))
+	if err != nil {
+		return 0, err
+	}
+	meta := cache.Meta{SQL: SQL, Args: argsMarshal, Signature: c.signature, Fields: fields, Tables: ast.Tables(SQL), ExpiryTimeMs: c.expiryTimeMs()}
+	values := make(chan *cache.Indexed, 512)
+	var fetchErr error
+	go func() {
+		fetchErr = cache.IndexRows(fields, column, rows, values, false)
+		close(values)
+	}()
+	var items []*item
+	marker := &item{key: columnKey(column, key), meta: meta, indexed: map[string]bool{}}
+	for indexed := range values { //values are drained till the producer ends, items are installed once all rows are fetched
+		if err != nil {
+			continue
+		}
+		if column == "" {
+			items = append(items, &item{key: key, meta: meta, data: indexed.Data.Bytes()})
+			continue
+		}
+		if indexed.ColumnValue == nil {
+			continue
+		}
+		var valueMarshal []byte
+		if valueMarshal, err = json.Marshal(indexed.ColumnValue); err != nil {
+			continue
+		}
+		valueItem := &item{key: columnValueKey(column, valueMarshal, key), meta: meta, data: indexed.Data.Bytes()}
+		marker.indexed[valueItem.key] = true
+		items = append(items, valueItem)
+	}
+	if fetchErr != nil {
+		return 0, fetchErr
+	}
+	if err != nil {
+		return 0, err
+	}
+	if column != "" {
+		items = append(items, marker)
+	}
+	c.mux.Lock()
+	defer c.mux.Unlock()
+	for _, anItem := range items {
+		c.put(anItem)
+	}
+	return len(items), nil
+}
+
+func (c *Cache) AsSource(ctx context.Context, entry *cache.Entry) (cache.Source, error) {
+	return &Source{entry: entry, cache: c}, nil
+}
+
+func (c *Cache) scanner(e *cache.Entry) cache.ScannerFn {
+	return cache.NewScanner(c.typeHolder, c.recorder).New(e)
+}
+
+func (i *item) size() int {
+	result := len(i.key) + len(i.meta.SQL) + len(i.meta.Args) + len(i.data)
+	for key := range i.indexed {
+		result += len(key)
+	}
+	return result
+}
+
+func columnKey(column, key string) string {
+	return strings.ToLower(column) + "#" + key
+}
+
+func columnValueKey(column string, valueMarshal []byte, key string) string {
+	return strings.ToLower(column) + "#" + strconv.Quote(string(valueMarshal)) + "#" + key
+}
+
+//dependsOn returns true if buffered entry depends on table
+func (b *buffer) dependsOn(table string) bool {
+	for _, candidate := range b.tables {
+		if candidate == table {
+			return true
+		}
+	}
+	return false
+}
+
+//Flush flushes buffer
+func (b *buffer) Flush() error {
+	return nil
+}
+
+//Close closes buffer
+func (b *buffer) Close() error {
+	return nil
+}
+
+//Close closes reader
+func (r *reader) Close() error {
+	return nil
+}
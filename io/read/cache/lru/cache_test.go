@@ -0,0 +1,463 @@
+package lru_test
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/assert"
+	"github.com/viant/sqlx/io"
+	"github.com/viant/sqlx/io/delete"
+	"github.com/viant/sqlx/io/insert"
+	"github.com/viant/sqlx/io/merge"
+	"github.com/viant/sqlx/io/read"
+	"github.com/viant/sqlx/io/read/cache"
+	"github.com/viant/sqlx/io/read/cache/lru"
+	"github.com/viant/sqlx/io/update"
+	_ "github.com/viant/sqlx/metadata/product/sqlite"
+	"github.com/viant/sqlx/option"
+	"testing"
+	"time"
+)
+
+func TestCache_Get(t *testing.T) {
+	type warmup struct {
+		column string
+		SQL    string
+	}
+	var testCases = []struct {
+		description string
+		maxSize     int
+		populate    []string
+		warmup      *warmup
+		matcher     *cache.ParmetrizedQuery
+		noTTL       bool
+		elapsed     time.Duration
+		query       string
+		expect      []int
+		expectType  cache.Type
+		expectLen   int
+	}{
+		{
+			description: "cached query",
+			populate:    []string{"SELECT id, name FROM t_lru ORDER BY id"},
+			query:       "SELECT id, name FROM t_lru ORDER BY id",
+			expect:      []int{1, 2, 3},
+			expectType:  cache.TypeReadSingle,
+			expectLen:   1,
+		},
+		{
+			description: "expired entry",
+			populate:    []string{"SELECT id, name FROM t_lru ORDER BY id"},
+			elapsed:     2 * time.Minute,
+			query:       "SELECT id, name FROM t_lru ORDER BY id",
+			expect:      []int{1},
+			expectType:  cache.TypeWrite,
+			expectLen:   1,
+		},
+		{
+			description: "entry without ttl does not expire",
+			populate:    []string{"SELECT id, name FROM t_lru ORDER BY id"},
+			noTTL:       true,
+			elapsed:     24 * time.Hour,
+			query:       "SELECT id, name FROM t_lru ORDER BY id",
+			expect:      []int{1, 2, 3},
+			expectType:  cache.TypeReadSingle,
+			expectLen:   1,
+		},
+		{
+			description: "entry without ttl evicted by size",
+			maxSize:     160,
+			noTTL:       true,
+			populate:    []string{"SELECT id, name FROM t_lru ORDER BY id", "SELECT id, name FROM t_lru ORDER BY id DESC"},
+			query:       "SELECT id, name FROM t_lru ORDER BY id",
+			expect:      []int{1},
+			expectType:  cache.TypeWrite,
+			expectLen:   2,
+		},
+		{
+			description: "least recently used entry evicted by size",
+			maxSize:     160,
+			populate:    []string{"SELECT id, name FROM t_lru ORDER BY id", "SELECT id, name FROM t_lru ORDER BY id DESC"},
+			query:       "SELECT id, name FROM t_lru ORDER BY id",
+			expect:      []int{1},
+			expectType:  cache.TypeWrite,
+			expectLen:   2,
+		},
+		{
+			description: "recently used entry kept",
+			maxSize:     160,
+			populate:    []string{"SELECT id, name FROM t_lru ORDER BY id DESC", "SELECT id, name FROM t_lru ORDER BY id"},
+			query:       "SELECT id, name FROM t_lru ORDER BY id",
+			expect:      []int{1, 2, 3},
+			expectType:  cache.TypeReadSingle,
+			expectLen:   1,
+		},
+		{
+			description: "indexed warmup with limit",
+			warmup:      &warmup{column: "name", SQL: "SELECT id, name FROM t_lru ORDER BY id"},
+			matcher: &cache.ParmetrizedQuery{
+				SQL:   "SELECT id, name FROM t_lru ORDER BY id",
+				By:    "name",
+				In:    []interface{}{"a", "c"},
+				Limit: 1,
+			},
+			query:      "SELECT id, name FROM t_lru WHERE name IN ('a', 'c') ORDER BY id",
+			expect:     []int{1, 3},
+			expectType: cache.TypeReadMulti,
+			expectLen:  3,
+		},
+		{
+			description: "indexed warmup with value without rows",
+			warmup:      &warmup{column: "name", SQL: "SELECT id, name FROM t_lru ORDER BY id"},
+			matcher: &cache.ParmetrizedQuery{
+				SQL: "SELECT id, name FROM t_lru ORDER BY id",
+				By:  "name",
+				In:  []interface{}{"a", "x"},
+			},
+			query:      "SELECT id, name FROM t_lru WHERE name IN ('a', 'x') ORDER BY id",
+			expect:     []int{1, 2},
+			expectType: cache.TypeReadMulti,
+			expectLen:  3,
+		},
+		{
+			description: "indexed warmup without ttl does not expire",
+			warmup:      &warmup{column: "name", SQL: "SELECT id, name FROM t_lru ORDER BY id"},
+			matcher: &cache.ParmetrizedQuery{
+				SQL: "SELECT id, name FROM t_lru ORDER BY id",
+				By:  "name",
+				In:  []interface{}{"a", "c"},
+			},
+			noTTL:      true,
+			elapsed:    24 * time.Hour,
+			query:      "SELECT id, name FROM t_lru WHERE name IN ('a', 'c') ORDER BY id",
+			expect:     []int{1, 2, 3},
+			expectType: cache.TypeReadMulti,
+			expectLen:  3,
+		},
+		{
+			description: "indexed warmup with evicted value",
+			maxSize:     300,
+			warmup:      &warmup{column: "name", SQL: "SELECT id, name FROM t_lru ORDER BY id"},
+			populate:    []string{"SELECT id, name FROM t_lru ORDER BY id DESC"},
+			matcher: &cache.ParmetrizedQuery{
+				SQL: "SELECT id, name FROM t_lru ORDER BY id",
+				By:  "name",
+				In:  []interface{}{"a", "c"},
+			},
+			query:      "SELECT id, name FROM t_lru WHERE name IN ('a', 'c') ORDER BY id",
+			expect:     []int{1},
+			expectType: cache.TypeWrite,
+			expectLen:  2,
+		},
+	}
+	db, err := sql.Open("sqlite3", "/tmp/sqllite.db")
+	if !assert.Nil(t, err) {
+		return
+	}
+	defer func() { cache.Now = time.Now }()
+	for _, testCase := range testCases {
+		for _, SQL := range []string{
+			"DROP TABLE IF EXISTS t_lru",
+			"CREATE TABLE t_lru (id INTEGER PRIMARY KEY, name TEXT)",
+			"INSERT INTO t_lru (id, name) VALUES(1, 'a'), (2, 'a'), (3, 'c')",
+		} {
+			if _, err = db.Exec(SQL); !assert.Nil(t, err, testCase.description) {
+				return
+			}
+		}
+		now := time.Now()
+		cache.Now = func() time.Time { return now }
+		ttl := time.Minute
+		if testCase.noTTL {
+			ttl = 0
+		}
+		aCache := lru.New(testCase.maxSize, ttl, "v1")
+		if testCase.warmup != nil {
+			_, err = aCache.IndexBy(context.TODO(), db, testCase.warmup.column, testCase.warmup.SQL, nil)
+			if !assert.Nil(t, err, testCase.description) {
+				continue
+			}
+		}
+		for _, SQL := range testCase.populate {
+			_, err = queryIds(db, SQL, aCache)
+			assert.Nil(t, err, testCase.description)
+		}
+		if _, err = db.Exec("DELETE FROM t_lru WHERE id > 1"); !assert.Nil(t, err, testCase.description) {
+			continue
+		}
+		cache.Now = func() time.Time { return now.Add(testCase.elapsed) }
+		stats := &cache.Stats{}
+		options := []option.Option{aCache, stats}
+		if testCase.matcher != nil {
+			options = append(options, testCase.matcher)
+		}
+		actual, err := queryIds(db, testCase.query, options...)
+		assert.Nil(t, err, testCase.description)
+		assert.EqualValues(t, testCase.expect, actual, testCase.description)
+		assert.EqualValues(t, testCase.expectType, stats.Type, testCase.description)
+		assert.EqualValues(t, testCase.expectLen, aCache.Len(), testCase.description)
+		if testCase.maxSize > 0 {
+			assert.True(t, aCache.Size() <= testCase.maxSize, testCase.description)
+		}
+	}
+}
+
+func TestCache_Invalidate(t *testing.T) {
+	type record struct {
+		Id   int    `sqlx:"name=id,primaryKey=true"`
+		Name string `sqlx:"name"`
+	}
+	failure := fmt.Errorf("failure")
+	var testCases = []struct {
+		description string
+		write       func(ctx context.Context, db *sql.DB, aCache *lru.Cache) error
+		expect      []int
+		expectType  cache.Type
+		expectLen   int
+	}{
+		{
+			description: "insert invalidates entry",
+			write: func(ctx context.Context, db *sql.DB, aCache *lru.Cache) error {
+				inserter, err := insert.New(ctx, db, "t_lru_invalidate", aCache)
+				if err != nil {
+					return err
+				}
+				_, _, err = inserter.Exec(ctx, &record{Id: 4, Name: "d"})
+				return err
+			},
+			expect:     []int{1, 2, 3, 4},
+			expectType: cache.TypeWrite,
+			expectLen:  1,
+		},
+		{
+			description: "update with exec option invalidates entry",
+			write: func(ctx context.Context, db *sql.DB, aCache *lru.Cache) error {
+				updater, err := update.New(ctx, db, "t_lru_invalidate")
+				if err != nil {
+					return err
+				}
+				_, err = updater.Exec(ctx, &record{Id: 1, Name: "z"}, aCache)
+				return err
+			},
+			expect:     []int{1, 2, 3},
+			expectType: cache.TypeWrite,
+			expectLen:  1,
+		},
+		{
+			description: "delete invalidates entry",
+			write: func(ctx context.Context, db *sql.DB, aCache *lru.Cache) error {
+				deleter, err := delete.New(ctx, db, "t_lru_invalidate", aCache)
+				if err != nil {
+					return err
+				}
+				_, err = deleter.Exec(ctx, &record{Id: 3})
+				return err
+			},
+			expect:     []int{1, 2},
+			expectType: cache.TypeWrite,
+			expectLen:  1,
+		},
+		{
+			description: "merge invalidates entry",
+			write: func(ctx context.Context, db *sql.DB, aCache *lru.Cache) error {
+				merger, err := merge.New(ctx, db, "t_lru_invalidate", aCache)
+				if err != nil {
+					return err
+				}
+				_, _, err = merger.Exec(ctx, []*record{{Id: 1, Name: "z"}, {Id: 4, Name: "d"}})
+				return err
+			},
+			expect:     []int{1, 2, 3, 4},
+			expectType: cache.TypeWrite,
+			expectLen:  1,
+		},
+		{
+			description: "write without invalidator keeps entry",
+			write: func(ctx context.Context, db *sql.DB, aCache *lru.Cache) error {
+				inserter, err := insert.New(ctx, db, "t_lru_invalidate")
+				if err != nil {
+					return err
+				}
+				_, _, err = inserter.Exec(ctx, &record{Id: 4, Name: "d"})
+				return err
+			},
+			expect:     []int{1, 2, 3},
+			expectType: cache.TypeReadSingle,
+			expectLen:  2,
+		},
+		{
+			description: "other table write keeps entry",
+			write: func(ctx context.Context, db *sql.DB, aCache *lru.Cache) error {
+				inserter, err := insert.New(ctx, db, "t_lru_other", aCache)
+				if err != nil {
+					return err
+				}
+				_, _, err = inserter.Exec(ctx, &record{Id: 4, Name: "d"})
+				return err
+			},
+			expect:     []int{1, 2, 3},
+			expectType: cache.TypeReadSingle,
+			expectLen:  1,
+		},
+		{
+			description: "rolled back unit of work keeps entry",
+			write: func(ctx context.Context, db *sql.DB, aCache *lru.Cache) error {
+				inserter, err := insert.New(ctx, db, "t_lru_invalidate", aCache)
+				if err != nil {
+					return err
+				}
+				err = io.NewUnitOfWork(db).Run(ctx, func(ctx context.Context) error {
+					if _, _, err := inserter.Exec(ctx, &record{Id: 4, Name: "d"}); err != nil {
+						return err
+					}
+					return failure
+				})
+				if err != failure {
+					return fmt.Errorf("expected failure, but had: %v", err)
+				}
+				return nil
+			},
+			expect:     []int{1, 2, 3},
+			expectType: cache.TypeReadSingle,
+			expectLen:  2,
+		},
+		{
+			description: "committed unit of work invalidates entry",
+			write: func(ctx context.Context, db *sql.DB, aCache *lru.Cache) error {
+				inserter, err := insert.New(ctx, db, "t_lru_invalidate", aCache)
+				if err != nil {
+					return err
+				}
+				return io.NewUnitOfWork(db).Run(ctx, func(ctx context.Context) error {
+					_, _, err := inserter.Exec(ctx, &record{Id: 4, Name: "d"})
+					return err
+				})
+			},
+			expect:     []int{1, 2, 3, 4},
+			expectType: cache.TypeWrite,
+			expectLen:  1,
+		},
+		{
+			description: "caller transaction keeps entry",
+			write: func(ctx context.Context, db *sql.DB, aCache *lru.Cache) error {
+				inserter, err := insert.New(ctx, db, "t_lru_invalidate", aCache)
+				if err != nil {
+					return err
+				}
+				tx, err := db.BeginTx(ctx, nil)
+				if err != nil {
+					return err
+				}
+				if _, _, err = inserter.Exec(ctx, &record{Id: 4, Name: "d"}, tx); err != nil {
+					_ = tx.Rollback()
+					return err
+				}
+				return tx.Commit()
+			},
+			expect:     []int{1, 2, 3},
+			expectType: cache.TypeReadSingle,
+			expectLen:  2,
+		},
+		{
+			description: "caller transaction entry invalidated after commit",
+			write: func(ctx context.Context, db *sql.DB, aCache *lru.Cache) error {
+				inserter, err := insert.New(ctx, db, "t_lru_invalidate", aCache)
+				if err != nil {
+					return err
+				}
+				tx, err := db.BeginTx(ctx, nil)
+				if err != nil {
+					return err
+				}
+				if _, _, err = inserter.Exec(ctx, &record{Id: 4, Name: "d"}, tx); err != nil {
+					_ = tx.Rollback()
+					return err
+				}
+				if err = tx.Commit(); err != nil {
+					return err
+				}
+				_, err = aCache.Invalidate(ctx, "t_lru_invalidate")
+				return err
+			},
+			expect:     []int{1, 2, 3, 4},
+			expectType: cache.TypeWrite,
+			expectLen:  1,
+		},
+		{
+			description: "failed invalidation keeps write result",
+			write: func(ctx context.Context, db *sql.DB, aCache *lru.Cache) error {
+				inserter, err := insert.New(ctx, db, "t_lru_invalidate", aCache)
+				if err != nil {
+					return err
+				}
+				affected, _, err := inserter.Exec(ctx, &record{Id: 4, Name: "d"}, &failingInvalidator{err: failure})
+				if err == nil && affected != 1 {
+					return fmt.Errorf("expected 1 affected row, but had: %v", affected)
+				}
+				return err
+			},
+			expect:     []int{1, 2, 3},
+			expectType: cache.TypeReadSingle,
+			expectLen:  2,
+		},
+	}
+	db, err := sql.Open("sqlite3", "/tmp/sqllite.db")
+	if !assert.Nil(t, err) {
+		return
+	}
+	SQL := "SELECT id, name FROM t_lru_invalidate ORDER BY id"
+	for _, testCase := range testCases {
+		for _, initSQL := range []string{
+			"DROP TABLE IF EXISTS t_lru_invalidate",
+			"CREATE TABLE t_lru_invalidate (id INTEGER PRIMARY KEY, name TEXT)",
+			"INSERT INTO t_lru_invalidate (id, name) VALUES(1, 'a'), (2, 'b'), (3, 'c')",
+			"DROP TABLE IF EXISTS t_lru_other",
+			"CREATE TABLE t_lru_other (id INTEGER PRIMARY KEY, name TEXT)",
+		} {
+			if _, err = db.Exec(initSQL); !assert.Nil(t, err, testCase.description) {
+				return
+			}
+		}
+		aCache := lru.New(0, time.Minute, "v1")
+		_, err = queryIds(db, SQL, aCache)
+		assert.Nil(t, err, testCase.description)
+		_, err = queryIds(db, "SELECT id, name FROM t_lru_other ORDER BY id", aCache)
+		assert.Nil(t, err, testCase.description)
+		if !assert.Nil(t, testCase.write(context.TODO(), db, aCache), testCase.description) {
+			continue
+		}
+		assert.EqualValues(t, testCase.expectLen, aCache.Len(), testCase.description)
+		stats := &cache.Stats{}
+		actual, err := queryIds(db, SQL, aCache, stats)
+		assert.Nil(t, err, testCase.description)
+		assert.EqualValues(t, testCase.expect, actual, testCase.description)
+		assert.EqualValues(t, testCase.expectType, stats.Type, testCase.description)
+	}
+}
+
+type failingInvalidator struct {
+	err error
+}
+
+func (f *failingInvalidator) Invalidate(ctx context.Context, tables ...string) (int, error) {
+	return 0, f.err
+}
+
+func queryIds(db *sql.DB, SQL string, options ...option.Option) ([]int, error) {
+	type record struct {
+		Id   int    `sqlx:"id"`
+		Name string `sqlx:"name"`
+	}
+	reader, err := read.New(context.TODO(), db, SQL, func() interface{} { return &record{} }, options...)
+	if err != nil {
+		return nil, err
+	}
+	defer reader.Close()
+	var result = []int{}
+	err = reader.QueryAll(context.TODO(), func(row interface{}) error {
+		result = append(result, row.(*record).Id)
+		return nil
+	})
+	return result, err
+}
@@ -52,6 +52,7 @@ type (
 		ErrorType      string           `json:",omitempty"`
 		ErrorCode      types.ResultCode `json:",omitempty"`
 		ExpiryTime     *time.Time
+		Tier           string `json:",omitempty"` //tier which served cached data, set by tiered cache
 	}
 )
 
@@ -7,6 +7,7 @@ type Meta struct {
 	Signature    string
 	ExpiryTimeMs int
 	Fields       []*Field
+	Tables       []string `json:",omitempty"` //tables referenced by SQL, used to invalidate entry on table modification
 
 	URL string `json:"-" yaml:"-"`
 }
@@ -1,14 +1,13 @@
-package aerospike
+package cache
 
 import (
 	"github.com/viant/sqlx/converter"
-	"github.com/viant/sqlx/io/read/cache"
 	"github.com/viant/xunsafe"
 	"reflect"
 )
 
 type Placeholders struct {
-	fields           []*cache.Field
+	fields           []*Field
 	deref            []interface{}
 	ptrs             []interface{}
 	columnIndex      int
@@ -143,7 +142,7 @@ func (p *Placeholders) Values() []interface{} {
 	return p.deref
 }
 
-func NewPlaceholders(columnIndex int, fields []*cache.Field) *Placeholders {
+func NewPlaceholders(columnIndex int, fields []*Field) *Placeholders {
 	result := &Placeholders{
 		fields:      fields,
 		columnIndex: columnIndex,
@@ -0,0 +1,311 @@
+package tier
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"github.com/viant/sqlx"
+	"github.com/viant/sqlx/io"
+	"github.com/viant/sqlx/io/read/cache"
+	"sync"
+)
+
+const (
+	//L1 represents data served by the first (fast) tier
+	L1 = "l1"
+	//L2 represents data served by the second tier
+	L2 = "l2"
+)
+
+type (
+	//Cache represents two-tier cache, data is served from L1 when possible, L1 is populated on L2 whole entry hits,
+	//query results are written through both tiers
+	Cache struct {
+		l1      cache.Cache
+		l2      cache.Cache
+		mux     sync.Mutex
+		entries map[*cache.Entry]*entries
+	}
+
+	//entries represents tier entries of returned entry, nil entry means the tier does not take part
+	entries struct {
+		l1 *cache.Entry
+		l2 *cache.Entry
+	}
+)
+
+//New creates two-tier cache, i.e. in-process l1 over aerospike or afs l2
+func New(l1, l2 cache.Cache) *Cache {
+	return &Cache{
+		l1:      l1,
+		l2:      l2,
+		entries: map[*cache.Entry]*entries{},
+	}
+}
+
+//Get returns L1 entry if data was cached in L1, otherwise L2 entry, cache.Stats Tier reports tier which served data
+func (c *Cache) Get(ctx context.Context, SQL string, args []interface{}, options ...interface{}) (*cache.Entry, error) {
+	var cacheStats *cache.Stats
+	for _, anOption := range options {
+		if actual, ok := anOption.(*cache.Stats); ok {
+			cacheStats = actual
+		}
+	}
+	if cacheStats == nil {
+		cacheStats = &cache.Stats{}
+		options = append(options, cacheStats)
+	}
+	cacheStats.Tier = ""
+	l1Entry, err := c.l1.Get(ctx, SQL, args, options...)
+	if err != nil {
+		return nil, err
+	}
+	if l1Entry != nil && l1Entry.Has() {
+		cacheStats.Tier = L1
+		return c.register(l1Entry, &entries{l1: l1Entry}), nil
+	}
+	l2Entry, err := c.l2.Get(ctx, SQL, args, options...)
+	if err != nil {
+		if l1Entry != nil {
+			_ = c.l1.Rollback(ctx, l1Entry)
+		}
+		return nil, err
+	}
+	switch {
+	case l2Entry != nil && l2Entry.Has():
+		cacheStats.Tier = L2
+		//only whole entry populates L1, reader queries database for entry without fields, rows are not added to has-data entry,
+		//matched indexed data (cache.TypeReadMulti) is a subset of entry rows
+		if l1Entry != nil && (len(l2Entry.Meta.Fields) == 0 || cacheStats.Type != cache.TypeReadSingle) {
+			_ = c.l1.Rollback(ctx, l1Entry)
+			l1Entry = nil
+		}
+		if l1Entry != nil {
+			l1Entry.Meta.Type = l2Entry.Meta.Type
+			l1Entry.Meta.Fields = l2Entry.Meta.Fields
+		}
+		return c.register(l2Entry, &entries{l1: l1Entry, l2: l2Entry}), nil
+	case l2Entry != nil:
+		return c.register(l2Entry, &entries{l1: l1Entry, l2: l2Entry}), nil
+	case l1Entry != nil:
+		return c.register(l1Entry, &entries{l1: l1Entry}), nil
+	}
+	return nil, nil
+}
+
+func (c *Cache) register(entry *cache.Entry, tierEntries *entries) *cache.Entry {
+	c.mux.Lock()
+	defer c.mux.Unlock()
+	c.entries[entry] = tierEntries
+	return entry
+}
+
+func (c *Cache) lookup(entry *cache.Entry) *entries {
+	c.mux.Lock()
+	defer c.mux.Unlock()
+	if result, ok := c.entries[entry]; ok {
+		return result
+	}
+	return &entries{}
+}
+
+//populated returns L1 entry being populated with L2 entry data
+func (c *Cache) populated(tierEntries *entries) *cache.Entry {
+	c.mux.Lock()
+	defer c.mux.Unlock()
+	if tierEntries.l1 == nil || tierEntries.l1.Has() {
+		return nil
+	}
+	if tierEntries.l2 == nil || !tierEntries.l2.Has() {
+		return nil
+	}
+	return tierEntries.l1
+}
+
+func (c *Cache) release(entry *cache.Entry) *entries {
+	c.mux.Lock()
+	defer c.mux.Unlock()
+	result, ok := c.entries[entry]
+	if !ok {
+		return &entries{}
+	}
+	delete(c.entries, entry)
+	return result
+}
+
+//AsSource returns source of the tier which served data, L2 source populates L1 entry
+func (c *Cache) AsSource(ctx context.Context, entry *cache.Entry) (cache.Source, error) {
+	tierEntries := c.lookup(entry)
+	aCache := c.l2
+	if entry == tierEntries.l1 {
+		aCache = c.l1
+	}
+	source, err := aCache.AsSource(ctx, entry)
+	if err != nil {
+		return nil, err
+	}
+	return &Source{Source: source, cache: c, entry: entry, entries: tierEntries}, nil
+}
+
+//AddValues writes values through both tiers
+func (c *Cache) AddValues(ctx context.Context, entry *cache.Entry, values []interface{}) error {
+	tierEntries := c.lookup(entry)
+	if tierEntries.l1 != nil {
+		if err := c.l1.AddValues(ctx, tierEntries.l1, values); err != nil {
+			return err
+		}
+	}
+	if tierEntries.l2 != nil {
+		return c.l2.AddValues(ctx, tierEntries.l2, values)
+	}
+	return nil
+}
+
+func (c *Cache) AssignRows(entry *cache.Entry, rows *sql.Rows) error {
+	tierEntries := c.lookup(entry)
+	if tierEntries.l1 != nil {
+		if err := c.l1.AssignRows(tierEntries.l1, rows); err != nil {
+			return err
+		}
+	}
+	if tierEntries.l2 != nil {
+		return c.l2.AssignRows(tierEntries.l2, rows)
+	}
+	return nil
+}
+
+//UpdateType updates both tiers type, entry is deleted from both tiers if any type does not match
+func (c *Cache) UpdateType(ctx context.Context, entry *cache.Entry, values []interface{}) (bool, error) {
+	tierEntries := c.lookup(entry)
+	if tierEntries.l1 != nil {
+		if ok, err := c.l1.UpdateType(ctx, tierEntries.l1, values); !ok || err != nil {
+			return ok, notNil(err, c.Rollback(ctx, entry))
+		}
+	}
+	if tierEntries.l2 != nil {
+		if ok, err := c.l2.UpdateType(ctx, tierEntries.l2, values); !ok || err != nil {
+			return ok, notNil(err, c.Rollback(ctx, entry))
+		}
+	}
+	return true, nil
+}
+
+//Close closes both tiers entries
+func (c *Cache) Close(ctx context.Context, entry *cache.Entry) error {
+	tierEntries := c.release(entry)
+	var l1Err, l2Err error
+	if tierEntries.l1 != nil {
+		l1Err = c.l1.Close(ctx, tierEntries.l1)
+	}
+	if tierEntries.l2 != nil {
+		l2Err = c.l2.Close(ctx, tierEntries.l2)
+	}
+	return notNil(l1Err, l2Err)
+}
+
+//Delete removes entry from both tiers, entries being populated are discarded
+func (c *Cache) Delete(ctx context.Context, entry *cache.Entry) error {
+	tierEntries := c.lookup(entry)
+	c.mux.Lock()
+	l1Entry, l2Entry := tierEntries.l1, tierEntries.l2
+	if l1Entry != nil && !l1Entry.Has() {
+		tierEntries.l1 = nil
+	}
+	if l2Entry != nil && !l2Entry.Has() {
+		tierEntries.l2 = nil
+	}
+	c.mux.Unlock()
+	var l1Err, l2Err error
+	if l1Entry != nil {
+		l1Err = c.discard(ctx, c.l1, l1Entry)
+	}
+	if l2Entry != nil {
+		l2Err = c.discard(ctx, c.l2, l2Entry)
+	} else if l1Entry != nil {
+		l2Err = c.deleteL2(ctx, l1Entry.Meta)
+	}
+	return notNil(l1Err, l2Err)
+}
+
+//discard deletes cached entry data or rollbacks entry being populated
+func (c *Cache) discard(ctx context.Context, aCache cache.Cache, entry *cache.Entry) error {
+	if entry.Has() {
+		return aCache.Delete(ctx, entry)
+	}
+	return aCache.Rollback(ctx, entry)
+}
+
+//deleteL2 deletes L2 entry for L1 entry meta
+func (c *Cache) deleteL2(ctx context.Context, meta cache.Meta) error {
+	var args []interface{}
+	if len(meta.Args) > 0 {
+		decoder := json.NewDecoder(bytes.NewReader(meta.Args))
+		decoder.UseNumber() //preserves args encoding used by entry key
+		if err := decoder.Decode(&args); err != nil {
+			return err
+		}
+	}
+	entry, err := c.l2.Get(ctx, meta.SQL, args)
+	if entry == nil || err != nil {
+		return err
+	}
+	err = c.discard(ctx, c.l2, entry)
+	if entry.Has() {
+		err = notNil(err, c.l2.Close(ctx, entry))
+	}
+	return err
+}
+
+//Rollback rollbacks both tiers entries
+func (c *Cache) Rollback(ctx context.Context, entry *cache.Entry) error {
+	tierEntries := c.release(entry)
+	var l1Err, l2Err error
+	if tierEntries.l1 != nil {
+		l1Err = c.l1.Rollback(ctx, tierEntries.l1)
+	}
+	if tierEntries.l2 != nil {
+		l2Err = c.l2.Rollback(ctx, tierEntries.l2)
+	}
+	return notNil(l1Err, l2Err)
+}
+
+//IndexBy indexes SQL results in both tiers, SQL runs once, its rows are kept in memory and replayed to both tiers,
+//returns indexed entries count of both tiers
+func (c *Cache) IndexBy(ctx context.Context, db sqlx.Executor, column, SQL string, args []interface{}) (int, error) {
+	source := &replay{Executor: db}
+	defer source.Close()
+	l2Count, err := c.l2.IndexBy(ctx, source, column, SQL, args)
+	if err != nil {
+		return l2Count, err
+	}
+	l1Count, err := c.l1.IndexBy(ctx, source, column, SQL, args)
+	return l1Count + l2Count, err
+}
+
+//Invalidate removes entries depending on any of supplied tables from tiers implementing io.Invalidator, returns removed entries count of both tiers,
+//L2 is invalidated first so that L1 can not be populated with stale L2 data
+func (c *Cache) Invalidate(ctx context.Context, tables ...string) (int, error) {
+	count := 0
+	for _, aCache := range []cache.Cache{c.l2, c.l1} {
+		invalidator, ok := aCache.(io.Invalidator)
+		if !ok {
+			continue
+		}
+		tierCount, err := invalidator.Invalidate(ctx, tables...)
+		count += tierCount
+		if err != nil {
+			return count, err
+		}
+	}
+	return count, nil
+}
+
+func notNil(errors ...error) error {
+	for _, err := range errors {
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,187 @@
+package tier_test
+
+import (
+	"context"
+	"database/sql"
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/assert"
+	"github.com/viant/sqlx/io/read"
+	"github.com/viant/sqlx/io/read/cache"
+	"github.com/viant/sqlx/io/read/cache/lru"
+	"github.com/viant/sqlx/io/read/cache/tier"
+	"testing"
+	"time"
+)
+
+func TestCache_Get(t *testing.T) {
+	type record struct {
+		Id   int    `sqlx:"id"`
+		Name string `sqlx:"name"`
+	}
+	//test cases run in order against the same tiers
+	var testCases = []struct {
+		description string
+		initSQL     string
+		resetL1     bool
+		delete      bool
+		expect      []int
+		expectType  cache.Type
+		expectTier  string
+		expectL1Len int
+		expectL2Len int
+	}{
+		{
+			description: "written through both tiers",
+			expect:      []int{1, 2, 3},
+			expectType:  cache.TypeWrite,
+			expectL1Len: 1,
+			expectL2Len: 1,
+		},
+		{
+			description: "served from l1",
+			initSQL:     "DELETE FROM t_tier WHERE id = 3",
+			expect:      []int{1, 2, 3},
+			expectType:  cache.TypeReadSingle,
+			expectTier:  tier.L1,
+			expectL1Len: 1,
+			expectL2Len: 1,
+		},
+		{
+			description: "served from l2 populates l1",
+			resetL1:     true,
+			expect:      []int{1, 2, 3},
+			expectType:  cache.TypeReadSingle,
+			expectTier:  tier.L2,
+			expectL1Len: 1,
+			expectL2Len: 1,
+		},
+		{
+			description: "served from populated l1",
+			expect:      []int{1, 2, 3},
+			expectType:  cache.TypeReadSingle,
+			expectTier:  tier.L1,
+			expectL1Len: 1,
+			expectL2Len: 1,
+		},
+		{
+			description: "deleted from both tiers",
+			delete:      true,
+			expect:      []int{1, 2},
+			expectType:  cache.TypeWrite,
+			expectL1Len: 1,
+			expectL2Len: 1,
+		},
+	}
+	db, err := sql.Open("sqlite3", "/tmp/sqllite.db")
+	if !assert.Nil(t, err) {
+		return
+	}
+	for _, SQL := range []string{
+		"DROP TABLE IF EXISTS t_tier",
+		"CREATE TABLE t_tier (id INTEGER PRIMARY KEY, name TEXT)",
+		"INSERT INTO t_tier (id, name) VALUES(1, 'a'), (2, 'b'), (3, 'c')",
+	} {
+		if _, err = db.Exec(SQL); !assert.Nil(t, err) {
+			return
+		}
+	}
+	SQL := "SELECT id, name FROM t_tier ORDER BY id"
+	l1 := lru.New(0, time.Minute, "v1")
+	l2 := lru.New(0, time.Minute, "v1")
+	aCache := tier.New(l1, l2)
+	for _, testCase := range testCases {
+		if testCase.initSQL != "" {
+			if _, err = db.Exec(testCase.initSQL); !assert.Nil(t, err, testCase.description) {
+				return
+			}
+		}
+		if testCase.resetL1 {
+			l1 = lru.New(0, time.Minute, "v1")
+			aCache = tier.New(l1, l2)
+		}
+		if testCase.delete {
+			entry, err := aCache.Get(context.TODO(), SQL, nil)
+			if !assert.Nil(t, err, testCase.description) {
+				continue
+			}
+			assert.Nil(t, aCache.Delete(context.TODO(), entry), testCase.description)
+			assert.Nil(t, aCache.Close(context.TODO(), entry), testCase.description)
+		}
+		stats := &cache.Stats{}
+		reader, err := read.New(context.TODO(), db, SQL, func() interface{} { return &record{} }, aCache, stats)
+		if !assert.Nil(t, err, testCase.description) {
+			continue
+		}
+		var actual []int
+		err = reader.QueryAll(context.TODO(), func(row interface{}) error {
+			actual = append(actual, row.(*record).Id)
+			return nil
+		})
+		assert.Nil(t, err, testCase.description)
+		assert.EqualValues(t, testCase.expect, actual, testCase.description)
+		assert.EqualValues(t, testCase.expectType, stats.Type, testCase.description)
+		assert.EqualValues(t, testCase.expectTier, stats.Tier, testCase.description)
+		assert.EqualValues(t, testCase.expectL1Len, l1.Len(), testCase.description)
+		assert.EqualValues(t, testCase.expectL2Len, l2.Len(), testCase.description)
+		_ = reader.Close()
+	}
+}
+
+func TestCache_IndexBy(t *testing.T) {
+	type record struct {
+		Id   int    `sqlx:"id"`
+		Name string `sqlx:"name"`
+	}
+	db, err := sql.Open("sqlite3", "/tmp/sqllite.db")
+	if !assert.Nil(t, err) {
+		return
+	}
+	for _, SQL := range []string{
+		"DROP TABLE IF EXISTS t_tier_index",
+		"CREATE TABLE t_tier_index (id INTEGER PRIMARY KEY, name TEXT)",
+		"INSERT INTO t_tier_index (id, name) VALUES(1, 'a'), (2, 'a'), (3, 'c')",
+	} {
+		if _, err = db.Exec(SQL); !assert.Nil(t, err) {
+			return
+		}
+	}
+	matcher := &cache.ParmetrizedQuery{SQL: "SELECT id, name FROM t_tier_index ORDER BY id", By: "name", In: []interface{}{"a"}}
+	l1 := lru.New(0, time.Minute, "v1")
+	l2 := lru.New(0, time.Minute, "v1")
+	source := &countingDB{DB: db}
+	count, err := tier.New(l1, l2).IndexBy(context.TODO(), source, matcher.By, matcher.SQL, nil)
+	assert.Nil(t, err)
+	assert.EqualValues(t, 6, count)
+	assert.EqualValues(t, 1, source.queries, "source query runs once")
+	assert.EqualValues(t, 3, l1.Len())
+	assert.EqualValues(t, 3, l2.Len())
+
+	l1 = lru.New(0, time.Minute, "v1")
+	stats := &cache.Stats{}
+	reader, err := read.New(context.TODO(), db, "SELECT id, name FROM t_tier_index WHERE name IN ('a')", func() interface{} { return &record{} }, tier.New(l1, l2), stats, matcher)
+	if !assert.Nil(t, err) {
+		return
+	}
+	defer reader.Close()
+	var actual []int
+	err = reader.QueryAll(context.TODO(), func(row interface{}) error {
+		actual = append(actual, row.(*record).Id)
+		return nil
+	})
+	assert.Nil(t, err)
+	assert.EqualValues(t, []int{1, 2}, actual)
+	assert.EqualValues(t, cache.TypeReadMulti, stats.Type)
+	assert.EqualValues(t, tier.L2, stats.Tier)
+	assert.EqualValues(t, 0, l1.Len(), "matched l2 data does not populate l1")
+}
+
+//countingDB counts source queries
+type countingDB struct {
+	*sql.DB
+	queries int
+}
+
+func (d *countingDB) QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error) {
+	d.queries++
+	return d.DB.QueryContext(ctx, query, args...)
+}
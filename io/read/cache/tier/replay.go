@@ -0,0 +1,185 @@
+package tier
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"fmt"
+	"github.com/viant/sqlx"
+	goIo "io"
+	"reflect"
+)
+
+type (
+	//replay represents executor running the first query with executor, query rows are kept in memory
+	//and replayed for every query, so that tiers index the same source rows
+	replay struct {
+		sqlx.Executor
+		rows *rowSet
+		db   *sql.DB
+	}
+
+	//rowSet represents fetched rows
+	rowSet struct {
+		columns []string
+		types   []*sql.ColumnType
+		values  [][]driver.Value
+	}
+
+	replayConnector struct {
+		rows *rowSet
+	}
+
+	replayDriver struct{}
+
+	replayConn struct {
+		rows *rowSet
+	}
+
+	replayStmt struct {
+		rows *rowSet
+	}
+
+	replayRows struct {
+		*rowSet
+		index int
+	}
+)
+
+var errReplayUnsupported = fmt.Errorf("unsupported replay operation")
+
+//QueryContext returns replayed rows, rows are fetched with executor by the first call
+func (r *replay) QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error) {
+	if r.rows == nil {
+		rows, err := fetchRows(ctx, r.Executor, query, args)
+		if err != nil {
+			return nil, err
+		}
+		r.rows = rows
+		r.db = sql.OpenDB(&replayConnector{rows: rows})
+	}
+	return r.db.QueryContext(ctx, "")
+}
+
+//Close releases replayed rows
+func (r *replay) Close() error {
+	if r.db == nil {
+		return nil
+	}
+	return r.db.Close()
+}
+
+//fetchRows reads all query rows
+func fetchRows(ctx context.Context, db sqlx.Executor, query string, args []interface{}) (*rowSet, error) {
+	rows, err := db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	result := &rowSet{}
+	if result.columns, err = rows.Columns(); err != nil {
+		return nil, err
+	}
+	if result.types, err = rows.ColumnTypes(); err != nil {
+		return nil, err
+	}
+	for rows.Next() {
+		values := make([]interface{}, len(result.columns))
+		pointers := make([]interface{}, len(values))
+		for i := range values {
+			pointers[i] = &values[i]
+		}
+		if err = rows.Scan(pointers...); err != nil {
+			return nil, err
+		}
+		record := make([]driver.Value, len(values))
+		for i, value := range values {
+			record[i] = value
+		}
+		result.values = append(result.values, record)
+	}
+	return result, rows.Err()
+}
+
+func (c *replayConnector) Connect(ctx context.Context) (driver.Conn, error) {
+	return &replayConn{rows: c.rows}, nil
+}
+
+func (c *replayConnector) Driver() driver.Driver {
+	return replayDriver{}
+}
+
+func (d replayDriver) Open(name string) (driver.Conn, error) {
+	return nil, errReplayUnsupported
+}
+
+func (c *replayConn) Prepare(query string) (driver.Stmt, error) {
+	return &replayStmt{rows: c.rows}, nil
+}
+
+func (c *replayConn) Close() error {
+	return nil
+}
+
+func (c *replayConn) Begin() (driver.Tx, error) {
+	return nil, errReplayUnsupported
+}
+
+func (s *replayStmt) Close() error {
+	return nil
+}
+
+//NumInput returns -1 as replayed rows do not depend on args
+func (s *replayStmt) NumInput() int {
+	return -1
+}
+
+func (s *replayStmt) Exec(args []driver.Value) (driver.Result, error) {
+	return nil, errReplayUnsupported
+}
+
+func (s *replayStmt) Query(args []driver.Value) (driver.Rows, error) {
+	return &replayRows{rowSet: s.rows}, nil
+}
+
+func (r *replayRows) Columns() []string {
+	return r.columns
+}
+
+func (r *replayRows) Close() error {
+	return nil
+}
+
+func (r *replayRows) Next(dest []driver.Value) error {
+	if r.index >= len(r.values) {
+		return goIo.EOF
+	}
+	copy(dest, r.values[r.index])
+	r.index++
+	return nil
+}
+
+//ColumnTypeScanType returns source column scan type
+func (r *replayRows) ColumnTypeScanType(index int) reflect.Type {
+	return r.types[index].ScanType()
+}
+
+//ColumnTypeDatabaseTypeName returns source column database type name
+func (r *replayRows) ColumnTypeDatabaseTypeName(index int) string {
+	return r.types[index].DatabaseTypeName()
+}
+
+//ColumnTypeNullable returns source column nullability
+func (r *replayRows) ColumnTypeNullable(index int) (bool, bool) {
+	return r.types[index].Nullable()
+}
+
+//ColumnTypeLength returns source column length
+func (r *replayRows) ColumnTypeLength(index int) (int64, bool) {
+	return r.types[index].Length()
+}
+
+//ColumnTypePrecisionScale returns source column precision and scale
+func (r *replayRows) ColumnTypePrecisionScale(index int) (int64, int64, bool) {
+	return r.types[index].DecimalSize()
+}
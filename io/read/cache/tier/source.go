@@ -0,0 +1,65 @@
+package tier
+
+import (
+	"context"
+	"github.com/viant/sqlx/io/read/cache"
+)
+
+//Source represents tier source, rows read from L2 source are added to L1 entry being populated
+type Source struct {
+	cache.Source
+	cache   *Cache
+	entry   *cache.Entry
+	entries *entries
+	scanner cache.ScannerFn
+}
+
+func (s *Source) Scanner(ctx context.Context) cache.ScannerFn {
+	if s.scanner != nil {
+		return s.scanner
+	}
+	scanner := s.Source.Scanner(ctx)
+	s.scanner = func(args ...interface{}) error {
+		if err := scanner(args...); err != nil {
+			return err
+		}
+		return s.populate(ctx, args)
+	}
+	return s.scanner
+}
+
+//populate adds scanned values to L1 entry, L1 entry is discarded if values type does not match
+func (s *Source) populate(ctx context.Context, values []interface{}) error {
+	populated := s.cache.populated(s.entries)
+	if populated == nil {
+		return nil
+	}
+	ok, err := s.cache.l1.UpdateType(ctx, populated, values)
+	if !ok || err != nil {
+		s.cache.mux.Lock()
+		s.entries.l1 = nil
+		s.cache.mux.Unlock()
+		return notNil(err, s.cache.l1.Rollback(ctx, populated))
+	}
+	return s.cache.l1.AddValues(ctx, populated, values)
+}
+
+func (s *Source) Close(ctx context.Context) error {
+	populated := s.cache.populated(s.entries)
+	s.cache.release(s.entry)
+	err := s.Source.Close(ctx)
+	if populated != nil {
+		err = notNil(err, s.cache.l1.Close(ctx, populated))
+	}
+	return err
+}
+
+func (s *Source) Rollback(ctx context.Context) error {
+	populated := s.cache.populated(s.entries)
+	s.cache.release(s.entry)
+	err := s.Source.Rollback(ctx)
+	if populated != nil {
+		err = notNil(err, s.cache.l1.Rollback(ctx, populated))
+	}
+	return err
+}
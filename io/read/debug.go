@@ -2,6 +2,9 @@ package read
 
 var showSQL bool
 
+//ShowSQL prints executed SQL to stdout
+//
+//Deprecated: use sqlx.SQLPrinter or any other sqlx.Interceptor option
 func ShowSQL(b bool) {
 	showSQL = b
 }
@@ -0,0 +1,123 @@
+package read
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"github.com/viant/sqlx/io/read/cache"
+	goIo "io"
+)
+
+//Iterator represents pull style query rows iterator
+type Iterator struct {
+	ctx      context.Context
+	reader   *Reader
+	rows     *sql.Rows
+	source   cache.Source
+	entry    *cache.Entry
+	mapper   RowMapper
+	row      interface{}
+	err      error
+	complete bool
+	closed   bool
+}
+
+//Next advances iterator to the next row, returns false once rows are exhausted or reading failed, exhausted iterator is closed
+func (i *Iterator) Next() bool {
+	if i.closed || i.err != nil {
+		return false
+	}
+	i.row = nil
+	for i.source.Next() {
+		err := i.reader.read(i.ctx, i.source, &i.mapper, i.emit, i.entry)
+		if errors.Is(err, goIo.EOF) {
+			break
+		}
+		if err != nil {
+			i.err = err
+			return false
+		}
+		if i.row != nil {
+			return true
+		}
+	}
+	i.complete = true
+	if err := i.Close(); err != nil {
+		i.err = err
+	}
+	return false
+}
+
+func (i *Iterator) emit(row interface{}) error {
+	i.row = row
+	return nil
+}
+
+//Row returns current row
+func (i *Iterator) Row() interface{} {
+	return i.row
+}
+
+//Err returns error encountered during iteration
+func (i *Iterator) Err() error {
+	return i.err
+}
+
+//Close releases iterator resources, cache entry is committed only if all rows were read,
+//otherwise entry being populated is rolled back
+func (i *Iterator) Close() error {
+	if i.closed {
+		return nil
+	}
+	i.closed = true
+	if r := i.reader; r.row != nil && r.matcher != nil && r.matcher.OnSkip != nil {
+		_ = r.matcher.OnSkip(*r.row.values)
+	}
+	i.reader.row = nil
+	switch {
+	case i.err != nil:
+		_ = i.source.Rollback(i.ctx)
+		return nil
+	case i.complete || i.rows == nil: //cached source is only released
+		if err := i.source.Close(i.ctx); err != nil {
+			return err
+		}
+	default:
+		if err := i.source.Rollback(i.ctx); err != nil {
+			return err
+		}
+	}
+	if i.rows != nil {
+		return i.rows.Err()
+	}
+	return nil
+}
+
+//Iterate returns query rows iterator, iterator has to be closed unless all rows were read
+func (r *Reader) Iterate(ctx context.Context, args ...interface{}) (*Iterator, error) {
+	args, err := r.bindArgs(args)
+	if err != nil {
+		return nil, err
+	}
+	chunks, err := r.chunks(args)
+	if err != nil {
+		return nil, err
+	}
+	if len(chunks) > 1 {
+		return nil, fmt.Errorf("failed to iterate: %v, slice arguments exceed %v parameters limit, use QueryAll", r.query, r.dialect.MaxParameters)
+	}
+	entry, err := r.cacheEntry(ctx, r.query, args, r.matcher)
+	if err != nil {
+		return nil, err
+	}
+	rows, source, err := r.createSource(ctx, entry, args, r.matcher)
+	if err != nil {
+		return nil, err
+	}
+	if err = r.applyRowsIfNeeded(entry, rows); err != nil {
+		_ = source.Rollback(ctx)
+		return nil, err
+	}
+	return &Iterator{ctx: ctx, reader: r, rows: rows, source: source, entry: entry}, nil
+}
@@ -0,0 +1,74 @@
+package read_test
+
+import (
+	"context"
+	"database/sql"
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/assert"
+	option2 "github.com/viant/afs/option"
+	"github.com/viant/sqlx/io/read"
+	"github.com/viant/sqlx/io/read/cache/afs"
+	"testing"
+	"time"
+)
+
+func TestReader_Iterate(t *testing.T) {
+	type entity struct {
+		Id   int    `sqlx:"name=id,primaryKey"`
+		Name string `sqlx:"name"`
+	}
+	db, err := sql.Open("sqlite3", "/tmp/sqllite.db")
+	if !assert.Nil(t, err) {
+		return
+	}
+	for _, SQL := range []string{
+		"DROP TABLE IF EXISTS t_iter",
+		"CREATE TABLE t_iter (id INTEGER PRIMARY KEY, name TEXT)",
+		"INSERT INTO t_iter (id, name) VALUES(1, 'n1'), (2, 'n2'), (3, 'n3')",
+	} {
+		if _, err = db.Exec(SQL); !assert.Nil(t, err) {
+			return
+		}
+	}
+	dataCache, err := afs.NewCache("mem:///tmp/iterator/cache", time.Minute, "", option2.NewStream(64*1024, 1024))
+	if !assert.Nil(t, err) {
+		return
+	}
+	reader, err := read.New(context.TODO(), db, "SELECT id, name FROM t_iter WHERE id > ? ORDER BY id", func() interface{} { return &entity{} }, dataCache)
+	if !assert.Nil(t, err) {
+		return
+	}
+
+	var ids = func(limit int) []int {
+		iterator, err := reader.Iterate(context.TODO(), 0)
+		if !assert.Nil(t, err) {
+			return nil
+		}
+		defer iterator.Close()
+		var result []int
+		for iterator.Next() {
+			result = append(result, iterator.Row().(*entity).Id)
+			if len(result) == limit {
+				break
+			}
+		}
+		assert.Nil(t, iterator.Err())
+		assert.Nil(t, iterator.Close())
+		return result
+	}
+
+	assert.EqualValues(t, []int{1}, ids(1), "early close")
+	assert.EqualValues(t, []int{1, 2, 3}, ids(0), "partial entry rolled back")
+	_, err = db.Exec("INSERT INTO t_iter (id, name) VALUES(4, 'n4')")
+	assert.Nil(t, err)
+	assert.EqualValues(t, []int{1, 2, 3}, ids(0), "cached entry")
+	assert.EqualValues(t, []int{1, 2}, ids(2), "cached entry early close")
+	assert.EqualValues(t, []int{1, 2, 3}, ids(0), "cached entry kept")
+
+	invalid, err := read.New(context.TODO(), db, "SELECT id, name, missing FROM t_iter", func() interface{} { return &entity{} })
+	if !assert.Nil(t, err) {
+		return
+	}
+	_, err = invalid.Iterate(context.TODO())
+	assert.NotNil(t, err)
+}
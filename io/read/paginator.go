@@ -0,0 +1,296 @@
+package read
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"github.com/viant/sqlx"
+	"github.com/viant/sqlx/io"
+	"github.com/viant/sqlx/io/read/cache"
+	"github.com/viant/sqlx/metadata/info"
+	"github.com/viant/sqlx/metadata/info/dialect"
+	"github.com/viant/sqlx/option"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+type (
+	//Paginator represents keyset (seek) paginator, query rows are ordered by key columns and the next page
+	//continues after the last page row key instead of OFFSET scan, with *cache.ParmetrizedQuery option
+	//pages are read with matcher Offset and Limit, the same way as cached queries
+	Paginator struct {
+		keys     []*keyColumn
+		pageSize int
+		first    *Reader
+		next     *Reader
+		matcher  *cache.ParmetrizedQuery
+		columns  io.Columns
+		binder   io.PlaceholderBinder
+	}
+
+	//Page represents paginated rows
+	Page struct {
+		Rows   []interface{}
+		Cursor string //opaque token to resume after the page, empty for the last page
+	}
+
+	keyColumn struct {
+		name string
+		desc bool
+	}
+
+	cursor struct {
+		Keys   []interface{} `json:"k,omitempty"`
+		Offset int           `json:"o,omitempty"`
+	}
+)
+
+//Page returns page following cursor, empty cursor returns the first page, key columns values can not be NULL
+func (p *Paginator) Page(ctx context.Context, token string, args ...interface{}) (*Page, error) {
+	aCursor, err := decodeCursor(token)
+	if err != nil {
+		return nil, err
+	}
+	args, err = p.first.bindArgs(args)
+	if err != nil {
+		return nil, err
+	}
+	reader := p.first
+	var matcher *cache.ParmetrizedQuery
+	if p.matcher != nil { //paginator matcher is shared by concurrent pages, each page uses its copy
+		pageMatcher := *p.matcher
+		pageMatcher.Offset = aCursor.Offset
+		pageMatcher.Limit = p.pageSize
+		matcher = &pageMatcher
+	} else if len(aCursor.Keys) > 0 {
+		if len(aCursor.Keys) != len(p.keys) {
+			return nil, fmt.Errorf("invalid cursor: expected %v keys, but had %v", len(p.keys), len(aCursor.Keys))
+		}
+		reader = p.next
+		args = append(args[:len(args):len(args)], p.seekArgs(aCursor.Keys)...)
+	}
+	//matcher Offset and Limit are applied by Rows per By column value, otherwise they are applied to all rows,
+	//all rows are read so that cache entry is complete
+	applyLimit := matcher != nil && (len(matcher.In) <= 1 || matcher.By == "")
+	page := &Page{}
+	index := 0
+	err = reader.queryAll(ctx, func(row interface{}) error {
+		if index++; applyLimit && (index <= aCursor.Offset || index > aCursor.Offset+p.pageSize) {
+			return nil
+		}
+		page.Rows = append(page.Rows, row)
+		return nil
+	}, matcher, args)
+	if err != nil || len(page.Rows) < p.pageSize {
+		return page, err
+	}
+	next := &cursor{Offset: aCursor.Offset + p.pageSize}
+	if matcher == nil {
+		next.Offset = 0
+		if next.Keys, err = p.keyValues(page.Rows[len(page.Rows)-1]); err != nil {
+			return nil, err
+		}
+	}
+	page.Cursor, err = next.encode()
+	return page, err
+}
+
+//Close closes paginator statements
+func (p *Paginator) Close() error {
+	err := p.first.Close()
+	if p.next != nil {
+		if closeErr := p.next.Close(); err == nil {
+			err = closeErr
+		}
+	}
+	return err
+}
+
+//seekArgs returns seek predicate args for cursor keys
+func (p *Paginator) seekArgs(keys []interface{}) []interface{} {
+	var result []interface{}
+	for i := range p.keys {
+		result = append(result, keys[:i+1]...)
+	}
+	return result
+}
+
+//keyValues returns row key columns values
+func (p *Paginator) keyValues(row interface{}) ([]interface{}, error) {
+	var result = make([]interface{}, len(p.keys))
+	if aMap, ok := row.(map[string]interface{}); ok {
+		for i, key := range p.keys {
+			value, ok := aMap[key.name]
+			if !ok {
+				return nil, fmt.Errorf("failed to lookup key column %v in row", key.name)
+			}
+			result[i] = value
+		}
+		return result, nil
+	}
+	if p.binder == nil {
+		return nil, fmt.Errorf("unsupported paginated row type: %T", row)
+	}
+	values := make([]interface{}, len(p.columns))
+	p.binder(row, values, 0, len(values))
+outer:
+	for i, key := range p.keys {
+		for j, column := range p.columns {
+			if strings.EqualFold(column.Name(), key.name) {
+				result[i] = derefValue(values[j])
+				continue outer
+			}
+		}
+		return nil, fmt.Errorf("failed to lookup key column %v in %T", key.name, row)
+	}
+	return result, nil
+}
+
+//NewPaginator creates keyset paginator for query, keys define ordered key columns, i.e. "kind", "id DESC",
+//key columns have to uniquely identify query row
+func NewPaginator(ctx context.Context, db sqlx.Executor, query string, newRow func() interface{}, pageSize int, keys []string, options ...option.Option) (*Paginator, error) {
+	if pageSize <= 0 {
+		return nil, fmt.Errorf("invalid page size: %v", pageSize)
+	}
+	result := &Paginator{pageSize: pageSize}
+	for _, anOption := range options {
+		switch actual := anOption.(type) {
+		case *cache.ParmetrizedQuery:
+			result.matcher = actual
+		case **cache.ParmetrizedQuery:
+			result.matcher = *actual
+		}
+	}
+	var err error
+	if result.matcher != nil {
+		result.first, err = New(ctx, db, query, newRow, options...)
+		return result, err
+	}
+	if len(keys) == 0 {
+		return nil, fmt.Errorf("key columns were empty")
+	}
+	for _, key := range keys {
+		fragments := strings.Fields(key)
+		if len(fragments) == 0 {
+			return nil, fmt.Errorf("invalid key column: %q", key)
+		}
+		result.keys = append(result.keys, &keyColumn{name: fragments[0], desc: len(fragments) > 1 && strings.EqualFold(fragments[1], "DESC")})
+	}
+	if rowType := reflect.TypeOf(newRow()); io.IsStruct(rowType) {
+		if result.columns, result.binder, err = io.StructColumnMapper(rowType, option.Options(options).Tag()); err != nil {
+			return nil, err
+		}
+	}
+	aDialect := ensureDialect(options, db)
+	if result.first, err = New(ctx, db, result.pageSQL(aDialect, query, false), newRow, options...); err != nil {
+		return nil, err
+	}
+	if result.next, err = New(ctx, db, result.pageSQL(aDialect, query, true), newRow, options...); err != nil {
+		_ = result.first.Close()
+		return nil, err
+	}
+	return result, nil
+}
+
+//pageSQL returns query ordered by key columns limited to page size, seek adds predicate selecting rows after cursor keys
+func (p *Paginator) pageSQL(aDialect *info.Dialect, query string, seek bool) string {
+	pagination := dialect.PaginationLimitOffset
+	if aDialect != nil {
+		pagination = aDialect.Pagination
+	}
+	size := strconv.Itoa(p.pageSize)
+	sb := strings.Builder{}
+	sb.WriteString("SELECT ")
+	if pagination == dialect.PaginationTop {
+		sb.WriteString("TOP " + size + " ")
+	}
+	sb.WriteString("* FROM (")
+	sb.WriteString(query)
+	sb.WriteString(") t")
+	if seek {
+		sb.WriteString(" WHERE ")
+		for i, key := range p.keys {
+			if i > 0 {
+				sb.WriteString(" OR ")
+			}
+			sb.WriteString("(")
+			for _, prev := range p.keys[:i] {
+				sb.WriteString(aDialect.QuoteIdentifier(prev.name) + " = ? AND ")
+			}
+			operator := " > ?"
+			if key.desc {
+				operator = " < ?"
+			}
+			sb.WriteString(aDialect.QuoteIdentifier(key.name) + operator)
+			sb.WriteString(")")
+		}
+	}
+	sb.WriteString(" ORDER BY ")
+	for i, key := range p.keys {
+		if i > 0 {
+			sb.WriteString(", ")
+		}
+		sb.WriteString(aDialect.QuoteIdentifier(key.name))
+		if key.desc {
+			sb.WriteString(" DESC")
+		}
+	}
+	switch pagination {
+	case dialect.PaginationLimitOffset:
+		sb.WriteString(" LIMIT " + size)
+	case dialect.PaginationFetchFirst:
+		sb.WriteString(" FETCH FIRST " + size + " ROWS ONLY")
+	}
+	return sb.String()
+}
+
+func (c *cursor) encode() (string, error) {
+	data, err := json.Marshal(c)
+	if err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(data), nil
+}
+
+func decodeCursor(token string) (*cursor, error) {
+	result := &cursor{}
+	if token == "" {
+		return result, nil
+	}
+	data, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return nil, fmt.Errorf("invalid cursor: %w", err)
+	}
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	if err = decoder.Decode(result); err != nil {
+		return nil, fmt.Errorf("invalid cursor: %w", err)
+	}
+	for i, key := range result.Keys {
+		number, ok := key.(json.Number)
+		if !ok {
+			continue
+		}
+		if intValue, err := number.Int64(); err == nil {
+			result.Keys[i] = intValue
+		} else if result.Keys[i], err = number.Float64(); err != nil {
+			return nil, fmt.Errorf("invalid cursor key: %w", err)
+		}
+	}
+	return result, nil
+}
+
+//derefValue returns value of field address returned by io.PlaceholderBinder
+func derefValue(value interface{}) interface{} {
+	rValue := reflect.ValueOf(value)
+	if rValue.Kind() != reflect.Ptr {
+		return value
+	}
+	if rValue.IsNil() {
+		return nil
+	}
+	return rValue.Elem().Interface()
+}
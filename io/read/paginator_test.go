@@ -0,0 +1,115 @@
+package read_test
+
+import (
+	"context"
+	"database/sql"
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/assert"
+	option2 "github.com/viant/afs/option"
+	"github.com/viant/sqlx/io/read"
+	"github.com/viant/sqlx/io/read/cache"
+	"github.com/viant/sqlx/io/read/cache/afs"
+	"github.com/viant/sqlx/option"
+	"testing"
+	"time"
+)
+
+func TestPaginator_Page(t *testing.T) {
+	type entity struct {
+		Id   int    `sqlx:"name=id,primaryKey"`
+		Kind string `sqlx:"kind"`
+	}
+	dataCache, err := afs.NewCache("mem:///tmp/paginator/cache", time.Minute, "", option2.NewStream(64*1024, 1024))
+	if !assert.Nil(t, err) {
+		return
+	}
+	var testCases = []struct {
+		description string
+		SQL         string
+		keys        []string
+		newRow      func() interface{}
+		options     []option.Option
+		args        []interface{}
+		expect      [][]int
+	}{
+		{
+			description: "composite key",
+			SQL:         "SELECT id, kind FROM t_page",
+			keys:        []string{"kind", "id"},
+			expect:      [][]int{{2, 4}, {5, 1}, {3}},
+		},
+		{
+			description: "descending key with args",
+			SQL:         "SELECT id, kind FROM t_page WHERE id > ?",
+			keys:        []string{"id DESC"},
+			args:        []interface{}{1},
+			expect:      [][]int{{5, 4}, {3, 2}, {}},
+		},
+		{
+			description: "map rows",
+			SQL:         "SELECT id, kind FROM t_page WHERE kind = ?",
+			keys:        []string{"id"},
+			newRow:      func() interface{} { return map[string]interface{}{} },
+			args:        []interface{}{"a"},
+			expect:      [][]int{{2, 4}, {5}},
+		},
+		{
+			description: "cached query matcher offset and limit",
+			SQL:         "SELECT id, kind FROM t_page ORDER BY id",
+			options:     []option.Option{dataCache, &cache.ParmetrizedQuery{}},
+			expect:      [][]int{{1, 2}, {3, 4}, {5}},
+		},
+	}
+	db, err := sql.Open("sqlite3", "/tmp/sqllite.db")
+	if !assert.Nil(t, err) {
+		return
+	}
+	for _, SQL := range []string{
+		"DROP TABLE IF EXISTS t_page",
+		"CREATE TABLE t_page (id INTEGER PRIMARY KEY, kind TEXT)",
+		"INSERT INTO t_page (id, kind) VALUES(1, 'b'), (2, 'a'), (3, 'c'), (4, 'a'), (5, 'a')",
+	} {
+		if _, err = db.Exec(SQL); !assert.Nil(t, err) {
+			return
+		}
+	}
+	for _, testCase := range testCases {
+		newRow := testCase.newRow
+		if newRow == nil {
+			newRow = func() interface{} { return &entity{} }
+		}
+		paginator, err := read.NewPaginator(context.TODO(), db, testCase.SQL, newRow, 2, testCase.keys, testCase.options...)
+		if !assert.Nil(t, err, testCase.description) {
+			continue
+		}
+		var actual [][]int
+		cursor := ""
+		for i := 0; i < 5; i++ {
+			page, err := paginator.Page(context.TODO(), cursor, testCase.args...)
+			if !assert.Nil(t, err, testCase.description) {
+				break
+			}
+			ids := []int{}
+			for _, row := range page.Rows {
+				switch actual := row.(type) {
+				case *entity:
+					ids = append(ids, actual.Id)
+				case map[string]interface{}:
+					ids = append(ids, actual["id"].(int))
+				}
+			}
+			actual = append(actual, ids)
+			if cursor = page.Cursor; cursor == "" {
+				break
+			}
+		}
+		assert.EqualValues(t, testCase.expect, actual, testCase.description)
+		for _, opt := range testCase.options {
+			if matcher, ok := opt.(*cache.ParmetrizedQuery); ok { //pages must not modify shared matcher
+				assert.EqualValues(t, 0, matcher.Offset, testCase.description)
+				assert.EqualValues(t, 0, matcher.Limit, testCase.description)
+			}
+		}
+		assert.Nil(t, paginator.Close(), testCase.description)
+	}
+}
@@ -5,13 +5,18 @@ import (
 	"database/sql"
 	"errors"
 	"fmt"
+	"github.com/viant/sqlx"
 	"github.com/viant/sqlx/io"
 	"github.com/viant/sqlx/io/read/cache"
+	"github.com/viant/sqlx/io/read/cache/ast"
+	"github.com/viant/sqlx/io/telemetry"
 	"github.com/viant/sqlx/metadata/info"
+	"github.com/viant/sqlx/metadata/info/placeholder"
 	"github.com/viant/sqlx/metadata/registry"
 	"github.com/viant/sqlx/option"
 	goIo "io"
 	"reflect"
+	"sync"
 )
 
 // Reader represents generic query reader
@@ -31,10 +36,18 @@ type (
 		targetDatatype     string
 		disableMapperCache DisableMapperCache
 		matcher            *cache.ParmetrizedQuery
-		db                 *sql.DB
+		db                 sqlx.Executor
 		row                *bufferEntry
 		cacheStats         *cache.Stats
 		cacheRefresh       cache.Refresh
+		interceptor        sqlx.Interceptor
+		tracer             option.Tracer
+		names              []string //named parameters
+		namedArgs          *option.NamedArgs
+		template           string //query with '?' placeholders expanded for slice arguments
+		dialect            *info.Dialect
+		stmts              map[string]*sql.Stmt //statements per expanded query
+		mux                sync.Mutex
 	}
 
 	bufferEntry struct {
@@ -45,39 +58,77 @@ type (
 
 // QuerySingle returns single row
 func (r *Reader) QuerySingle(ctx context.Context, emit func(row interface{}) error, args ...interface{}) error {
-	if err := r.ensureStmt(ctx); err != nil {
+	args, err := r.bindArgs(args)
+	if err != nil {
+		return err
+	}
+	chunks, err := r.chunks(args)
+	if err != nil {
 		return err
 	}
+	for _, chunk := range chunks {
+		found, err := r.querySingle(ctx, emit, chunk)
+		if err != nil || found {
+			return err
+		}
+	}
+	return nil
+}
 
-	rows, err := r.stmt.QueryContext(ctx, args...)
+func (r *Reader) querySingle(ctx context.Context, emit func(row interface{}) error, args []interface{}) (bool, error) {
+	rows, err := r.queryRows(ctx, args)
 	if err != nil {
-		return fmt.Errorf("failed to run query: %v, due to %s", r.query, err)
+		return false, fmt.Errorf("failed to run query: %v, due to %s", r.query, err)
 	}
 
 	defer rows.Close()
 	newRows, err := NewRows(rows, nil, nil, nil)
 	if err != nil {
-		return err
+		return false, err
 	}
 
 	var mapper RowMapper
 	if rows.Next() {
 		if err = r.read(ctx, newRows, &mapper, emit, nil); err != nil {
-			return err
+			return false, err
 		}
+		return true, nil
 	}
 
-	return nil
+	return false, rows.Err()
 }
 
 // QueryAll query all
-func (r *Reader) QueryAll(ctx context.Context, emit func(row interface{}) error, args ...interface{}) error {
-	entry, err := r.cacheEntry(ctx, r.query, args)
+func (r *Reader) QueryAll(ctx context.Context, emit func(row interface{}) error, args ...interface{}) (err error) {
+	return r.queryAll(ctx, emit, r.matcher, args)
+}
+
+//queryAll queries all rows with cache matcher
+func (r *Reader) queryAll(ctx context.Context, emit func(row interface{}) error, matcher *cache.ParmetrizedQuery, args []interface{}) (err error) {
+	ctx, span := telemetry.Start(ctx, r.tracer, "sqlx.read.QueryAll", telemetry.Statement.String(r.query))
+	defer func() { telemetry.End(span, err) }()
+	if args, err = r.bindArgs(args); err != nil {
+		return err
+	}
+	chunks, err := r.chunks(args)
+	if err != nil {
+		return err
+	}
+	if len(chunks) > 1 {
+		return r.queryChunks(ctx, emit, chunks, matcher)
+	}
+	entry, err := r.cacheEntry(ctx, r.query, args, matcher)
 	if err != nil {
 		return err
 	}
+	if r.cache != nil {
+		span.SetAttributes(telemetry.CacheHit.Bool(entry != nil && entry.Has() && len(entry.Meta.Fields) > 0))
+		if r.cacheStats != nil {
+			span.SetAttributes(telemetry.CacheType.String(string(r.cacheStats.Type)))
+		}
+	}
 
-	rows, source, err := r.createSource(ctx, entry, args, r.matcher)
+	rows, source, err := r.createSource(ctx, entry, args, matcher)
 	if err != nil {
 		return err
 	}
@@ -86,7 +137,7 @@ func (r *Reader) QueryAll(ctx context.Context, emit func(row interface{}) error,
 		return err
 	}
 
-	if err = r.readAll(ctx, emit, entry, source); err != nil {
+	if err = r.readAll(ctx, emit, entry, source, matcher); err != nil {
 		return err
 	}
 
@@ -97,13 +148,26 @@ func (r *Reader) QueryAll(ctx context.Context, emit func(row interface{}) error,
 	return nil
 }
 
-func (r *Reader) createSource(ctx context.Context, entry *cache.Entry, args []interface{}, matcher *cache.ParmetrizedQuery) (*sql.Rows, cache.Source, error) {
-	if entry == nil || !entry.Has() || len(entry.Meta.Fields) == 0 {
-		if err := r.ensureStmt(ctx); err != nil {
-			return nil, nil, err
+//queryChunks runs query for each chunk of slice arguments exceeding dialect parameters limit, cache is not used
+func (r *Reader) queryChunks(ctx context.Context, emit func(row interface{}) error, chunks [][]interface{}, matcher *cache.ParmetrizedQuery) error {
+	for _, chunk := range chunks {
+		rows, source, err := r.createSource(ctx, nil, chunk, matcher)
+		if err != nil {
+			return err
+		}
+		if err = r.readAll(ctx, emit, nil, source, matcher); err != nil {
+			return err
+		}
+		if err = rows.Err(); err != nil {
+			return err
 		}
+	}
+	return nil
+}
 
-		rows, err := r.stmt.QueryContext(ctx, args...)
+func (r *Reader) createSource(ctx context.Context, entry *cache.Entry, args []interface{}, matcher *cache.ParmetrizedQuery) (*sql.Rows, cache.Source, error) {
+	if entry == nil || !entry.Has() || len(entry.Meta.Fields) == 0 {
+		rows, err := r.queryRows(ctx, args)
 		if err != nil {
 			return nil, nil, fmt.Errorf("failed to run query: %v, due to %s", r.query, err)
 		}
@@ -132,14 +196,14 @@ func (r *Reader) ReadAll(ctx context.Context, rows *sql.Rows, emit func(row inte
 		return err
 	}
 
-	if err = r.readAll(ctx, emit, cacheEntry, readerRows); err != nil {
+	if err = r.readAll(ctx, emit, cacheEntry, readerRows, r.matcher); err != nil {
 		return err
 	}
 
 	return rows.Err()
 }
 
-func (r *Reader) readAll(ctx context.Context, emit func(row interface{}) error, cacheEntry *cache.Entry, source cache.Source) error {
+func (r *Reader) readAll(ctx context.Context, emit func(row interface{}) error, cacheEntry *cache.Entry, source cache.Source, matcher *cache.ParmetrizedQuery) error {
 	var err error
 	var mapper RowMapper
 
@@ -147,8 +211,8 @@ func (r *Reader) readAll(ctx context.Context, emit func(row interface{}) error,
 		err = r.read(ctx, source, &mapper, emit, cacheEntry)
 	}
 
-	if r.row != nil && r.matcher != nil && r.matcher.OnSkip != nil {
-		_ = r.matcher.OnSkip(*r.row.values)
+	if r.row != nil && matcher != nil && matcher.OnSkip != nil {
+		_ = matcher.OnSkip(*r.row.values)
 	}
 
 	if err == nil || errors.Is(err, goIo.EOF) {
@@ -231,13 +295,9 @@ func (r *Reader) read(ctx context.Context, source cache.Source, mapperPtr *RowMa
 		return err
 	}
 
-	if err = emit(row); err != nil {
-		return err
-	}
-
-	r.row = nil
-
-	return nil
+	err = emit(row)
+	r.row = nil //emitted row is owned by caller, including when emit stopped reading
+	return err
 }
 
 func (r *Reader) addToEntry(ctx context.Context, cacheEntry *cache.Entry, values []interface{}) error {
@@ -336,9 +396,26 @@ func (r *Reader) Stmt() *sql.Stmt {
 	return r.stmt
 }
 
-func (r *Reader) cacheEntry(ctx context.Context, sql string, args []interface{}) (*cache.Entry, error) {
+//Close closes reader statement and statements prepared for expanded slice arguments
+func (r *Reader) Close() error {
+	var err error
+	if r.stmt != nil {
+		err = r.stmt.Close()
+	}
+	r.mux.Lock()
+	defer r.mux.Unlock()
+	for SQL, stmt := range r.stmts {
+		if closeErr := stmt.Close(); closeErr != nil && err == nil {
+			err = closeErr
+		}
+		delete(r.stmts, SQL)
+	}
+	return err
+}
+
+func (r *Reader) cacheEntry(ctx context.Context, sql string, args []interface{}, matcher *cache.ParmetrizedQuery) (*cache.Entry, error) {
 	if r.cache != nil {
-		entry, err := r.cache.Get(ctx, sql, args, r.matcher, r.cacheStats, r.cacheRefresh)
+		entry, err := r.cache.Get(ctx, sql, args, matcher, r.cacheStats, r.cacheRefresh)
 		return entry, err
 	}
 
@@ -359,9 +436,6 @@ func (r *Reader) ensureStmt(ctx context.Context) error {
 	}
 
 	stmt, err := r.db.PrepareContext(ctx, r.query)
-	if showSQL {
-		fmt.Println(r.query)
-	}
 	if err != nil {
 		return err
 	}
@@ -370,6 +444,107 @@ func (r *Reader) ensureStmt(ctx context.Context) error {
 	return nil
 }
 
+//expandedStmt returns statement prepared for query with slice arguments expanded, slices are padded to power of two length,
+//so that statements reused per expanded query are bounded by list size buckets
+func (r *Reader) expandedStmt(ctx context.Context, args []interface{}) (*sql.Stmt, string, []interface{}, error) {
+	limit := 0
+	if r.dialect != nil {
+		limit = r.dialect.MaxParameters
+	}
+	SQL, args, err := placeholder.Expand(r.template, placeholder.Pad(args, limit))
+	if err != nil {
+		return nil, "", nil, err
+	}
+	if r.dialect != nil {
+		SQL = r.dialect.EnsurePlaceholders(SQL)
+	}
+	r.mux.Lock()
+	defer r.mux.Unlock()
+	if stmt, ok := r.stmts[SQL]; ok {
+		return stmt, SQL, args, nil
+	}
+	stmt, err := r.db.PrepareContext(ctx, SQL)
+	if err != nil {
+		return nil, "", nil, err
+	}
+	if r.stmts == nil {
+		r.stmts = map[string]*sql.Stmt{}
+	}
+	r.stmts[SQL] = stmt
+	return stmt, SQL, args, nil
+}
+
+//chunks splits slice arguments exceeding dialect parameters limit, returns error if query results can not be combined from chunks, see ast.IsChunkable
+func (r *Reader) chunks(args []interface{}) ([][]interface{}, error) {
+	if r.template == "" || r.dialect == nil || r.dialect.MaxParameters == 0 || !placeholder.HasSlice(args) {
+		return [][]interface{}{args}, nil
+	}
+	chunks := placeholder.Chunk(args, r.dialect.MaxParameters)
+	if len(chunks) == 1 {
+		return chunks, nil
+	}
+	positions := placeholder.Positions(r.template)
+	if len(positions) != len(args) || !ast.IsChunkable(r.template, positions[placeholder.Largest(args)]) {
+		return nil, fmt.Errorf("failed to run query: %v, slice arguments exceed %v parameters limit, only column IN (?) criterion of query without ordering, limit, grouping or aggregates can be chunked", r.query, r.dialect.MaxParameters)
+	}
+	return chunks, nil
+}
+
+//queryRows runs reader statement, within context unit of work statement is bound to unit of work transaction
+func (r *Reader) queryRows(ctx context.Context, args []interface{}) (*sql.Rows, error) {
+	var stmt *sql.Stmt
+	SQL := r.query
+	if r.template != "" && placeholder.HasSlice(args) {
+		var err error
+		if stmt, SQL, args, err = r.expandedStmt(ctx, args); err != nil {
+			return nil, err
+		}
+	} else {
+		if err := r.ensureStmt(ctx); err != nil {
+			return nil, err
+		}
+		stmt = r.stmt
+	}
+	if tx := io.UnitOfWorkFrom(ctx).TxFor(r.db); tx != nil {
+		if _, ok := r.db.(sqlx.TxBeginner); ok {
+			stmt = tx.StmtContext(ctx, stmt)
+		}
+	}
+	var printer sqlx.Interceptor
+	if showSQL {
+		printer = &sqlx.SQLPrinter{}
+	}
+	return sqlx.InterceptQuery(ctx, sqlx.Chain(printer, r.interceptor), SQL, args, func(ctx context.Context) (*sql.Rows, error) {
+		return stmt.QueryContext(ctx, args...)
+	})
+}
+
+//bindArgs returns named parameters values bound from single map or struct argument, *option.NamedArgs or reader named arguments
+func (r *Reader) bindArgs(args []interface{}) ([]interface{}, error) {
+	if len(r.names) == 0 {
+		return args, nil
+	}
+	var source interface{}
+	switch len(args) {
+	case 0:
+		if r.namedArgs.Source == nil {
+			return args, nil
+		}
+		source = r.namedArgs.Source
+	case 1:
+		source = args[0]
+		if named, ok := source.(*option.NamedArgs); ok {
+			source = named.Source
+		}
+		if !placeholder.CanBind(source) {
+			return args, nil
+		}
+	default:
+		return args, nil
+	}
+	return placeholder.Bind(r.names, source)
+}
+
 func (r *Reader) ensureTargetType(row interface{}) {
 	if r.targetType != nil {
 		return
@@ -381,20 +556,53 @@ func (r *Reader) ensureTargetType(row interface{}) {
 }
 
 // New creates a records to a structs reader
-func New(ctx context.Context, db *sql.DB, query string, newRow func() interface{}, options ...option.Option) (*Reader, error) {
+func New(ctx context.Context, db sqlx.Executor, query string, newRow func() interface{}, options ...option.Option) (*Reader, error) {
 	dialect := ensureDialect(options, db)
+	namedArgs := option.Options(options).NamedArgs()
+	var names []string
+	if namedArgs != nil {
+		query, names = placeholder.Named(query, (&placeholder.DefaultGenerator{}).Resolver())
+	}
+	if option.Options(options).SkipSoftDeleted() {
+		var err error
+		if query, err = skipSoftDeleted(query, newRow, option.Options(options).Tag()); err != nil {
+			return nil, err
+		}
+	}
+	template := query
 	if dialect != nil {
 		query = dialect.EnsurePlaceholders(query)
 	}
 
-	options = append(options, db)
-
 	newStmt := NewStmt(nil, newRow, options...)
 	newStmt.query = query
+	newStmt.db = db
+	newStmt.names = names
+	newStmt.namedArgs = namedArgs
+	newStmt.template = template
+	newStmt.dialect = dialect
 	return newStmt, nil
 }
 
-func ensureDialect(options []option.Option, db *sql.DB) *info.Dialect {
+//skipSoftDeleted adds not deleted rows criterion to query WHERE clause if row type defines soft delete column,
+//criterion applies before ordering and limit, soft delete column has to be a column of the query FROM clause table
+func skipSoftDeleted(query string, newRow func() interface{}, tagName string) (string, error) {
+	rowType := reflect.TypeOf(newRow())
+	if !io.IsStruct(rowType) {
+		return query, nil
+	}
+	columns, err := io.StructColumns(rowType, tagName)
+	if err != nil {
+		return "", err
+	}
+	softDelete := io.Columns(columns).SoftDelete()
+	if softDelete == nil {
+		return query, nil
+	}
+	return ast.AddCriterion(query, softDelete.Criterion())
+}
+
+func ensureDialect(options []option.Option, db sqlx.Executor) *info.Dialect {
 	dialect := option.Options(options).Dialect()
 	if dialect == nil {
 		product := registry.MatchProduct(db)
@@ -419,7 +627,7 @@ func NewStmt(stmt *sql.Stmt, newRow func() interface{}, options ...option.Option
 	var readerCache cache.Cache
 	var mapperCache *MapperCache
 	var disableMapperCache DisableMapperCache
-	var db *sql.DB
+	var db sqlx.Executor
 	var columnsInMatcher *cache.ParmetrizedQuery
 	var stats *cache.Stats
 	var cacheRefresh cache.Refresh
@@ -457,19 +665,21 @@ func NewStmt(stmt *sql.Stmt, newRow func() interface{}, options ...option.Option
 		cacheRefresh:       cacheRefresh,
 		db:                 db,
 		cacheStats:         stats,
+		interceptor:        option.Options(options).Interceptor(),
+		tracer:             option.Options(options).Tracer(),
 	}
 	return result
 }
 
 // NewMap creates records to map reader
-func NewMap(ctx context.Context, db *sql.DB, query string, options ...option.Option) (*Reader, error) {
+func NewMap(ctx context.Context, db sqlx.Executor, query string, options ...option.Option) (*Reader, error) {
 	return New(ctx, db, query, func() interface{} {
 		return make(map[string]interface{})
 	}, options...)
 }
 
 // NewSlice create records to a slice reader
-func NewSlice(ctx context.Context, db *sql.DB, query string, columns int, options ...option.Option) (*Reader, error) {
+func NewSlice(ctx context.Context, db sqlx.Executor, query string, columns int, options ...option.Option) (*Reader, error) {
 	return New(ctx, db, query, func() interface{} {
 		return make([]interface{}, columns)
 	}, options...)
@@ -14,6 +14,7 @@ import (
 	"github.com/viant/sqlx/io/read/cache"
 	"github.com/viant/sqlx/io/read/cache/aerospike"
 	"github.com/viant/sqlx/io/read/cache/afs"
+	"github.com/viant/sqlx/metadata/info"
 	"github.com/viant/sqlx/option"
 	"github.com/viant/toolbox"
 	"log"
@@ -88,7 +89,10 @@ func TestReader_ReadAll(t *testing.T) {
 		return parse
 	}
 	testLocation := toolbox.CallerDirectory(3)
-	cacheLocation := path.Join(testLocation, "testdata", "cache")
+	cacheLocation := t.TempDir()
+	if !assert.Nil(t, copyFiles(path.Join(testLocation, "testdata", "cache"), cacheLocation)) {
+		return
+	}
 
 	type fooCase1 struct {
 		Id   int
@@ -758,6 +762,27 @@ func getCache(aRecorder *recorder, config *cacheConfig) (cache.Cache, error) {
 	return aerospike.New("test", "aerospike", client, 0, aRecorder)
 }
 
+//copyFiles copies checked-in cache fixtures, so that tests do not modify them
+func copyFiles(source, dest string) error {
+	entries, err := os.ReadDir(source)
+	if err != nil {
+		return err
+	}
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+		data, err := os.ReadFile(path.Join(source, entry.Name()))
+		if err != nil {
+			return err
+		}
+		if err = os.WriteFile(path.Join(dest, entry.Name()), data, 0644); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 func boolPtr(b bool) *bool {
 	return &b
 }
@@ -1096,3 +1121,217 @@ func BenchmarkStructMapper(b *testing.B) {
 	})
 
 }
+
+func TestReader_QueryAll_skipSoftDeleted(t *testing.T) {
+	type entity struct {
+		Id        int        `sqlx:"name=id,primaryKey"`
+		Name      string     `sqlx:"name"`
+		DeletedAt *time.Time `sqlx:"name=deleted_at,softDelete"`
+	}
+	var testCases = []struct {
+		description string
+		SQL         string
+		options     []option.Option
+		expect      []int
+	}{
+		{
+			description: "all rows",
+			SQL:         "SELECT id, name, deleted_at FROM t_soft WHERE id > ?",
+			expect:      []int{1, 2, 3},
+		},
+		{
+			description: "soft deleted rows skipped",
+			SQL:         "SELECT id, name, deleted_at FROM t_soft WHERE id > ?",
+			options:     []option.Option{option.SkipSoftDeleted(true)},
+			expect:      []int{1, 3},
+		},
+		{
+			description: "soft deleted rows skipped before order by and limit",
+			SQL:         "SELECT id, name, deleted_at FROM t_soft WHERE id > ? ORDER BY id DESC LIMIT 2",
+			options:     []option.Option{option.SkipSoftDeleted(true)},
+			expect:      []int{3, 1},
+		},
+		{
+			description: "soft delete column not projected",
+			SQL:         "SELECT id, name FROM t_soft WHERE id > ? ORDER BY id",
+			options:     []option.Option{option.SkipSoftDeleted(true)},
+			expect:      []int{1, 3},
+		},
+	}
+	db, err := sql.Open("sqlite3", "/tmp/sqllite.db")
+	if !assert.Nil(t, err) {
+		return
+	}
+	for _, SQL := range []string{
+		"DROP TABLE IF EXISTS t_soft",
+		"CREATE TABLE t_soft (id INTEGER PRIMARY KEY, name TEXT, deleted_at DATETIME)",
+		"INSERT INTO t_soft (id, name) VALUES(1, 'n1')",
+		"INSERT INTO t_soft (id, name, deleted_at) VALUES(2, 'n2', '2022-01-01 00:00:00')",
+		"INSERT INTO t_soft (id, name) VALUES(3, 'n3')",
+	} {
+		if _, err = db.Exec(SQL); !assert.Nil(t, err) {
+			return
+		}
+	}
+	for _, testCase := range testCases {
+		reader, err := read.New(context.TODO(), db, testCase.SQL, func() interface{} { return &entity{} }, testCase.options...)
+		if !assert.Nil(t, err, testCase.description) {
+			continue
+		}
+		var actual []int
+		err = reader.QueryAll(context.TODO(), func(row interface{}) error {
+			actual = append(actual, row.(*entity).Id)
+			return nil
+		}, 0)
+		assert.Nil(t, err, testCase.description)
+		assert.EqualValues(t, testCase.expect, actual, testCase.description)
+	}
+}
+
+func TestReader_QueryAll_named(t *testing.T) {
+	type entity struct {
+		Id   int    `sqlx:"name=id,primaryKey"`
+		Name string `sqlx:"name"`
+	}
+	type criteria struct {
+		MinID int `sqlx:"min_id"`
+		Name  string
+	}
+	var testCases = []struct {
+		description string
+		options     []option.Option
+		args        []interface{}
+		expect      []int
+	}{
+		{
+			description: "struct argument",
+			options:     []option.Option{option.NewNamedArgs(nil)},
+			args:        []interface{}{&criteria{MinID: 1, Name: "n?"}},
+			expect:      []int{3},
+		},
+		{
+			description: "map argument",
+			options:     []option.Option{option.NewNamedArgs(nil)},
+			args:        []interface{}{map[string]interface{}{"min_id": 0, "name": "n?"}},
+			expect:      []int{1, 3},
+		},
+		{
+			description: "option source",
+			options:     []option.Option{option.NewNamedArgs(map[string]interface{}{"min_id": 2, "name": "n?"})},
+			expect:      []int{3},
+		},
+	}
+	db, err := sql.Open("sqlite3", "/tmp/sqllite.db")
+	if !assert.Nil(t, err) {
+		return
+	}
+	for _, SQL := range []string{
+		"DROP TABLE IF EXISTS t_named",
+		"CREATE TABLE t_named (id INTEGER PRIMARY KEY, name TEXT)",
+		"INSERT INTO t_named (id, name) VALUES(1, 'n?'), (2, 'x'), (3, 'n?')",
+	} {
+		if _, err = db.Exec(SQL); !assert.Nil(t, err) {
+			return
+		}
+	}
+	for _, testCase := range testCases {
+		reader, err := read.New(context.TODO(), db, "SELECT id, name FROM t_named WHERE id > :min_id AND name = @name AND ':x' <> '?' ORDER BY id", func() interface{} { return &entity{} }, testCase.options...)
+		if !assert.Nil(t, err, testCase.description) {
+			continue
+		}
+		var actual []int
+		err = reader.QueryAll(context.TODO(), func(row interface{}) error {
+			actual = append(actual, row.(*entity).Id)
+			return nil
+		}, testCase.args...)
+		assert.Nil(t, err, testCase.description)
+		assert.EqualValues(t, testCase.expect, actual, testCase.description)
+	}
+}
+
+func TestReader_QueryAll_slice(t *testing.T) {
+	type entity struct {
+		Id   int    `sqlx:"name=id,primaryKey"`
+		Name string `sqlx:"name"`
+	}
+	limited := &info.Dialect{Placeholder: "?", MaxParameters: 3}
+	var testCases = []struct {
+		description string
+		SQL         string
+		options     []option.Option
+		args        []interface{}
+		expect      []int
+		expectErr   bool
+	}{
+		{
+			description: "slice argument",
+			SQL:         "SELECT id, name FROM t_slice WHERE name = ? AND id IN (?) ORDER BY id",
+			args:        []interface{}{"n", []int{1, 3, 4}},
+			expect:      []int{1, 3},
+		},
+		{
+			description: "empty slice",
+			SQL:         "SELECT id, name FROM t_slice WHERE id IN (?) ORDER BY id",
+			args:        []interface{}{[]int{}},
+			expectErr:   true,
+		},
+		{
+			description: "named slice argument",
+			SQL:         "SELECT id, name FROM t_slice WHERE id IN (:ids) ORDER BY id",
+			options:     []option.Option{option.NewNamedArgs(nil)},
+			args:        []interface{}{map[string]interface{}{"ids": []int{2, 4}}},
+			expect:      []int{2, 4},
+		},
+		{
+			description: "chunked slice argument",
+			SQL:         "SELECT id, name FROM t_slice WHERE name <> ? AND id IN (?)",
+			options:     []option.Option{limited},
+			args:        []interface{}{"x", []int{1, 2, 3, 4, 5}},
+			expect:      []int{1, 2, 3, 4},
+		},
+		{
+			description: "ordered query can not be chunked",
+			SQL:         "SELECT id, name FROM t_slice WHERE name <> ? AND id IN (?) ORDER BY id",
+			options:     []option.Option{limited},
+			args:        []interface{}{"x", []int{1, 2, 3, 4, 5}},
+			expectErr:   true,
+		},
+		{
+			description: "ordered query within parameters limit",
+			SQL:         "SELECT id, name FROM t_slice WHERE name <> ? AND id IN (?) ORDER BY id DESC",
+			options:     []option.Option{limited},
+			args:        []interface{}{"x", []int{1, 2}},
+			expect:      []int{2, 1},
+		},
+	}
+	db, err := sql.Open("sqlite3", "/tmp/sqllite.db")
+	if !assert.Nil(t, err) {
+		return
+	}
+	for _, SQL := range []string{
+		"DROP TABLE IF EXISTS t_slice",
+		"CREATE TABLE t_slice (id INTEGER PRIMARY KEY, name TEXT)",
+		"INSERT INTO t_slice (id, name) VALUES(1, 'n'), (2, 'm'), (3, 'n'), (4, 'm'), (5, 'x')",
+	} {
+		if _, err = db.Exec(SQL); !assert.Nil(t, err) {
+			return
+		}
+	}
+	for _, testCase := range testCases {
+		reader, err := read.New(context.TODO(), db, testCase.SQL, func() interface{} { return &entity{} }, testCase.options...)
+		if !assert.Nil(t, err, testCase.description) {
+			continue
+		}
+		var actual []int
+		for i := 0; i < 2; i++ { //second run reuses prepared statement
+			actual = nil
+			err = reader.QueryAll(context.TODO(), func(row interface{}) error {
+				actual = append(actual, row.(*entity).Id)
+				return nil
+			}, testCase.args...)
+			assert.Equal(t, testCase.expectErr, err != nil, testCase.description)
+		}
+		assert.EqualValues(t, testCase.expect, actual, testCase.description)
+		assert.Nil(t, reader.Close(), testCase.description)
+	}
+}
@@ -0,0 +1,83 @@
+package read
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/viant/sqlx"
+	"github.com/viant/sqlx/option"
+	"iter"
+)
+
+//errStopped is used to stop reading once iteration consumer breaks
+var errStopped = errors.New("iteration stopped")
+
+//Typed represents type safe records reader
+type Typed[T any] struct {
+	reader *Reader
+}
+
+//Reader returns underlying reader
+func (t *Typed[T]) Reader() *Reader {
+	return t.reader
+}
+
+//QueryAll returns all records
+func (t *Typed[T]) QueryAll(ctx context.Context, args ...interface{}) ([]*T, error) {
+	var result []*T
+	err := t.reader.QueryAll(ctx, func(row interface{}) error {
+		record, err := asRecord[T](row)
+		if err != nil {
+			return err
+		}
+		result = append(result, record)
+		return nil
+	}, args...)
+	return result, err
+}
+
+//QuerySingle returns the first record or nil if query returned no rows
+func (t *Typed[T]) QuerySingle(ctx context.Context, args ...interface{}) (*T, error) {
+	var result *T
+	err := t.reader.QuerySingle(ctx, func(row interface{}) (err error) {
+		result, err = asRecord[T](row)
+		return err
+	}, args...)
+	return result, err
+}
+
+//QueryIter returns records iterator, reading stops once consumer breaks the loop, query error is yielded as the last element
+func (t *Typed[T]) QueryIter(ctx context.Context, args ...interface{}) iter.Seq2[*T, error] {
+	return func(yield func(*T, error) bool) {
+		err := t.reader.QueryAll(ctx, func(row interface{}) error {
+			record, err := asRecord[T](row)
+			if err != nil {
+				return err
+			}
+			if !yield(record, nil) {
+				return errStopped
+			}
+			return nil
+		}, args...)
+		if err != nil && !errors.Is(err, errStopped) {
+			yield(nil, err)
+		}
+	}
+}
+
+func asRecord[T any](row interface{}) (*T, error) {
+	record, ok := row.(*T)
+	if !ok {
+		return nil, fmt.Errorf("expected %T, but had %T", record, row)
+	}
+	return record, nil
+}
+
+//NewTyped creates a type safe records reader
+func NewTyped[T any](ctx context.Context, db sqlx.Executor, query string, options ...option.Option) (*Typed[T], error) {
+	reader, err := New(ctx, db, query, func() interface{} { return new(T) }, options...)
+	if err != nil {
+		return nil, err
+	}
+	return &Typed[T]{reader: reader}, nil
+}
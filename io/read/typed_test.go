@@ -0,0 +1,66 @@
+package read_test
+
+import (
+	"context"
+	"database/sql"
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/assert"
+	"github.com/viant/sqlx/io/read"
+	"testing"
+)
+
+func TestTyped_QueryAll(t *testing.T) {
+	type entity struct {
+		Id   int    `sqlx:"name=id,primaryKey"`
+		Name string `sqlx:"name"`
+	}
+	db, err := sql.Open("sqlite3", "/tmp/sqllite.db")
+	if !assert.Nil(t, err) {
+		return
+	}
+	for _, SQL := range []string{
+		"DROP TABLE IF EXISTS t_typed",
+		"CREATE TABLE t_typed (id INTEGER PRIMARY KEY, name TEXT)",
+		"INSERT INTO t_typed (id, name) VALUES(1, 'n1'), (2, 'n2'), (3, 'n3')",
+	} {
+		if _, err = db.Exec(SQL); !assert.Nil(t, err) {
+			return
+		}
+	}
+	reader, err := read.NewTyped[entity](context.TODO(), db, "SELECT id, name FROM t_typed WHERE id > ? ORDER BY id")
+	if !assert.Nil(t, err) {
+		return
+	}
+
+	records, err := reader.QueryAll(context.TODO(), 1)
+	assert.Nil(t, err)
+	assert.EqualValues(t, []*entity{{Id: 2, Name: "n2"}, {Id: 3, Name: "n3"}}, records)
+
+	record, err := reader.QuerySingle(context.TODO(), 2)
+	assert.Nil(t, err)
+	assert.EqualValues(t, &entity{Id: 3, Name: "n3"}, record)
+
+	var ids []int
+	for record, err := range reader.QueryIter(context.TODO(), 0) {
+		if !assert.Nil(t, err) {
+			break
+		}
+		ids = append(ids, record.Id)
+		if len(ids) == 2 {
+			break
+		}
+	}
+	assert.EqualValues(t, []int{1, 2}, ids)
+	records, err = reader.QueryAll(context.TODO(), 2)
+	assert.Nil(t, err)
+	assert.EqualValues(t, []*entity{{Id: 3, Name: "n3"}}, records)
+
+	invalid, err := read.NewTyped[entity](context.TODO(), db, "SELECT id, name FROM t_typed_missing")
+	if !assert.Nil(t, err) {
+		return
+	}
+	for record, err := range invalid.QueryIter(context.TODO()) {
+		assert.Nil(t, record)
+		assert.NotNil(t, err)
+	}
+}
@@ -0,0 +1,27 @@
+package io
+
+import (
+	"context"
+	"github.com/viant/sqlx/metadata/info"
+	"github.com/viant/sqlx/option"
+	"time"
+)
+
+//ShallRetry returns true after backoff delay if err is transient dialect error, retry policy allows next attempt
+//and transaction is owned by session, caller supplied transaction (*sql.Tx, unit of work) is never retried
+func ShallRetry(ctx context.Context, retry *option.Retry, dialect *info.Dialect, transaction *Transaction, attempt int, err error) bool {
+	if retry == nil || attempt >= retry.MaxAttempts || transaction == nil || transaction.Global {
+		return false
+	}
+	if !dialect.IsTransient(err) {
+		return false
+	}
+	timer := time.NewTimer(retry.Backoff(attempt))
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return false
+	case <-timer.C:
+		return true
+	}
+}
@@ -0,0 +1,40 @@
+package io
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"github.com/viant/sqlx/metadata/info"
+	"github.com/viant/sqlx/option"
+	"testing"
+	"time"
+)
+
+func TestShallRetry(t *testing.T) {
+	transient := fmt.Errorf("deadlock")
+	dialect := &info.Dialect{TransientError: func(err error) bool { return err == transient }}
+	retry := option.NewRetry(3, time.Millisecond, 0)
+	owned := &Transaction{Tx: &sql.Tx{}}
+
+	var testCases = []struct {
+		description string
+		retry       *option.Retry
+		transaction *Transaction
+		attempt     int
+		err         error
+		expect      bool
+	}{
+		{description: "transient error", retry: retry, transaction: owned, attempt: 1, err: transient, expect: true},
+		{description: "attempts exhausted", retry: retry, transaction: owned, attempt: 3, err: transient},
+		{description: "no retry policy", transaction: owned, attempt: 1, err: transient},
+		{description: "non transient error", retry: retry, transaction: owned, attempt: 1, err: fmt.Errorf("syntax error")},
+		{description: "caller transaction", retry: retry, transaction: &Transaction{Tx: &sql.Tx{}, Global: true}, attempt: 1, err: transient},
+		{description: "no transaction", retry: retry, attempt: 1, err: transient},
+	}
+
+	for _, testCase := range testCases {
+		actual := ShallRetry(context.Background(), testCase.retry, dialect, testCase.transaction, testCase.attempt, testCase.err)
+		assert.Equal(t, testCase.expect, actual, testCase.description)
+	}
+}
@@ -0,0 +1,58 @@
+package io
+
+import (
+	"reflect"
+	"time"
+)
+
+// SoftDelete represents soft delete marker column, timestamp marker is set to current time, other markers are set to true
+type SoftDelete struct {
+	Name   string
+	IsTime bool
+	IsBool bool
+}
+
+// Value returns deleted marker value
+func (d *SoftDelete) Value() interface{} {
+	switch {
+	case d.IsTime:
+		return time.Now().UTC()
+	case d.IsBool:
+		return true
+	default:
+		return 1
+	}
+}
+
+// Criterion returns criterion matching not deleted rows
+func (d *SoftDelete) Criterion() string {
+	switch {
+	case d.IsTime:
+		return d.Name + " IS NULL"
+	case d.IsBool:
+		return "(" + d.Name + " IS NULL OR " + d.Name + " = FALSE)"
+	default:
+		return "COALESCE(" + d.Name + ", 0) = 0"
+	}
+}
+
+// SoftDelete returns soft delete column or nil
+func (c Columns) SoftDelete() *SoftDelete {
+	for _, item := range c {
+		tag := item.Tag()
+		if tag == nil || !tag.SoftDelete {
+			continue
+		}
+		scanType := item.ScanType()
+		for scanType != nil && scanType.Kind() == reflect.Ptr {
+			scanType = scanType.Elem()
+		}
+		result := &SoftDelete{Name: item.Name()}
+		if scanType != nil {
+			result.IsTime = scanType == reflect.TypeOf(time.Time{})
+			result.IsBool = scanType.Kind() == reflect.Bool
+		}
+		return result
+	}
+	return nil
+}
@@ -20,12 +20,16 @@ type Tag struct {
 	Transient        bool
 	Ns               string
 	Generator        string
+	Generated        bool
 	IsUnique         bool
 	Db               string
 	Table            string
 	RefDb            string
 	RefTable         string
 	RefColumn        string
+	Key              string
+	Version          bool
+	SoftDelete       bool
 	Required         bool
 	NullifyEmpty     bool
 	ErrorMgs         string
@@ -95,10 +99,16 @@ func ParseTag(tagString string) *Tag {
 				tag.RefTable = nv[1]
 			case "refcolumn":
 				tag.RefColumn = nv[1]
+			case "key":
+				tag.Key = strings.TrimSpace(nv[1])
 			case "transient":
 				tag.Transient = strings.TrimSpace(nv[1]) == "true"
 			case "bit":
 				tag.Bit = strings.TrimSpace(nv[1]) == "true"
+			case "version":
+				tag.Version = strings.TrimSpace(nv[1]) == "true"
+			case "softdelete":
+				tag.SoftDelete = strings.TrimSpace(nv[1]) == "true"
 			case "required":
 				tag.Required = strings.TrimSpace(nv[1]) == "true"
 			case "errormsg":
@@ -110,6 +120,8 @@ func ParseTag(tagString string) *Tag {
 					tag.Autoincrement = true
 					tag.Generator = ""
 				}
+			case "generated":
+				tag.Generated = strings.TrimSpace(nv[1]) == "true"
 			case "nullifyempty":
 				nullifyEmpty := strings.TrimSpace(nv[1])
 				tag.NullifyEmpty = nullifyEmpty == "true" || nullifyEmpty == ""
@@ -127,10 +139,16 @@ func ParseTag(tagString string) *Tag {
 				tag.PrimaryKey = true
 			case "bit":
 				tag.Bit = true
+			case "version":
+				tag.Version = true
+			case "softdelete":
+				tag.SoftDelete = true
 			case "primarykey":
 				tag.PrimaryKey = true
 			case "unique":
 				tag.IsUnique = true
+			case "generated":
+				tag.Generated = true
 			case "nullifyempty":
 				tag.NullifyEmpty = true
 			case "required":
@@ -0,0 +1,138 @@
+package otel
+
+import (
+	"context"
+	"fmt"
+	"github.com/viant/sqlx/option"
+	global "go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/trace"
+	"go.opentelemetry.io/otel/trace/noop"
+)
+
+//InstrumentationName defines tracer and meter instrumentation name
+const InstrumentationName = "github.com/viant/sqlx"
+
+type (
+	//Telemetry represents OpenTelemetry option.Tracer and option.Meter adapter, nil Telemetry does not record anything
+	Telemetry struct {
+		tracer trace.Tracer
+		meter  metric.Meter
+	}
+
+	span struct {
+		trace.Span
+	}
+
+	meter struct {
+		metric.Meter
+	}
+
+	counter struct {
+		metric.Int64UpDownCounter
+	}
+
+	histogram struct {
+		metric.Float64Histogram
+	}
+)
+
+var noopSpan = &span{Span: noop.Span{}}
+
+//Start starts a span, returned context carries the span
+func (t *Telemetry) Start(ctx context.Context, name string, attributes ...option.Attribute) (context.Context, option.Span) {
+	if t == nil {
+		return ctx, noopSpan
+	}
+	ctx, traceSpan := t.tracer.Start(ctx, name, trace.WithAttributes(keyValues(attributes)...))
+	return ctx, &span{Span: traceSpan}
+}
+
+//Meter returns option.Meter adapter, nil for nil telemetry
+func (t *Telemetry) Meter() option.Meter {
+	if t == nil {
+		return nil
+	}
+	return &meter{Meter: t.meter}
+}
+
+//Counter returns up down counter
+func (m *meter) Counter(name, description, unit string) (option.Counter, error) {
+	instrument, err := m.Int64UpDownCounter(name, metric.WithDescription(description), metric.WithUnit(unit))
+	if err != nil {
+		return nil, err
+	}
+	return &counter{Int64UpDownCounter: instrument}, nil
+}
+
+//Histogram returns histogram
+func (m *meter) Histogram(name, description, unit string) (option.Histogram, error) {
+	instrument, err := m.Float64Histogram(name, metric.WithDescription(description), metric.WithUnit(unit))
+	if err != nil {
+		return nil, err
+	}
+	return &histogram{Float64Histogram: instrument}, nil
+}
+
+//Add adds delta to counter
+func (c *counter) Add(ctx context.Context, delta int64, attributes ...option.Attribute) {
+	c.Int64UpDownCounter.Add(ctx, delta, metric.WithAttributes(keyValues(attributes)...))
+}
+
+//Record records value
+func (h *histogram) Record(ctx context.Context, value float64, attributes ...option.Attribute) {
+	h.Float64Histogram.Record(ctx, value, metric.WithAttributes(keyValues(attributes)...))
+}
+
+//SetAttributes sets span attributes
+func (s *span) SetAttributes(attributes ...option.Attribute) {
+	s.Span.SetAttributes(keyValues(attributes)...)
+}
+
+//End records error if any and ends the span
+func (s *span) End(err error) {
+	if err != nil && s.IsRecording() {
+		s.RecordError(err)
+		s.SetStatus(codes.Error, err.Error())
+	}
+	s.Span.End()
+}
+
+//keyValues converts attributes to OpenTelemetry attributes
+func keyValues(attributes []option.Attribute) []attribute.KeyValue {
+	var result = make([]attribute.KeyValue, 0, len(attributes))
+	for _, item := range attributes {
+		key := attribute.Key(item.Key)
+		switch actual := item.Value.(type) {
+		case string:
+			result = append(result, key.String(actual))
+		case int:
+			result = append(result, key.Int(actual))
+		case int64:
+			result = append(result, key.Int64(actual))
+		case float64:
+			result = append(result, key.Float64(actual))
+		case bool:
+			result = append(result, key.Bool(actual))
+		default:
+			result = append(result, key.String(fmt.Sprint(actual)))
+		}
+	}
+	return result
+}
+
+//New creates telemetry for supplied providers, global otel providers are used for nil provider
+func New(tracerProvider trace.TracerProvider, meterProvider metric.MeterProvider) *Telemetry {
+	if tracerProvider == nil {
+		tracerProvider = global.GetTracerProvider()
+	}
+	if meterProvider == nil {
+		meterProvider = global.GetMeterProvider()
+	}
+	return &Telemetry{
+		tracer: tracerProvider.Tracer(InstrumentationName),
+		meter:  meterProvider.Meter(InstrumentationName),
+	}
+}
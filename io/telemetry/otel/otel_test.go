@@ -0,0 +1,156 @@
+package otel_test
+
+import (
+	"context"
+	"database/sql"
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/assert"
+	"github.com/viant/sqlx/io/delete"
+	"github.com/viant/sqlx/io/insert"
+	"github.com/viant/sqlx/io/insert/batcher"
+	"github.com/viant/sqlx/io/read"
+	"github.com/viant/sqlx/io/telemetry"
+	"github.com/viant/sqlx/io/telemetry/otel"
+	"github.com/viant/sqlx/io/update"
+	_ "github.com/viant/sqlx/metadata/product/sqlite"
+	"github.com/viant/sqlx/option"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"reflect"
+	"testing"
+)
+
+type entity struct {
+	ID   int    `sqlx:"name=id,primaryKey"`
+	Name string `sqlx:"name=name,unique"`
+}
+
+func TestTelemetry_Spans(t *testing.T) {
+	ctx := context.Background()
+	db, err := sql.Open("sqlite3", "/tmp/sqllite.db")
+	if !assert.Nil(t, err) {
+		return
+	}
+	for _, SQL := range []string{
+		"DROP TABLE IF EXISTS t_telemetry",
+		"CREATE TABLE t_telemetry (id INTEGER PRIMARY KEY, name TEXT UNIQUE)",
+	} {
+		if _, err = db.Exec(SQL); !assert.Nil(t, err) {
+			return
+		}
+	}
+	exporter := tracetest.NewInMemoryExporter()
+	tel := otel.New(sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter)), nil)
+
+	inserter, err := insert.New(ctx, db, "t_telemetry", tel)
+	if !assert.Nil(t, err) {
+		return
+	}
+	_, _, err = inserter.Exec(ctx, []*entity{{ID: 1, Name: "n1"}, {ID: 2, Name: "n2"}}, option.BatchSize(2))
+	assert.Nil(t, err)
+	_, _, err = inserter.Exec(ctx, []*entity{{ID: 1, Name: "n1"}})
+	assert.NotNil(t, err)
+	updater, err := update.New(ctx, db, "t_telemetry")
+	if !assert.Nil(t, err) {
+		return
+	}
+	_, err = updater.Exec(ctx, []*entity{{ID: 1, Name: "u1"}}, tel)
+	assert.Nil(t, err)
+	deleter, err := delete.New(ctx, db, "t_telemetry", tel)
+	if !assert.Nil(t, err) {
+		return
+	}
+	_, err = deleter.Exec(ctx, []*entity{{ID: 2}})
+	assert.Nil(t, err)
+	reader, err := read.New(ctx, db, "SELECT id, name FROM t_telemetry", func() interface{} { return &entity{} }, tel)
+	if !assert.Nil(t, err) {
+		return
+	}
+	err = reader.QueryAll(ctx, func(row interface{}) error { return nil })
+	assert.Nil(t, err)
+
+	spans := exporter.GetSpans()
+	var names []string
+	for _, span := range spans {
+		names = append(names, span.Name)
+	}
+	assert.Equal(t, []string{"sqlx.insert.flush", "sqlx.insert.flush", "sqlx.update.flush", "sqlx.delete.flush", "sqlx.read.QueryAll"}, names)
+	if len(spans) != 5 {
+		return
+	}
+	assert.EqualValues(t, 2, attributeValue(spans[0].Attributes, telemetry.BatchSize).AsInt64())
+	assert.EqualValues(t, 2, attributeValue(spans[0].Attributes, telemetry.RowsAffected).AsInt64())
+	assert.Equal(t, "t_telemetry", attributeValue(spans[0].Attributes, telemetry.Table).AsString())
+	assert.Equal(t, codes.Error, spans[1].Status.Code)
+	assert.EqualValues(t, 1, attributeValue(spans[2].Attributes, telemetry.RowsAffected).AsInt64())
+	assert.Equal(t, "SELECT id, name FROM t_telemetry", attributeValue(spans[4].Attributes, telemetry.Statement).AsString())
+}
+
+func TestTelemetry_BatcherMetrics(t *testing.T) {
+	ctx := context.Background()
+	db, err := sql.Open("sqlite3", "/tmp/sqllite.db")
+	if !assert.Nil(t, err) {
+		return
+	}
+	for _, SQL := range []string{
+		"DROP TABLE IF EXISTS t_telemetry_batcher",
+		"CREATE TABLE t_telemetry_batcher (id INTEGER PRIMARY KEY, name TEXT)",
+	} {
+		if _, err = db.Exec(SQL); !assert.Nil(t, err) {
+			return
+		}
+	}
+	metricReader := sdkmetric.NewManualReader()
+	tel := otel.New(nil, sdkmetric.NewMeterProvider(sdkmetric.WithReader(metricReader)))
+	inserter, err := insert.New(ctx, db, "t_telemetry_batcher")
+	if !assert.Nil(t, err) {
+		return
+	}
+	service, err := batcher.New(ctx, inserter, reflect.TypeOf(&entity{}), &batcher.Config{MaxElements: 4, MaxDurationMs: 10, BatchSize: 2, Meter: tel.Meter()})
+	if !assert.Nil(t, err) {
+		return
+	}
+	var states []*batcher.State
+	for i := 1; i <= 4; i++ {
+		state, err := service.Collect(&entity{ID: i, Name: "n"})
+		if !assert.Nil(t, err) {
+			return
+		}
+		states = append(states, state)
+	}
+	for _, state := range states {
+		assert.Nil(t, state.Wait())
+	}
+
+	data := metricdata.ResourceMetrics{}
+	if !assert.Nil(t, metricReader.Collect(ctx, &data)) {
+		return
+	}
+	values := map[string]interface{}{}
+	for _, scope := range data.ScopeMetrics {
+		for _, aMetric := range scope.Metrics {
+			switch actual := aMetric.Data.(type) {
+			case metricdata.Sum[int64]:
+				values[aMetric.Name] = actual.DataPoints[0].Value
+			case metricdata.Histogram[float64]:
+				values[aMetric.Name] = actual.DataPoints[0].Count
+			}
+		}
+	}
+	assert.EqualValues(t, 0, values["sqlx.batcher.queue.depth"])
+	assert.EqualValues(t, 1, values["sqlx.batcher.flush.duration"])
+	assert.EqualValues(t, 1, values["sqlx.batcher.batch.fill"])
+}
+
+func attributeValue(attributes []attribute.KeyValue, key telemetry.Key) attribute.Value {
+	for _, candidate := range attributes {
+		if candidate.Key == attribute.Key(key) {
+			return candidate.Value
+		}
+	}
+	return attribute.Value{}
+}
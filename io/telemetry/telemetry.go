@@ -0,0 +1,70 @@
+package telemetry
+
+import (
+	"context"
+	"github.com/viant/sqlx/option"
+)
+
+//Key represents span attribute key
+type Key string
+
+const (
+	//Statement defines SQL statement attribute
+	Statement = Key("db.statement")
+	//Table defines table name attribute
+	Table = Key("db.sql.table")
+	//BatchSize defines flushed batch size attribute
+	BatchSize = Key("sqlx.batch.size")
+	//RowsAffected defines rows affected attribute
+	RowsAffected = Key("sqlx.rows.affected")
+	//CacheHit defines cache hit attribute
+	CacheHit = Key("sqlx.cache.hit")
+	//CacheType defines cache match type attribute (cache.Stats Type)
+	CacheType = Key("sqlx.cache.type")
+	//CacheKey defines cache entry key attribute
+	CacheKey = Key("sqlx.cache.key")
+)
+
+//String returns string attribute
+func (k Key) String(value string) option.Attribute {
+	return option.Attribute{Key: string(k), Value: value}
+}
+
+//Int returns int attribute
+func (k Key) Int(value int) option.Attribute {
+	return option.Attribute{Key: string(k), Value: value}
+}
+
+//Int64 returns int64 attribute
+func (k Key) Int64(value int64) option.Attribute {
+	return option.Attribute{Key: string(k), Value: value}
+}
+
+//Bool returns bool attribute
+func (k Key) Bool(value bool) option.Attribute {
+	return option.Attribute{Key: string(k), Value: value}
+}
+
+type noopSpan struct{}
+
+//SetAttributes does nothing
+func (s noopSpan) SetAttributes(attributes ...option.Attribute) {}
+
+//End does nothing
+func (s noopSpan) End(err error) {}
+
+//Start starts a span with tracer, nil tracer does not record anything
+func Start(ctx context.Context, tracer option.Tracer, name string, attributes ...option.Attribute) (context.Context, option.Span) {
+	if tracer == nil {
+		return ctx, noopSpan{}
+	}
+	return tracer.Start(ctx, name, attributes...)
+}
+
+//End sets span attributes, records error if any and ends the span
+func End(span option.Span, err error, attributes ...option.Attribute) {
+	if len(attributes) > 0 {
+		span.SetAttributes(attributes...)
+	}
+	span.End(err)
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
+	"github.com/viant/sqlx"
 	"github.com/viant/sqlx/metadata/info"
 	"github.com/viant/sqlx/option"
 )
@@ -13,13 +14,25 @@ type Transaction struct {
 	Global bool
 }
 
-func TransactionFor(ctx context.Context, dialect *info.Dialect, db *sql.DB, options []option.Option) (*Transaction, error) {
+//TransactionFor returns transaction for transactional dialect
+func TransactionFor(ctx context.Context, dialect *info.Dialect, db sqlx.Executor, options []option.Option) (*Transaction, error) {
 	if !dialect.Transactional {
 		return nil, nil
 	}
+	return BeginTransaction(ctx, db, options)
+}
 
+//BeginTransaction returns global transaction for *sql.Tx option, context unit of work started for db executor or Tx-backed executor,
+//otherwise begins a new one, nil is returned if executor can not begin a transaction
+func BeginTransaction(ctx context.Context, db sqlx.Executor, options []option.Option) (*Transaction, error) {
 	var tx *sql.Tx
 	option.Assign(options, &tx)
+	if tx == nil {
+		tx = UnitOfWorkFrom(ctx).TxFor(db)
+	}
+	if tx == nil {
+		tx, _ = db.(*sql.Tx)
+	}
 	if tx != nil {
 		return &Transaction{
 			Tx:     tx,
@@ -27,7 +40,11 @@ func TransactionFor(ctx context.Context, dialect *info.Dialect, db *sql.DB, opti
 		}, nil
 	}
 
-	tx, err := db.BeginTx(ctx, nil)
+	beginner, ok := db.(sqlx.TxBeginner)
+	if !ok {
+		return nil, nil
+	}
+	tx, err := beginner.BeginTx(ctx, nil)
 	if err != nil {
 		if tx == nil {
 			return nil, err
@@ -42,6 +59,22 @@ func TransactionFor(ctx context.Context, dialect *info.Dialect, db *sql.DB, opti
 	}, nil
 }
 
+//CallerTransaction returns global transaction for *sql.Tx option, context unit of work started for db executor or Tx-backed executor, nil otherwise
+func CallerTransaction(ctx context.Context, db sqlx.Executor, options []option.Option) *Transaction {
+	var tx *sql.Tx
+	option.Assign(options, &tx)
+	if tx == nil {
+		tx = UnitOfWorkFrom(ctx).TxFor(db)
+	}
+	if tx == nil {
+		tx, _ = db.(*sql.Tx)
+	}
+	if tx == nil {
+		return nil
+	}
+	return &Transaction{Tx: tx, Global: true}
+}
+
 func (t *Transaction) Rollback() error {
 	if t.Global {
 		return nil
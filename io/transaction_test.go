@@ -0,0 +1,64 @@
+package io
+
+import (
+	"context"
+	"database/sql"
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/assert"
+	"github.com/viant/sqlx"
+	"testing"
+)
+
+func TestBeginTransaction(t *testing.T) {
+	db, err := sql.Open("sqlite3", "/tmp/sqllite.db")
+	if !assert.Nil(t, err) {
+		return
+	}
+	ctx := context.Background()
+	conn, err := db.Conn(ctx)
+	if !assert.Nil(t, err) {
+		return
+	}
+	defer conn.Close()
+	tx, err := db.BeginTx(ctx, nil)
+	if !assert.Nil(t, err) {
+		return
+	}
+	defer tx.Rollback()
+
+	type wrapper struct {
+		sqlx.Executor
+	}
+
+	var testCases = []struct {
+		description string
+		db          sqlx.Executor
+		expectNil   bool
+		expectTx    *sql.Tx
+		expectOwned bool
+	}{
+		{description: "db", db: db, expectOwned: true},
+		{description: "conn", db: conn, expectOwned: true},
+		{description: "tx", db: tx, expectTx: tx},
+		{description: "wrapped executor", db: wrapper{Executor: db}, expectNil: true},
+	}
+
+	for _, testCase := range testCases {
+		transaction, err := BeginTransaction(ctx, testCase.db, nil)
+		if !assert.Nil(t, err, testCase.description) {
+			continue
+		}
+		if testCase.expectNil {
+			assert.Nil(t, transaction, testCase.description)
+			continue
+		}
+		if !assert.NotNil(t, transaction, testCase.description) {
+			continue
+		}
+		assert.EqualValues(t, !testCase.expectOwned, transaction.Global, testCase.description)
+		if testCase.expectTx != nil {
+			assert.Same(t, testCase.expectTx, transaction.Tx, testCase.description)
+		}
+		assert.Nil(t, transaction.Rollback(), testCase.description)
+	}
+}
@@ -0,0 +1,190 @@
+package io
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"github.com/viant/sqlx"
+	"github.com/viant/sqlx/metadata/info"
+	"github.com/viant/sqlx/option"
+	"reflect"
+	"sync"
+	"sync/atomic"
+)
+
+type unitOfWorkKey struct{}
+
+//UnitOfWork represents transaction propagated with context, io services using unit of work executor run within its transaction,
+//nested units of work use dialect savepoints, unit of work for other executor runs its own transaction
+type UnitOfWork struct {
+	db          sqlx.Executor
+	dialect     *info.Dialect
+	options     []option.Option
+	transaction *Transaction
+	outer       *UnitOfWork
+	savepoints  *uint32
+	mux         sync.Mutex
+	onCommit    []func(ctx context.Context) error
+}
+
+//Tx returns unit of work transaction, nil if unit of work has not been started
+func (u *UnitOfWork) Tx() *sql.Tx {
+	if u == nil || u.transaction == nil {
+		return nil
+	}
+	return u.transaction.Tx
+}
+
+//TxFor returns transaction of unit of work or its outer unit of work started for db executor, nil otherwise
+func (u *UnitOfWork) TxFor(db sqlx.Executor) *sql.Tx {
+	if unit := u.unitFor(db); unit != nil {
+		return unit.transaction.Tx
+	}
+	return nil
+}
+
+//unitFor returns unit of work or its outer unit of work started for db executor or running its transaction
+func (u *UnitOfWork) unitFor(db sqlx.Executor) *UnitOfWork {
+	for unit := u; unit != nil; unit = unit.outer {
+		if unit.transaction == nil {
+			continue
+		}
+		if sameExecutor(unit.db, db) || sameExecutor(unit.transaction.Tx, db) {
+			return unit
+		}
+	}
+	return nil
+}
+
+//Run runs fn within unit of work, transaction is committed if fn succeeded and rolled back otherwise,
+//if context already carries unit of work for the same executor, fn runs within its transaction nested in a savepoint
+func (u *UnitOfWork) Run(ctx context.Context, fn func(ctx context.Context) error) (err error) {
+	outer := UnitOfWorkFrom(ctx)
+	if active := outer.unitFor(u.db); active != nil {
+		return active.runNested(ctx, fn)
+	}
+	transaction, err := BeginTransaction(ctx, u.db, u.options)
+	if err != nil {
+		return err
+	}
+	if transaction == nil {
+		return fmt.Errorf("unable to begin unit of work with %T", u.db)
+	}
+	active := &UnitOfWork{db: u.db, dialect: u.dialect, transaction: transaction, outer: outer, savepoints: new(uint32)}
+	defer func() {
+		if r := recover(); r != nil {
+			_ = transaction.Rollback()
+			panic(r)
+		}
+	}()
+	if err = fn(context.WithValue(ctx, unitOfWorkKey{}, active)); err != nil {
+		return transaction.RollbackWithErr(err)
+	}
+	if err = transaction.Commit(); err != nil {
+		return err
+	}
+	return active.committed(ctx)
+}
+
+//OnCommit registers fn called once unit of work transaction is committed, callbacks registered within nested unit of work
+//are discarded when its savepoint is rolled back, registration fails if unit of work has not been started
+//or runs within externally managed transaction, which commit can not be observed
+func (u *UnitOfWork) OnCommit(ctx context.Context, fn func(ctx context.Context) error) error {
+	if u == nil || u.transaction == nil {
+		return fmt.Errorf("unable to register on commit callback: unit of work has not been started")
+	}
+	if u.transaction.Global {
+		return fmt.Errorf("unable to register on commit callback: %w", ErrCallerTransaction)
+	}
+	u.mux.Lock()
+	u.onCommit = append(u.onCommit, fn)
+	u.mux.Unlock()
+	return nil
+}
+
+//committed calls registered on commit functions, returns the first error
+func (u *UnitOfWork) committed(ctx context.Context) error {
+	u.mux.Lock()
+	onCommit := u.onCommit
+	u.onCommit = nil
+	u.mux.Unlock()
+	var err error
+	for _, fn := range onCommit {
+		if fnErr := fn(ctx); fnErr != nil && err == nil {
+			err = fnErr
+		}
+	}
+	return err
+}
+
+//callbacks returns registered on commit callbacks count
+func (u *UnitOfWork) callbacks() int {
+	u.mux.Lock()
+	defer u.mux.Unlock()
+	return len(u.onCommit)
+}
+
+//discard discards on commit callbacks registered after count callbacks, i.e. within rolled back savepoint
+func (u *UnitOfWork) discard(count int) {
+	u.mux.Lock()
+	defer u.mux.Unlock()
+	if count < len(u.onCommit) {
+		u.onCommit = u.onCommit[:count]
+	}
+}
+
+func (u *UnitOfWork) runNested(ctx context.Context, fn func(ctx context.Context) error) (err error) {
+	if u.dialect == nil || u.dialect.Savepoint == "" {
+		return fmt.Errorf("unable to run nested unit of work: savepoints are not supported")
+	}
+	name := fmt.Sprintf("sqlx_sp%d", atomic.AddUint32(u.savepoints, 1))
+	if _, err = u.transaction.ExecContext(ctx, u.dialect.Savepoint+" "+name); err != nil {
+		return fmt.Errorf("failed to create savepoint %v: %w", name, err)
+	}
+	callbacks := u.callbacks()
+	defer func() {
+		if r := recover(); r != nil {
+			_, _ = u.transaction.ExecContext(ctx, u.dialect.RollbackToSavepoint+" "+name)
+			u.discard(callbacks)
+			panic(r)
+		}
+	}()
+	if err = fn(ctx); err != nil {
+		u.discard(callbacks)
+		if _, rErr := u.transaction.ExecContext(ctx, u.dialect.RollbackToSavepoint+" "+name); rErr != nil {
+			return fmt.Errorf("failed to rollback to savepoint %v: %w, %v", name, err, rErr)
+		}
+		return err
+	}
+	if u.dialect.ReleaseSavepoint == "" {
+		return nil
+	}
+	if _, err = u.transaction.ExecContext(ctx, u.dialect.ReleaseSavepoint+" "+name); err != nil {
+		return fmt.Errorf("failed to release savepoint %v: %w", name, err)
+	}
+	return nil
+}
+
+//sameExecutor returns true if both executors are the same comparable value, i.e. the same *sql.DB
+func sameExecutor(x, y sqlx.Executor) bool {
+	if x == nil || y == nil {
+		return false
+	}
+	xType := reflect.TypeOf(x)
+	return xType == reflect.TypeOf(y) && xType.Comparable() && x == y
+}
+
+//UnitOfWorkFrom returns the innermost unit of work active in context or nil
+func UnitOfWorkFrom(ctx context.Context) *UnitOfWork {
+	if ctx == nil {
+		return nil
+	}
+	unit, _ := ctx.Value(unitOfWorkKey{}).(*UnitOfWork)
+	return unit
+}
+
+//NewUnitOfWork creates a unit of work, *info.Dialect option defines savepoint statements used by nested units of work,
+//*sql.Tx option or *sql.Tx executor makes unit of work run within that (externally managed) transaction
+func NewUnitOfWork(db sqlx.Executor, options ...option.Option) *UnitOfWork {
+	return &UnitOfWork{db: db, dialect: option.Options(options).Dialect(), options: options}
+}
@@ -0,0 +1,362 @@
+package io
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/stretchr/testify/assert"
+	"github.com/viant/sqlx/metadata/info"
+	"github.com/viant/sqlx/option"
+	"testing"
+)
+
+func TestUnitOfWork_Run(t *testing.T) {
+	db, err := sql.Open("sqlite3", "/tmp/sqllite.db")
+	if !assert.Nil(t, err) {
+		return
+	}
+	defer db.Close()
+	dialect := &info.Dialect{Transactional: true, Savepoint: "SAVEPOINT", RollbackToSavepoint: "ROLLBACK TO SAVEPOINT", ReleaseSavepoint: "RELEASE SAVEPOINT"}
+
+	insert := func(ctx context.Context, id int) error {
+		tx, err := BeginTransaction(ctx, db, nil)
+		if err != nil {
+			return err
+		}
+		if !tx.Global {
+			return fmt.Errorf("expected unit of work transaction")
+		}
+		_, err = tx.ExecContext(ctx, "INSERT INTO t_unit(id) VALUES(?)", id)
+		return err
+	}
+	failure := fmt.Errorf("failure")
+
+	var testCases = []struct {
+		description string
+		dialect     *info.Dialect
+		fn          func(ctx context.Context, unit *UnitOfWork) error
+		expectErr   bool
+		expect      []int
+	}{
+		{
+			description: "commit",
+			dialect:     dialect,
+			fn: func(ctx context.Context, unit *UnitOfWork) error {
+				if err := insert(ctx, 1); err != nil {
+					return err
+				}
+				return insert(ctx, 2)
+			},
+			expect: []int{1, 2},
+		},
+		{
+			description: "rollback",
+			dialect:     dialect,
+			fn: func(ctx context.Context, unit *UnitOfWork) error {
+				if err := insert(ctx, 1); err != nil {
+					return err
+				}
+				return failure
+			},
+			expectErr: true,
+		},
+		{
+			description: "nested rollback to savepoint",
+			dialect:     dialect,
+			fn: func(ctx context.Context, unit *UnitOfWork) error {
+				if err := insert(ctx, 1); err != nil {
+					return err
+				}
+				err := unit.Run(ctx, func(ctx context.Context) error {
+					if err := insert(ctx, 2); err != nil {
+						return err
+					}
+					return failure
+				})
+				if err != failure {
+					return fmt.Errorf("expected nested failure, but had: %v", err)
+				}
+				return unit.Run(ctx, func(ctx context.Context) error {
+					return insert(ctx, 3)
+				})
+			},
+			expect: []int{1, 3},
+		},
+		{
+			description: "nested without savepoints",
+			fn: func(ctx context.Context, unit *UnitOfWork) error {
+				if err := insert(ctx, 1); err != nil {
+					return err
+				}
+				return unit.Run(ctx, func(ctx context.Context) error {
+					return insert(ctx, 2)
+				})
+			},
+			expectErr: true,
+		},
+	}
+
+	for _, testCase := range testCases {
+		for _, SQL := range []string{"DROP TABLE IF EXISTS t_unit", "CREATE TABLE t_unit(id INTEGER PRIMARY KEY)"} {
+			if _, err = db.Exec(SQL); !assert.Nil(t, err, testCase.description) {
+				return
+			}
+		}
+		unit := NewUnitOfWork(db, testCase.dialect)
+		err = unit.Run(context.Background(), func(ctx context.Context) error {
+			return testCase.fn(ctx, unit)
+		})
+		if testCase.expectErr {
+			assert.NotNil(t, err, testCase.description)
+		} else if !assert.Nil(t, err, testCase.description) {
+			continue
+		}
+		rows, err := db.Query("SELECT id FROM t_unit ORDER BY id")
+		if !assert.Nil(t, err, testCase.description) {
+			continue
+		}
+		var actual []int
+		for rows.Next() {
+			var id int
+			_ = rows.Scan(&id)
+			actual = append(actual, id)
+		}
+		_ = rows.Close()
+		assert.EqualValues(t, testCase.expect, actual, testCase.description)
+	}
+}
+
+func TestUnitOfWork_Run_executors(t *testing.T) {
+	db, err := sql.Open("sqlite3", "/tmp/sqllite.db")
+	if !assert.Nil(t, err) {
+		return
+	}
+	defer db.Close()
+	otherDb, err := sql.Open("sqlite3", "/tmp/sqllite_unit.db")
+	if !assert.Nil(t, err) {
+		return
+	}
+	defer otherDb.Close()
+	dialect := &info.Dialect{Transactional: true, Savepoint: "SAVEPOINT", RollbackToSavepoint: "ROLLBACK TO SAVEPOINT", ReleaseSavepoint: "RELEASE SAVEPOINT"}
+	failure := fmt.Errorf("failure")
+
+	insert := func(ctx context.Context, db *sql.DB, id int, expectGlobal bool) error {
+		tx, err := BeginTransaction(ctx, db, nil)
+		if err != nil {
+			return err
+		}
+		if tx.Global != expectGlobal {
+			return fmt.Errorf("expected global: %v, but had: %v", expectGlobal, tx.Global)
+		}
+		if _, err = tx.ExecContext(ctx, "INSERT INTO t_unit(id) VALUES(?)", id); err != nil {
+			return tx.RollbackWithErr(err)
+		}
+		return tx.Commit()
+	}
+
+	var testCases = []struct {
+		description string
+		fn          func(ctx context.Context) error
+		expectErr   bool
+		expect      []int
+		expectOther []int
+	}{
+		{
+			description: "other executor runs own transaction",
+			fn: func(ctx context.Context) error {
+				if err := insert(ctx, db, 1, true); err != nil {
+					return err
+				}
+				if err := insert(ctx, otherDb, 2, false); err != nil {
+					return err
+				}
+				return failure
+			},
+			expectErr:   true,
+			expectOther: []int{2},
+		},
+		{
+			description: "unit of work for other executor runs own transaction",
+			fn: func(ctx context.Context) error {
+				if err := insert(ctx, db, 1, true); err != nil {
+					return err
+				}
+				err := NewUnitOfWork(otherDb, dialect).Run(ctx, func(ctx context.Context) error {
+					if err := insert(ctx, otherDb, 2, true); err != nil {
+						return err
+					}
+					return insert(ctx, db, 3, true)
+				})
+				if err != nil {
+					return err
+				}
+				return failure
+			},
+			expectErr:   true,
+			expectOther: []int{2},
+		},
+		{
+			description: "commit with other executor",
+			fn: func(ctx context.Context) error {
+				if err := insert(ctx, db, 1, true); err != nil {
+					return err
+				}
+				return insert(ctx, otherDb, 2, false)
+			},
+			expect:      []int{1},
+			expectOther: []int{2},
+		},
+	}
+
+	query := func(db *sql.DB) ([]int, error) {
+		rows, err := db.Query("SELECT id FROM t_unit ORDER BY id")
+		if err != nil {
+			return nil, err
+		}
+		defer rows.Close()
+		var result []int
+		for rows.Next() {
+			var id int
+			if err = rows.Scan(&id); err != nil {
+				return nil, err
+			}
+			result = append(result, id)
+		}
+		return result, rows.Err()
+	}
+
+	for _, testCase := range testCases {
+		for _, aDb := range []*sql.DB{db, otherDb} {
+			for _, SQL := range []string{"DROP TABLE IF EXISTS t_unit", "CREATE TABLE t_unit(id INTEGER PRIMARY KEY)"} {
+				if _, err = aDb.Exec(SQL); !assert.Nil(t, err, testCase.description) {
+					return
+				}
+			}
+		}
+		err = NewUnitOfWork(db, dialect).Run(context.Background(), testCase.fn)
+		if testCase.expectErr {
+			assert.Equal(t, failure, err, testCase.description)
+		} else if !assert.Nil(t, err, testCase.description) {
+			continue
+		}
+		actual, err := query(db)
+		if !assert.Nil(t, err, testCase.description) {
+			continue
+		}
+		assert.EqualValues(t, testCase.expect, actual, testCase.description)
+		actual, err = query(otherDb)
+		if !assert.Nil(t, err, testCase.description) {
+			continue
+		}
+		assert.EqualValues(t, testCase.expectOther, actual, testCase.description)
+	}
+}
+
+func TestUnitOfWork_OnCommit(t *testing.T) {
+	db, err := sql.Open("sqlite3", "/tmp/sqllite.db")
+	if !assert.Nil(t, err) {
+		return
+	}
+	defer db.Close()
+	dialect := &info.Dialect{Transactional: true, Savepoint: "SAVEPOINT", RollbackToSavepoint: "ROLLBACK TO SAVEPOINT", ReleaseSavepoint: "RELEASE SAVEPOINT"}
+	failure := fmt.Errorf("failure")
+
+	var testCases = []struct {
+		description string
+		callerTx    bool
+		fn          func(ctx context.Context, unit *UnitOfWork, onCommit func(ctx context.Context) error) error
+		expectErr   bool
+		expect      int
+	}{
+		{
+			description: "called after commit",
+			fn: func(ctx context.Context, unit *UnitOfWork, onCommit func(ctx context.Context) error) error {
+				return UnitOfWorkFrom(ctx).OnCommit(ctx, onCommit)
+			},
+			expect: 1,
+		},
+		{
+			description: "not called after rollback",
+			fn: func(ctx context.Context, unit *UnitOfWork, onCommit func(ctx context.Context) error) error {
+				if err := UnitOfWorkFrom(ctx).OnCommit(ctx, onCommit); err != nil {
+					return err
+				}
+				return failure
+			},
+			expectErr: true,
+		},
+		{
+			description: "nested unit called after outer commit",
+			fn: func(ctx context.Context, unit *UnitOfWork, onCommit func(ctx context.Context) error) error {
+				return unit.Run(ctx, func(ctx context.Context) error {
+					return UnitOfWorkFrom(ctx).OnCommit(ctx, onCommit)
+				})
+			},
+			expect: 1,
+		},
+		{
+			description: "discarded after savepoint rollback",
+			fn: func(ctx context.Context, unit *UnitOfWork, onCommit func(ctx context.Context) error) error {
+				if err := UnitOfWorkFrom(ctx).OnCommit(ctx, onCommit); err != nil {
+					return err
+				}
+				err := unit.Run(ctx, func(ctx context.Context) error {
+					if err := UnitOfWorkFrom(ctx).OnCommit(ctx, onCommit); err != nil {
+						return err
+					}
+					return failure
+				})
+				if err != failure {
+					return fmt.Errorf("expected failure, but had: %v", err)
+				}
+				return nil
+			},
+			expect: 1,
+		},
+		{
+			description: "refused without unit of work",
+			fn: func(ctx context.Context, unit *UnitOfWork, onCommit func(ctx context.Context) error) error {
+				return UnitOfWorkFrom(context.Background()).OnCommit(ctx, onCommit)
+			},
+			expectErr: true,
+		},
+		{
+			description: "refused within caller transaction",
+			callerTx:    true,
+			fn: func(ctx context.Context, unit *UnitOfWork, onCommit func(ctx context.Context) error) error {
+				return UnitOfWorkFrom(ctx).OnCommit(ctx, onCommit)
+			},
+			expectErr: true,
+		},
+	}
+
+	for _, testCase := range testCases {
+		called := 0
+		onCommit := func(ctx context.Context) error {
+			called++
+			return nil
+		}
+		options := []option.Option{dialect}
+		var tx *sql.Tx
+		if testCase.callerTx {
+			if tx, err = db.Begin(); !assert.Nil(t, err, testCase.description) {
+				continue
+			}
+			options = append(options, tx)
+		}
+		unit := NewUnitOfWork(db, options...)
+		err = unit.Run(context.Background(), func(ctx context.Context) error {
+			return testCase.fn(ctx, unit, onCommit)
+		})
+		if tx != nil {
+			_ = tx.Rollback()
+		}
+		if testCase.expectErr {
+			assert.NotNil(t, err, testCase.description)
+		} else {
+			assert.Nil(t, err, testCase.description)
+		}
+		assert.EqualValues(t, testCase.expect, called, testCase.description)
+	}
+}
@@ -2,7 +2,7 @@ package update
 
 import (
 	"context"
-	"database/sql"
+	"github.com/viant/sqlx"
 	"github.com/viant/sqlx/io"
 	"github.com/viant/sqlx/io/config"
 	"github.com/viant/sqlx/option"
@@ -15,9 +15,11 @@ type Service struct {
 	*config.Config
 	initSession *session
 	mux         sync.Mutex
-	db          *sql.DB
+	db          sqlx.Executor
 }
 
+// Exec runs update statements, records are grouped by updated columns defined by option.Columns, PartialUpdatable or set marker,
+// with option.BatchSize grouped records are updated with multi records statement if dialect supports it
 func (s *Service) Exec(ctx context.Context, any interface{}, options ...option.Option) (int64, error) {
 	valueAt, count, err := io.Values(any)
 	if err != nil || count == 0 {
@@ -25,51 +27,39 @@ func (s *Service) Exec(ctx context.Context, any interface{}, options ...option.O
 	}
 	record := valueAt(0)
 	var sess *session
-	if sess, err = s.ensureSession(record, options...); err != nil {
+	batchSize := option.Options(options).BatchSize()
+	if sess, err = s.ensureSession(ctx, record, batchSize, options...); err != nil {
 		return 0, err
 	}
-	if err = sess.begin(ctx, sess.db, options); err != nil {
-		return 0, err
-	}
-	var rowsAffected int64
-	dml := ""
-	for i := 0; i < count; i++ {
-		aRecord := valueAt(i)
-		changed, e := s.tryUpdate(ctx, sess, aRecord, &dml)
-		if e != nil {
-			err = e
-			break
-		}
-		rowsAffected += changed
-	}
-	err = sess.end(err)
-	return rowsAffected, err
-}
-
-func (s *Service) tryUpdate(ctx context.Context, sess *session, record interface{}, dml *string) (int64, error) {
-	ok, err := sess.prepare(ctx, record, dml)
+	sess.interceptor = sess.InterceptorFor(options, showSQL)
+	sess.tracer = sess.TracerFor(options)
+	batches, err := sess.batches(ctx, valueAt, count, options)
 	if err != nil {
 		return 0, err
 	}
-	if !ok {
-		return 0, nil
-	}
-	if updatable, ok := record.(Updatable); ok {
-		if err := updatable.OnUpdate(ctx); err != nil {
-			return 0, err
+	retry := option.Options(options).Retry()
+	for attempt := 1; ; attempt++ {
+		rowsAffected, err := sess.exec(ctx, batches, options)
+		if err == nil || !io.ShallRetry(ctx, retry, sess.Dialect, sess.Transaction, attempt, err) {
+			if err == nil && rowsAffected > 0 {
+				sess.InvalidateCache(ctx, sess.Transaction, options)
+			}
+			return rowsAffected, sess.Dialect.NormalizeError(err)
 		}
 	}
-	return sess.update(ctx, record)
 }
 
-func (s *Service) ensureSession(record interface{}, options ...option.Option) (*session, error) {
+func (s *Service) ensureSession(ctx context.Context, record interface{}, batchSize int, options ...option.Option) (*session, error) {
 	s.mux.Lock()
 	defer s.mux.Unlock()
 	rType := reflect.TypeOf(record)
-	if sess := s.initSession; sess != nil && sess.rType == rType {
-		db := option.Options(options).Db()
-		if db == nil {
-			db = sess.db
+	if !s.Dialect.Update.MultiRecords() {
+		batchSize = 1
+	}
+	if sess := s.initSession; sess != nil && sess.rType == rType && sess.batchSize == batchSize {
+		var db sqlx.Executor = sess.db
+		if optionDb := option.Options(options).Db(); optionDb != nil {
+			db = optionDb
 		}
 		return &session{
 			rType:         rType,
@@ -77,16 +67,20 @@ func (s *Service) ensureSession(record interface{}, options ...option.Option) (*
 			binder:        sess.binder,
 			columns:       sess.columns,
 			identityIndex: sess.identityIndex,
+			version:       sess.version,
 			setMarker:     sess.setMarker,
+			batchSize:     sess.batchSize,
+			builder:       sess.builder,
 			db:            db,
 		}, nil
 	}
 	result := &session{
-		rType:  rType,
-		Config: s.Config,
-		db:     s.db,
+		rType:     rType,
+		Config:    s.Config,
+		batchSize: batchSize,
+		db:        s.db,
 	}
-	err := result.init(record, options...)
+	err := result.init(ctx, record, options...)
 	if err == nil {
 		s.initSession = result
 	}
@@ -94,7 +88,7 @@ func (s *Service) ensureSession(record interface{}, options ...option.Option) (*
 }
 
 // New creates an updater
-func New(ctx context.Context, db *sql.DB, tableName string, options ...option.Option) (*Service, error) {
+func New(ctx context.Context, db sqlx.Executor, tableName string, options ...option.Option) (*Service, error) {
 	updater := &Service{
 		Config: config.New(tableName),
 		db:     db,
@@ -3,9 +3,11 @@ package update_test
 import (
 	"context"
 	"database/sql"
+	"errors"
 	_ "github.com/mattn/go-sqlite3"
 	"github.com/stretchr/testify/assert"
 	"github.com/viant/assertly"
+	"github.com/viant/sqlx/io"
 	"github.com/viant/sqlx/io/read"
 	"github.com/viant/sqlx/io/update"
 	_ "github.com/viant/sqlx/metadata/product/sqlite"
@@ -97,6 +99,68 @@ func TestService_Exec(t *testing.T) {
 			},
 			affected: 2,
 		},
+		{
+			description: "Update restricted columns",
+			driver:      "sqlite3",
+			dsn:         "/tmp/sqllite.db",
+			table:       "t3",
+			initSQL: []string{
+				"DROP TABLE IF EXISTS t3",
+				"CREATE TABLE t3 (foo_id INTEGER PRIMARY KEY, foo_name TEXT, bar DECIMAL)",
+				"INSERT INTO t3 (foo_id, foo_name, bar) VALUES(1, 'old 1', 1)",
+				"INSERT INTO t3 (foo_id, foo_name, bar) VALUES(2, 'old 2', 2)",
+			},
+			options: []option.Option{option.Columns{"foo_name"}},
+			records: []interface{}{
+				&entity{Id: 1, Name: "John1", Bar: 17},
+				&entity{Id: 2, Name: "John2", Bar: 18},
+			},
+			expect:   `[{"Id":1,"Name":"John1","Bar":1},{"Id":2,"Name":"John2","Bar":2}]`,
+			affected: 2,
+		},
+		{
+			description: "Update record updatable columns",
+			driver:      "sqlite3",
+			dsn:         "/tmp/sqllite.db",
+			table:       "t4",
+			initSQL: []string{
+				"DROP TABLE IF EXISTS t4",
+				"CREATE TABLE t4 (foo_id INTEGER PRIMARY KEY, foo_name TEXT, bar DECIMAL)",
+				"INSERT INTO t4 (foo_id, foo_name, bar) VALUES(1, 'old 1', 1)",
+				"INSERT INTO t4 (foo_id, foo_name, bar) VALUES(2, 'old 2', 2)",
+				"INSERT INTO t4 (foo_id, foo_name, bar) VALUES(3, 'old 3', 3)",
+			},
+			records: []*partialEntity{
+				{Id: 1, Name: "John1", Bar: 17, updatable: []string{"bar"}},
+				{Id: 2, Name: "John2", Bar: 18, updatable: []string{"foo_name"}},
+				{Id: 3, Name: "John3", Bar: 19, updatable: []string{"bar"}},
+			},
+			expect:   `[{"Id":1,"Name":"old 1","Bar":17},{"Id":2,"Name":"John2","Bar":2},{"Id":3,"Name":"old 3","Bar":19}]`,
+			affected: 3,
+		},
+		{
+			description: "Update with batch size: 2",
+			driver:      "sqlite3",
+			dsn:         "/tmp/sqllite.db",
+			table:       "t5",
+			initSQL: []string{
+				"DROP TABLE IF EXISTS t5",
+				"CREATE TABLE t5 (foo_id INTEGER PRIMARY KEY, foo_name TEXT, bar DECIMAL)",
+				"INSERT INTO t5 (foo_id, foo_name, bar) VALUES(1, 'old 1', 1)",
+				"INSERT INTO t5 (foo_id, foo_name, bar) VALUES(2, 'old 2', 2)",
+				"INSERT INTO t5 (foo_id, foo_name, bar) VALUES(3, 'old 3', 3)",
+				"INSERT INTO t5 (foo_id, foo_name, bar) VALUES(4, 'old 4', 4)",
+			},
+			options: []option.Option{option.BatchSize(2)},
+			records: []*entity{
+				{Id: 1, Name: "John1", Bar: 17},
+				{Id: 2, Name: "John2", Bar: 18},
+				{Id: 3, Name: "John3", Bar: 19},
+				{Id: 5, Name: "John5", Bar: 21},
+			},
+			expect:   `[{"Id":1,"Name":"John1","Bar":17},{"Id":2,"Name":"John2","Bar":18},{"Id":3,"Name":"John3","Bar":19},{"Id":4,"Name":"old 4","Bar":4}]`,
+			affected: 3,
+		},
 	}
 
 outer:
@@ -124,11 +188,199 @@ outer:
 		if !assert.Nil(t, err, testCase.description) {
 			continue
 		}
-		affected, err := updater.Exec(context.TODO(), testCase.records)
+		affected, err := updater.Exec(context.TODO(), testCase.records, testCase.options...)
 		assert.Nil(t, err, testCase.description)
 		assert.EqualValues(t, testCase.affected, affected, testCase.description)
+		if testCase.expect == nil {
+			continue
+		}
+		reader, err := read.New(context.TODO(), db, "SELECT foo_id, foo_name, bar FROM "+testCase.table+" ORDER BY foo_id", func() interface{} { return &entity{} })
+		if !assert.Nil(t, err, testCase.description) {
+			continue
+		}
+		var actual []*entity
+		err = reader.QueryAll(context.TODO(), func(row interface{}) error {
+			actual = append(actual, row.(*entity))
+			return nil
+		})
+		assert.Nil(t, err, testCase.description)
+		assertly.AssertValues(t, testCase.expect, actual, testCase.description)
+	}
+
+}
+
+func TestService_Exec_compositeKey(t *testing.T) {
+	type entity struct {
+		TenantId int    `sqlx:"name=tenant_id,primaryKey=true"`
+		Id       int    `sqlx:"name=foo_id,primaryKey=true"`
+		Name     string `sqlx:"foo_name"`
+	}
+	var testCases = []struct {
+		description string
+		options     []option.Option
+		records     []*entity
+		expect      interface{}
+		affected    int64
+	}{
+		{
+			description: "Update with composite key",
+			records: []*entity{
+				{TenantId: 1, Id: 1, Name: "John1"},
+				{TenantId: 2, Id: 1, Name: "John3"},
+			},
+			expect:   `[{"TenantId":1,"Id":1,"Name":"John1"},{"TenantId":1,"Id":2,"Name":"old 2"},{"TenantId":2,"Id":1,"Name":"John3"},{"TenantId":2,"Id":2,"Name":"old 4"}]`,
+			affected: 2,
+		},
+		{
+			description: "Update with composite key and batch size: 2",
+			options:     []option.Option{option.BatchSize(2)},
+			records: []*entity{
+				{TenantId: 1, Id: 2, Name: "John2"},
+				{TenantId: 2, Id: 1, Name: "John3"},
+				{TenantId: 2, Id: 2, Name: "John4"},
+				{TenantId: 3, Id: 2, Name: "John5"},
+			},
+			expect:   `[{"TenantId":1,"Id":1,"Name":"old 1"},{"TenantId":1,"Id":2,"Name":"John2"},{"TenantId":2,"Id":1,"Name":"John3"},{"TenantId":2,"Id":2,"Name":"John4"}]`,
+			affected: 3,
+		},
+	}
+
+	initSQL := []string{
+		"DROP TABLE IF EXISTS t6",
+		"CREATE TABLE t6 (tenant_id INTEGER, foo_id INTEGER, foo_name TEXT, PRIMARY KEY(tenant_id, foo_id))",
+		"INSERT INTO t6 (tenant_id, foo_id, foo_name) VALUES(1, 1, 'old 1')",
+		"INSERT INTO t6 (tenant_id, foo_id, foo_name) VALUES(1, 2, 'old 2')",
+		"INSERT INTO t6 (tenant_id, foo_id, foo_name) VALUES(2, 1, 'old 3')",
+		"INSERT INTO t6 (tenant_id, foo_id, foo_name) VALUES(2, 2, 'old 4')",
 	}
 
+outer:
+	for _, testCase := range testCases {
+		db, err := sql.Open("sqlite3", "/tmp/sqllite.db")
+		if !assert.Nil(t, err, testCase.description) {
+			continue
+		}
+		for _, SQL := range initSQL {
+			if _, err := db.Exec(SQL); !assert.Nil(t, err, testCase.description) {
+				continue outer
+			}
+		}
+		updater, err := update.New(context.TODO(), db, "t6", testCase.options...)
+		if !assert.Nil(t, err, testCase.description) {
+			continue
+		}
+		affected, err := updater.Exec(context.TODO(), testCase.records, testCase.options...)
+		assert.Nil(t, err, testCase.description)
+		assert.EqualValues(t, testCase.affected, affected, testCase.description)
+		reader, err := read.New(context.TODO(), db, "SELECT tenant_id, foo_id, foo_name FROM t6 ORDER BY tenant_id, foo_id", func() interface{} { return &entity{} })
+		if !assert.Nil(t, err, testCase.description) {
+			continue
+		}
+		var actual []*entity
+		err = reader.QueryAll(context.TODO(), func(row interface{}) error {
+			actual = append(actual, row.(*entity))
+			return nil
+		})
+		assert.Nil(t, err, testCase.description)
+		assertly.AssertValues(t, testCase.expect, actual, testCase.description)
+	}
+}
+
+func TestService_Exec_version(t *testing.T) {
+	type entity struct {
+		Id      int    `sqlx:"name=foo_id,primaryKey=true"`
+		Name    string `sqlx:"foo_name"`
+		Version int    `sqlx:"name=version,version"`
+	}
+	var testCases = []struct {
+		description   string
+		records       []*entity
+		expect        interface{}
+		expectVersion []int
+		expectStale   [][]interface{}
+		affected      int64
+	}{
+		{
+			description: "Update with version",
+			records: []*entity{
+				{Id: 1, Name: "John1", Version: 1},
+				{Id: 2, Name: "John2", Version: 3},
+			},
+			expect:        `[{"Id":1,"Name":"John1","Version":2},{"Id":2,"Name":"John2","Version":4}]`,
+			expectVersion: []int{2, 4},
+			affected:      2,
+		},
+		{
+			description: "Update with stale record",
+			records: []*entity{
+				{Id: 1, Name: "John1", Version: 1},
+				{Id: 2, Name: "John2", Version: 2},
+			},
+			expect:        `[{"Id":1,"Name":"old 1","Version":1},{"Id":2,"Name":"old 2","Version":3}]`,
+			expectVersion: []int{1, 2},
+			expectStale:   [][]interface{}{{2}},
+			affected:      0,
+		},
+	}
+
+	initSQL := []string{
+		"DROP TABLE IF EXISTS t7",
+		"CREATE TABLE t7 (foo_id INTEGER PRIMARY KEY, foo_name TEXT, version INTEGER)",
+		"INSERT INTO t7 (foo_id, foo_name, version) VALUES(1, 'old 1', 1)",
+		"INSERT INTO t7 (foo_id, foo_name, version) VALUES(2, 'old 2', 3)",
+	}
+
+outer:
+	for _, testCase := range testCases {
+		db, err := sql.Open("sqlite3", "/tmp/sqllite.db")
+		if !assert.Nil(t, err, testCase.description) {
+			continue
+		}
+		for _, SQL := range initSQL {
+			if _, err := db.Exec(SQL); !assert.Nil(t, err, testCase.description) {
+				continue outer
+			}
+		}
+		updater, err := update.New(context.TODO(), db, "t7")
+		if !assert.Nil(t, err, testCase.description) {
+			continue
+		}
+		affected, err := updater.Exec(context.TODO(), testCase.records)
+		assert.EqualValues(t, testCase.affected, affected, testCase.description)
+		if len(testCase.expectStale) > 0 {
+			staleErr := &io.StaleRecordError{}
+			if assert.True(t, errors.As(err, &staleErr), testCase.description) {
+				assert.EqualValues(t, testCase.expectStale, staleErr.Identities, testCase.description)
+			}
+		} else {
+			assert.Nil(t, err, testCase.description)
+		}
+		for i, record := range testCase.records {
+			assert.EqualValues(t, testCase.expectVersion[i], record.Version, testCase.description)
+		}
+		reader, err := read.New(context.TODO(), db, "SELECT foo_id, foo_name, version FROM t7 ORDER BY foo_id", func() interface{} { return &entity{} })
+		if !assert.Nil(t, err, testCase.description) {
+			continue
+		}
+		var actual []*entity
+		err = reader.QueryAll(context.TODO(), func(row interface{}) error {
+			actual = append(actual, row.(*entity))
+			return nil
+		})
+		assert.Nil(t, err, testCase.description)
+		assertly.AssertValues(t, testCase.expect, actual, testCase.description)
+	}
+}
+
+type partialEntity struct {
+	Id        int    `sqlx:"name=foo_id,primaryKey=true"`
+	Name      string `sqlx:"foo_name"`
+	Bar       float64
+	updatable []string
+}
+
+func (e *partialEntity) UpdatableColumns() []string {
+	return e.updatable
 }
 
 func TestService_Exec_encodingJSON(t *testing.T) {
@@ -4,10 +4,16 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
+	"github.com/viant/sqlx"
 	"github.com/viant/sqlx/io"
 	"github.com/viant/sqlx/io/config"
+	"github.com/viant/sqlx/io/telemetry"
+	"github.com/viant/sqlx/metadata/info/dialect"
+	"github.com/viant/sqlx/metadata/sink"
 	"github.com/viant/sqlx/option"
+	"github.com/viant/xunsafe"
 	"reflect"
+	"strings"
 )
 
 type session struct {
@@ -18,24 +24,47 @@ type session struct {
 	binder        io.PlaceholderBinder
 	columns       io.Columns
 	identityIndex int
-	db            *sql.DB
-	stmt          *sql.Stmt
+	version       *io.Version
+	versions      []*versionUpdate
+	batchSize     int
+	builder       *Builder
+	db            sqlx.Executor
+	stmts         map[string]*sql.Stmt
+	interceptor   sqlx.Interceptor
+	tracer        option.Tracer
 }
 
-func (s *session) init(record interface{}, options ...option.Option) (err error) {
+//versionUpdate represents record version refreshed once update succeeded
+type versionUpdate struct {
+	fieldAddr interface{}
+	next      interface{}
+}
+
+//batch represents records sharing the same update statement
+type batch struct {
+	SQL       string
+	positions []int
+	records   []interface{}
+}
+
+func (s *session) init(ctx context.Context, record interface{}, options ...option.Option) (err error) {
+	var mapperOptions []option.Option
 	for _, anOption := range options {
 		switch actual := anOption.(type) {
 		case *option.SetMarker:
 			s.setMarker = actual
+		case option.Columns: //columns are restricted per statement, all columns have to be mapped
+			continue
 		}
+		mapperOptions = append(mapperOptions, anOption)
 	}
 
 	if s.setMarker == nil {
 		s.setMarker = &option.SetMarker{}
-		options = append(options, s.setMarker)
+		mapperOptions = append(mapperOptions, s.setMarker)
 	}
 
-	if s.columns, s.binder, err = s.Mapper(record, s.TagName, options...); err != nil {
+	if s.columns, s.binder, err = s.Mapper(record, s.TagName, mapperOptions...); err != nil {
 		return err
 	}
 
@@ -43,11 +72,45 @@ func (s *session) init(record interface{}, options ...option.Option) (err error)
 		s.identityIndex = identityIndex
 	}
 
-	s.Builder, err = NewBuilder(s.TableName, s.columns.Names(), s.identityIndex, s.Dialect)
+	if s.builder, err = NewBuilder(s.TableName, s.columns.Names(), s.identityIndex, s.Dialect); err != nil {
+		return err
+	}
+	s.version = s.columns.Version()
+	s.builder.version = s.version
+	s.Builder = s.builder
+	if s.batchSize > 1 && s.Dialect.Update == dialect.UpdateTypeValuesJoin {
+		s.builder.types, err = s.columnTypes(ctx)
+	}
 	return err
 }
 
-func (s *session) begin(ctx context.Context, db *sql.DB, options []option.Option) error {
+//columnTypes returns table column types, used to define VALUES list types
+func (s *session) columnTypes(ctx context.Context) ([]string, error) {
+	metaSession, err := config.Session(ctx, s.db, s.Dialect)
+	if err != nil {
+		return nil, err
+	}
+	tableColumns, err := config.Columns(ctx, metaSession, s.db, s.TableName, s.Dialect)
+	if err != nil {
+		return nil, fmt.Errorf("failed to lookup %v column types: %w", s.TableName, err)
+	}
+	byName := sink.Columns(tableColumns).By(func(c *sink.Column) string { return strings.ToLower(c.Name) })
+	var result = make([]string, len(s.columns))
+	for i, column := range s.columns {
+		tableColumn, ok := byName[strings.ToLower(column.Name())]
+		if !ok {
+			continue
+		}
+		switch dataType := strings.ToUpper(tableColumn.Type); dataType {
+		case "ARRAY", "USER-DEFINED":
+		default:
+			result[i] = dataType
+		}
+	}
+	return result, nil
+}
+
+func (s *session) begin(ctx context.Context, db sqlx.Executor, options []option.Option) error {
 	var err error
 	s.Transaction, err = io.TransactionFor(ctx, s.Dialect, db, options)
 	if err != nil {
@@ -57,55 +120,255 @@ func (s *session) begin(ctx context.Context, db *sql.DB, options []option.Option
 	return nil
 }
 
-func (s *session) prepare(ctx context.Context, record interface{}, dml *string) (bool, error) {
-	SQL := s.Builder.Build(record, s.setMarker)
-	if SQL == "" {
-		return false, nil
+//batches groups records by update statement, records without changes are skipped
+func (s *session) batches(ctx context.Context, valueAt io.ValueAccessor, count int, options []option.Option) ([]*batch, error) {
+	columns := option.Options(options).Columns()
+	var result []*batch
+	var index = map[string]*batch{}
+	for i := 0; i < count; i++ {
+		record := valueAt(i)
+		recordColumns := columns
+		if partial, ok := record.(PartialUpdatable); ok {
+			if updatable := partial.UpdatableColumns(); len(updatable) > 0 {
+				recordColumns = updatable
+			}
+		}
+		SQL := s.Builder.Build(record, s.setMarker, recordColumns)
+		if SQL == "" {
+			continue
+		}
+		if updatable, ok := record.(Updatable); ok {
+			if err := updatable.OnUpdate(ctx); err != nil {
+				return nil, err
+			}
+		}
+		aBatch, ok := index[SQL]
+		if !ok {
+			aBatch = &batch{SQL: SQL, positions: s.positions(record, recordColumns.Restriction())}
+			index[SQL] = aBatch
+			result = append(result, aBatch)
+		}
+		aBatch.records = append(aBatch.records, record)
 	}
-	if *dml == SQL {
-		return true, nil
+	return result, nil
+}
+
+//exec runs batches update within session transaction, records versions are refreshed once transaction succeeded
+func (s *session) exec(ctx context.Context, batches []*batch, options []option.Option) (int64, error) {
+	if err := s.begin(ctx, s.db, options); err != nil {
+		return 0, err
 	}
-	*dml = SQL
+	s.versions = nil
+	var rowsAffected int64
 	var err error
-	if s.stmt != nil {
-		if err = s.stmt.Close(); err != nil {
-			return false, fmt.Errorf("failed to close stetement: %w", err)
+	for _, aBatch := range batches {
+		affected, e := s.update(ctx, aBatch)
+		if e != nil {
+			err = e
+			break
 		}
+		rowsAffected += affected
+	}
+	if err = s.end(err); err == nil {
+		s.refreshVersions()
+	}
+	return rowsAffected, err
+}
+
+//prepare returns cached statement for supplied SQL
+func (s *session) prepare(ctx context.Context, SQL string) (*sql.Stmt, error) {
+	if stmt, ok := s.stmts[SQL]; ok {
+		return stmt, nil
 	}
-	if showSQL {
-		fmt.Println(SQL)
+	if s.stmts == nil {
+		s.stmts = map[string]*sql.Stmt{}
 	}
+	var stmt *sql.Stmt
+	var err error
 	if s.Transaction != nil {
-		s.stmt, err = s.Transaction.Prepare(SQL)
-		return err == nil, err
+		stmt, err = s.Transaction.PrepareContext(ctx, SQL)
+	} else {
+		stmt, err = s.db.PrepareContext(ctx, SQL)
+	}
+	if err != nil {
+		return nil, err
 	}
-	s.stmt, err = s.db.PrepareContext(ctx, SQL)
-	return err == nil, err
+	s.stmts[SQL] = stmt
+	return stmt, nil
 }
 
-func (s *session) update(ctx context.Context, record interface{}) (int64, error) {
+//execStmt executes prepared statement with session interceptor
+func (s *session) execStmt(ctx context.Context, stmt *sql.Stmt, SQL string, args []interface{}) (sql.Result, error) {
+	return sqlx.InterceptExec(ctx, s.interceptor, SQL, args, func(ctx context.Context) (sql.Result, error) {
+		return stmt.ExecContext(ctx, args...)
+	})
+}
 
-	var placeholders = make([]interface{}, len(s.columns))
-	s.binder(record, placeholders, 0, len(s.columns))
+//update updates batch records within telemetry span
+func (s *session) update(ctx context.Context, aBatch *batch) (rowsAffected int64, err error) {
+	ctx, span := telemetry.Start(ctx, s.tracer, "sqlx.update.flush", telemetry.Table.String(s.TableName), telemetry.BatchSize.Int(len(aBatch.records)))
+	defer func() { telemetry.End(span, err, telemetry.RowsAffected.Int64(rowsAffected)) }()
+	return s.updateRecords(ctx, aBatch)
+}
 
-	placeholders = s.setMarker.Placeholders(record, placeholders)
-	result, err := s.stmt.ExecContext(ctx, placeholders...)
+func (s *session) updateRecords(ctx context.Context, aBatch *batch) (int64, error) {
+	if s.batchSize > 1 && len(aBatch.records) > 1 && s.builder.Batchable() {
+		return s.updateBatch(ctx, aBatch)
+	}
+	stmt, err := s.prepare(ctx, aBatch.SQL)
 	if err != nil {
 		return 0, err
 	}
-	affected, _ := result.RowsAffected()
-	return affected, nil
+	var rowsAffected int64
+	var stale *io.StaleRecordError
+	for _, record := range aBatch.records {
+		values := s.values(record)
+		var next interface{}
+		if s.version != nil {
+			next = s.version.Next(values[s.version.Position])
+		}
+		result, err := s.execStmt(ctx, stmt, aBatch.SQL, s.placeholders(values, aBatch.positions, next))
+		if err != nil {
+			return 0, err
+		}
+		affected, _ := result.RowsAffected()
+		rowsAffected += affected
+		if s.version == nil {
+			continue
+		}
+		if affected == 0 {
+			if stale == nil {
+				stale = &io.StaleRecordError{Table: s.TableName}
+			}
+			stale.Append(values[s.identityIndex:])
+			continue
+		}
+		s.versions = append(s.versions, &versionUpdate{fieldAddr: values[s.version.Position], next: next})
+	}
+	if stale != nil {
+		return 0, stale
+	}
+	return rowsAffected, nil
+}
+
+//refreshVersions sets updated records version
+func (s *session) refreshVersions() {
+	for _, update := range s.versions {
+		s.version.Set(update.fieldAddr, update.next)
+	}
+	s.versions = nil
+}
+
+//updateBatch runs one multi records update statement per batch size records
+func (s *session) updateBatch(ctx context.Context, aBatch *batch) (int64, error) {
+	var rowsAffected int64
+	for offset := 0; offset < len(aBatch.records); offset += s.batchSize {
+		limit := offset + s.batchSize
+		if limit > len(aBatch.records) {
+			limit = len(aBatch.records)
+		}
+		records := aBatch.records[offset:limit]
+		SQL := s.builder.BuildBatch(aBatch.positions, len(records))
+		stmt, err := s.prepare(ctx, SQL)
+		if err != nil {
+			return 0, err
+		}
+		result, err := s.execStmt(ctx, stmt, SQL, s.batchPlaceholders(records, aBatch.positions))
+		if err != nil {
+			return 0, err
+		}
+		affected, _ := result.RowsAffected()
+		rowsAffected += affected
+	}
+	return rowsAffected, nil
+}
+
+//positions returns updated column positions for supplied record
+func (s *session) positions(record interface{}, restriction option.ColumnRestriction) []int {
+	ptr := xunsafe.AsPointer(record)
+	presenceAware := s.setMarker.Marker != nil
+	var result = make([]int, 0, s.identityIndex)
+	for i := 0; i < s.identityIndex; i++ {
+		if s.version != nil && i == s.version.Position {
+			continue
+		}
+		if presenceAware && !s.setMarker.IsSet(ptr, i) {
+			continue
+		}
+		if !restriction.CanUse(s.columns[i].Name()) {
+			continue
+		}
+		result = append(result, i)
+	}
+	return result
+}
+
+//values returns record column values
+func (s *session) values(record interface{}) []interface{} {
+	var values = make([]interface{}, len(s.columns))
+	s.binder(record, values, 0, len(s.columns))
+	return values
+}
+
+//placeholders returns values of updated columns followed by identity values, versioned record adds next (timestamp) and current version
+func (s *session) placeholders(values []interface{}, positions []int, next interface{}) []interface{} {
+	if len(positions) == s.identityIndex {
+		return values
+	}
+	var result = make([]interface{}, 0, len(positions)+len(values)-s.identityIndex+2)
+	for _, position := range positions {
+		result = append(result, values[position])
+	}
+	if s.version != nil && s.version.IsTime {
+		result = append(result, next)
+	}
+	result = append(result, values[s.identityIndex:]...)
+	if s.version != nil {
+		result = append(result, values[s.version.Position])
+	}
+	return result
+}
+
+//batchPlaceholders returns multi records update statement values
+func (s *session) batchPlaceholders(records []interface{}, positions []int) []interface{} {
+	var values = make([][]interface{}, len(records))
+	for i, record := range records {
+		values[i] = make([]interface{}, len(s.columns))
+		s.binder(record, values[i], 0, len(s.columns))
+	}
+	keySize := len(s.columns) - s.identityIndex
+	var result = make([]interface{}, 0, len(records)*((keySize+1)*len(positions)+keySize))
+	if s.Dialect.Update == dialect.UpdateTypeValuesJoin {
+		for _, recordValues := range values {
+			for _, position := range positions {
+				result = append(result, recordValues[position])
+			}
+			result = append(result, recordValues[s.identityIndex:]...)
+		}
+		return result
+	}
+	for _, position := range positions {
+		for _, recordValues := range values {
+			result = append(result, recordValues[s.identityIndex:]...)
+			result = append(result, recordValues[position])
+		}
+	}
+	for _, recordValues := range values {
+		result = append(result, recordValues[s.identityIndex:]...)
+	}
+	return result
 }
 
 func (s *session) end(err error) error {
-	if s.stmt != nil {
-		if sErr := s.stmt.Close(); sErr != nil {
+	for SQL, stmt := range s.stmts {
+		if sErr := stmt.Close(); sErr != nil {
 			err = fmt.Errorf("%w, %v", sErr, err)
 		}
+		delete(s.stmts, SQL)
 	}
 
 	if s.Transaction == nil {
-		return nil
+		return err
 	}
 
 	if err != nil {
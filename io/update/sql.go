@@ -3,49 +3,259 @@ package update
 import (
 	"bytes"
 	"fmt"
+	"github.com/viant/sqlx/io"
 	"github.com/viant/sqlx/metadata/info"
+	"github.com/viant/sqlx/metadata/info/dialect"
 	"github.com/viant/sqlx/option"
 	"github.com/viant/xunsafe"
-	"strings"
 )
 
 const (
 	columnSeparator = ", "
+	targetAlias     = "dst"
+	sourceAlias     = "src"
 )
 
 // Builder represent update DML builder
 type Builder struct {
 	id                  string
+	table               string
+	columns             []string
+	types               []string
 	identityIndex       int
-	fragments           []string
+	version             *io.Version
+	dialect             *info.Dialect
 	sqlPrefix           string
-	sqlSuffix           string
 	estimatedBufferSize int
 }
 
-// Build builds update statement
+// Build builds update statement, updated columns can be restricted with option.SetMarker or option.Columns,
+// version column is always updated and checked in WHERE clause
 func (b *Builder) Build(record interface{}, options ...option.Option) string {
 	presenceProvider := option.Options(options).SetMarker()
+	restriction := option.Options(options).Columns().Restriction()
 	buffer := bytes.Buffer{}
 	buffer.Grow(b.estimatedBufferSize)
 	ptr := xunsafe.AsPointer(record)
 	buffer.WriteString(b.sqlPrefix)
+	getter := b.dialect.PlaceholderGetter()
 	hasCount := 0
 	presenceAware := presenceProvider != nil && presenceProvider.Marker != nil
 	for i := 0; i < b.identityIndex; i++ {
+		if b.version != nil && i == b.version.Position {
+			continue
+		}
 		if presenceAware && !presenceProvider.IsSet(ptr, i) {
 			continue
 		}
+		if !restriction.CanUse(b.columns[i]) {
+			continue
+		}
 		if hasCount > 0 {
 			buffer.WriteString(columnSeparator)
 		}
-		buffer.WriteString(b.fragments[i])
+		buffer.WriteString(b.columns[i])
+		buffer.WriteString(" = ")
+		buffer.WriteString(getter())
 		hasCount++
 	}
-	if presenceAware && hasCount == 0 { //record has no changes no point to run update
+	if hasCount == 0 && (presenceAware || len(restriction) > 0) { //record has no changes no point to run update
 		return ""
 	}
-	buffer.WriteString(b.sqlSuffix)
+	if b.version != nil {
+		if hasCount > 0 {
+			buffer.WriteString(columnSeparator)
+		}
+		buffer.WriteString(b.version.Name)
+		buffer.WriteString(" = ")
+		if b.version.IsTime {
+			buffer.WriteString(getter())
+		} else {
+			buffer.WriteString(b.version.Name)
+			buffer.WriteString(" + 1")
+		}
+	}
+	buffer.WriteString(" WHERE ")
+	for i := b.identityIndex; i < len(b.columns); i++ {
+		if i > b.identityIndex {
+			buffer.WriteString(" AND ")
+		}
+		buffer.WriteString(b.columns[i])
+		buffer.WriteString(" = ")
+		buffer.WriteString(getter())
+	}
+	if b.version != nil {
+		buffer.WriteString(" AND ")
+		buffer.WriteString(b.version.Name)
+		buffer.WriteString(" = ")
+		buffer.WriteString(getter())
+	}
+	return buffer.String()
+}
+
+// Batchable returns true if multi records update statement can be built,
+// versioned records are updated individually to detect stale records
+func (b *Builder) Batchable() bool {
+	return b.dialect.Update.MultiRecords() && b.version == nil
+}
+
+// BuildBatch builds multi records update statement for supplied updated column positions
+func (b *Builder) BuildBatch(positions []int, batchSize int) string {
+	if b.dialect.Update == dialect.UpdateTypeValuesJoin {
+		return b.valuesJoin(positions, batchSize)
+	}
+	return b.caseBatch(positions, batchSize)
+}
+
+//caseBatch builds UPDATE t SET c = CASE id WHEN ? THEN ? END WHERE id IN (?) statement,
+//composite key uses CASE WHEN k1 = ? AND k2 = ? THEN ? END form
+func (b *Builder) caseBatch(positions []int, batchSize int) string {
+	ids := b.columns[b.identityIndex:]
+	getter := b.dialect.PlaceholderGetter()
+	buffer := bytes.Buffer{}
+	buffer.Grow(b.estimatedBufferSize * batchSize)
+	buffer.WriteString(b.sqlPrefix)
+	for i, position := range positions {
+		if i > 0 {
+			buffer.WriteString(columnSeparator)
+		}
+		buffer.WriteString(b.columns[position])
+		buffer.WriteString(" = CASE")
+		if len(ids) == 1 {
+			buffer.WriteString(" ")
+			buffer.WriteString(ids[0])
+		}
+		for j := 0; j < batchSize; j++ {
+			buffer.WriteString(" WHEN ")
+			if len(ids) == 1 {
+				buffer.WriteString(getter())
+			} else {
+				b.writeKeyCriterion(&buffer, ids, getter)
+			}
+			buffer.WriteString(" THEN ")
+			buffer.WriteString(getter())
+		}
+		buffer.WriteString(" END")
+	}
+	buffer.WriteString(" WHERE ")
+	if len(ids) > 1 && b.dialect.NoRowValueIn {
+		for j := 0; j < batchSize; j++ {
+			if j > 0 {
+				buffer.WriteString(" OR ")
+			}
+			buffer.WriteString("(")
+			b.writeKeyCriterion(&buffer, ids, getter)
+			buffer.WriteString(")")
+		}
+		return buffer.String()
+	}
+	b.writeKeyList(&buffer, ids)
+	buffer.WriteString(" IN (")
+	for j := 0; j < batchSize; j++ {
+		if j > 0 {
+			buffer.WriteString(columnSeparator)
+		}
+		if len(ids) == 1 {
+			buffer.WriteString(getter())
+			continue
+		}
+		buffer.WriteString("(")
+		for k := range ids {
+			if k > 0 {
+				buffer.WriteString(columnSeparator)
+			}
+			buffer.WriteString(getter())
+		}
+		buffer.WriteString(")")
+	}
+	buffer.WriteString(")")
+	return buffer.String()
+}
+
+//writeKeyCriterion writes k1 = ? AND k2 = ? criterion
+func (b *Builder) writeKeyCriterion(buffer *bytes.Buffer, ids []string, getter func() string) {
+	for k, id := range ids {
+		if k > 0 {
+			buffer.WriteString(" AND ")
+		}
+		buffer.WriteString(id)
+		buffer.WriteString(" = ")
+		buffer.WriteString(getter())
+	}
+}
+
+//writeKeyList writes id or (k1, k2) key list
+func (b *Builder) writeKeyList(buffer *bytes.Buffer, ids []string) {
+	if len(ids) == 1 {
+		buffer.WriteString(ids[0])
+		return
+	}
+	buffer.WriteString("(")
+	for k, id := range ids {
+		if k > 0 {
+			buffer.WriteString(columnSeparator)
+		}
+		buffer.WriteString(id)
+	}
+	buffer.WriteString(")")
+}
+
+//valuesJoin builds UPDATE t dst SET c = src.c FROM (VALUES (?, ?)) AS src(c, id) WHERE dst.id = src.id statement, composite key joins on all key columns
+func (b *Builder) valuesJoin(positions []int, batchSize int) string {
+	updated := len(positions)
+	positions = positions[:updated:updated]
+	for i := b.identityIndex; i < len(b.columns); i++ {
+		positions = append(positions, i)
+	}
+	getter := b.dialect.PlaceholderGetter()
+	buffer := bytes.Buffer{}
+	buffer.Grow(b.estimatedBufferSize * batchSize)
+	buffer.WriteString("UPDATE ")
+	buffer.WriteString(b.table)
+	buffer.WriteString(" " + targetAlias + " SET ")
+	for i, position := range positions[:updated] {
+		if i > 0 {
+			buffer.WriteString(columnSeparator)
+		}
+		buffer.WriteString(b.columns[position])
+		buffer.WriteString(" = " + sourceAlias + ".")
+		buffer.WriteString(b.columns[position])
+	}
+	buffer.WriteString(" FROM (VALUES ")
+	for j := 0; j < batchSize; j++ {
+		if j > 0 {
+			buffer.WriteString(columnSeparator)
+		}
+		buffer.WriteString("(")
+		for i, position := range positions {
+			if i > 0 {
+				buffer.WriteString(columnSeparator)
+			}
+			buffer.WriteString(getter())
+			if j == 0 && position < len(b.types) && b.types[position] != "" { //first row defines values types
+				buffer.WriteString("::")
+				buffer.WriteString(b.types[position])
+			}
+		}
+		buffer.WriteString(")")
+	}
+	buffer.WriteString(") AS " + sourceAlias + "(")
+	for i, position := range positions {
+		if i > 0 {
+			buffer.WriteString(columnSeparator)
+		}
+		buffer.WriteString(b.columns[position])
+	}
+	buffer.WriteString(") WHERE ")
+	for i, id := range b.columns[b.identityIndex:] {
+		if i > 0 {
+			buffer.WriteString(" AND ")
+		}
+		buffer.WriteString(targetAlias + ".")
+		buffer.WriteString(id)
+		buffer.WriteString(" = " + sourceAlias + ".")
+		buffer.WriteString(id)
+	}
 	return buffer.String()
 }
 
@@ -57,26 +267,26 @@ func NewBuilder(table string, columns []string, identityIndex int, dialect *info
 	if identityIndex <= 0 {
 		return nil, fmt.Errorf("identity index was empty")
 	}
-	var fragments = make([]string, len(columns))
-	getter := dialect.PlaceholderGetter()
 	fragmentSize := 0
-	for i, name := range columns {
-		fragments[i] = name + " = " + getter()
-		fragmentSize += len(fragments[i])
+	for _, name := range columns {
+		fragmentSize += len(name) + 5
 	}
-	criteria := strings.Join(fragments[identityIndex:], " AND ")
 	result := &Builder{
 		sqlPrefix:     "UPDATE " + table + " SET ",
-		sqlSuffix:     " WHERE " + criteria,
+		table:         table,
+		columns:       columns,
 		identityIndex: identityIndex,
-		fragments:     fragments,
+		dialect:       dialect,
 	}
-	result.estimatedBufferSize = len(result.sqlPrefix) + len(result.sqlSuffix) + fragmentSize + (3 * len(fragments))
+	result.estimatedBufferSize = len(result.sqlPrefix) + len(" WHERE ") + fragmentSize + (5 * len(columns))
 	return result, nil
 }
 
 var showSQL bool
 
+//ShowSQL prints executed SQL to stdout
+//
+//Deprecated: use sqlx.SQLPrinter or any other sqlx.Interceptor option
 func ShowSQL(b bool) {
 	showSQL = b
 }
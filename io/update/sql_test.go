@@ -2,7 +2,11 @@ package update
 
 import (
 	"github.com/stretchr/testify/assert"
+	"github.com/viant/sqlx/io"
 	"github.com/viant/sqlx/metadata/info"
+	"github.com/viant/sqlx/metadata/info/dialect"
+	"github.com/viant/sqlx/metadata/product/pg"
+	"github.com/viant/sqlx/option"
 	"testing"
 )
 
@@ -14,6 +18,8 @@ func TestUpdate_Build(t *testing.T) {
 		columns       []string
 		dialect       *info.Dialect
 		pkColumnIndex int
+		version       *io.Version
+		options       []option.Option
 		expect        string
 	}{
 		{
@@ -26,13 +32,170 @@ func TestUpdate_Build(t *testing.T) {
 			pkColumnIndex: 2,
 			expect:        "UPDATE foo SET c1 = ?, cN = ? WHERE cId = ?",
 		},
+		{
+			description: "updated with restricted columns",
+			table:       "foo",
+			columns:     []string{"c1", "c2", "cN", "cId"},
+			dialect: &info.Dialect{
+				Placeholder:         "$",
+				PlaceholderResolver: &pg.PlaceholderGenerator{},
+			},
+			pkColumnIndex: 3,
+			options:       []option.Option{option.Columns{"c2", "cN"}},
+			expect:        "UPDATE foo SET c2 = $1, cN = $2 WHERE cId = $3",
+		},
+		{
+			description: "updated without matching columns",
+			table:       "foo",
+			columns:     []string{"c1", "cId"},
+			dialect: &info.Dialect{
+				Placeholder: "?",
+			},
+			pkColumnIndex: 1,
+			options:       []option.Option{option.Columns{"cX"}},
+			expect:        "",
+		},
+		{
+			description: "updated with version",
+			table:       "foo",
+			columns:     []string{"c1", "version", "cId"},
+			dialect: &info.Dialect{
+				Placeholder: "?",
+			},
+			pkColumnIndex: 2,
+			version:       &io.Version{Name: "version", Position: 1},
+			expect:        "UPDATE foo SET c1 = ?, version = version + 1 WHERE cId = ? AND version = ?",
+		},
+		{
+			description: "updated with timestamp version",
+			table:       "foo",
+			columns:     []string{"c1", "updated", "cId"},
+			dialect: &info.Dialect{
+				Placeholder:         "$",
+				PlaceholderResolver: &pg.PlaceholderGenerator{},
+			},
+			pkColumnIndex: 2,
+			version:       &io.Version{Name: "updated", Position: 1, IsTime: true},
+			expect:        "UPDATE foo SET c1 = $1, updated = $2 WHERE cId = $3 AND updated = $4",
+		},
 	}
 
 	for _, testCase := range testCases {
 		builder, err := NewBuilder(testCase.table, testCase.columns, testCase.pkColumnIndex, testCase.dialect)
 		assert.Nil(t, err, testCase.description)
-		actual := builder.Build(nil)
+		builder.version = testCase.version
+		actual := builder.Build(nil, testCase.options...)
 		assert.EqualValues(t, testCase.expect, actual, testCase.description)
 	}
 
 }
+
+func TestUpdate_BuildBatch(t *testing.T) {
+
+	var testCases = []struct {
+		description   string
+		table         string
+		columns       []string
+		types         []string
+		dialect       *info.Dialect
+		pkColumnIndex int
+		positions     []int
+		batchSize     int
+		expect        string
+	}{
+		{
+			description: "case batch",
+			table:       "foo",
+			columns:     []string{"c1", "c2", "id"},
+			dialect: &info.Dialect{
+				Placeholder: "?",
+				Update:      dialect.UpdateTypeCase,
+			},
+			pkColumnIndex: 2,
+			positions:     []int{0, 1},
+			batchSize:     2,
+			expect:        "UPDATE foo SET c1 = CASE id WHEN ? THEN ? WHEN ? THEN ? END, c2 = CASE id WHEN ? THEN ? WHEN ? THEN ? END WHERE id IN (?, ?)",
+		},
+		{
+			description: "values join batch",
+			table:       "foo",
+			columns:     []string{"c1", "c2", "id"},
+			types:       []string{"TEXT", "", "INTEGER"},
+			dialect: &info.Dialect{
+				Placeholder:         "$",
+				PlaceholderResolver: &pg.PlaceholderGenerator{},
+				Update:              dialect.UpdateTypeValuesJoin,
+			},
+			pkColumnIndex: 2,
+			positions:     []int{0, 1},
+			batchSize:     2,
+			expect:        "UPDATE foo dst SET c1 = src.c1, c2 = src.c2 FROM (VALUES ($1::TEXT, $2, $3::INTEGER), ($4, $5, $6)) AS src(c1, c2, id) WHERE dst.id = src.id",
+		},
+		{
+			description: "values join batch with restricted columns",
+			table:       "foo",
+			columns:     []string{"c1", "c2", "id"},
+			dialect: &info.Dialect{
+				Placeholder:         "$",
+				PlaceholderResolver: &pg.PlaceholderGenerator{},
+				Update:              dialect.UpdateTypeValuesJoin,
+			},
+			pkColumnIndex: 2,
+			positions:     []int{1},
+			batchSize:     1,
+			expect:        "UPDATE foo dst SET c2 = src.c2 FROM (VALUES ($1, $2)) AS src(c2, id) WHERE dst.id = src.id",
+		},
+		{
+			description: "case batch with composite key",
+			table:       "foo",
+			columns:     []string{"c1", "tenant_id", "id"},
+			dialect: &info.Dialect{
+				Placeholder: "?",
+				Update:      dialect.UpdateTypeCase,
+			},
+			pkColumnIndex: 1,
+			positions:     []int{0},
+			batchSize:     2,
+			expect:        "UPDATE foo SET c1 = CASE WHEN tenant_id = ? AND id = ? THEN ? WHEN tenant_id = ? AND id = ? THEN ? END WHERE (tenant_id, id) IN ((?, ?), (?, ?))",
+		},
+		{
+			description: "case batch with composite key without row value IN",
+			table:       "foo",
+			columns:     []string{"c1", "tenant_id", "id"},
+			dialect: &info.Dialect{
+				Placeholder:  "?",
+				Update:       dialect.UpdateTypeCase,
+				NoRowValueIn: true,
+			},
+			pkColumnIndex: 1,
+			positions:     []int{0},
+			batchSize:     2,
+			expect:        "UPDATE foo SET c1 = CASE WHEN tenant_id = ? AND id = ? THEN ? WHEN tenant_id = ? AND id = ? THEN ? END WHERE (tenant_id = ? AND id = ?) OR (tenant_id = ? AND id = ?)",
+		},
+		{
+			description: "values join batch with composite key",
+			table:       "foo",
+			columns:     []string{"c1", "tenant_id", "id"},
+			dialect: &info.Dialect{
+				Placeholder:         "$",
+				PlaceholderResolver: &pg.PlaceholderGenerator{},
+				Update:              dialect.UpdateTypeValuesJoin,
+			},
+			pkColumnIndex: 1,
+			positions:     []int{0},
+			batchSize:     2,
+			expect:        "UPDATE foo dst SET c1 = src.c1 FROM (VALUES ($1, $2, $3), ($4, $5, $6)) AS src(c1, tenant_id, id) WHERE dst.tenant_id = src.tenant_id AND dst.id = src.id",
+		},
+	}
+
+	for _, testCase := range testCases {
+		builder, err := NewBuilder(testCase.table, testCase.columns, testCase.pkColumnIndex, testCase.dialect)
+		if !assert.Nil(t, err, testCase.description) {
+			continue
+		}
+		builder.types = testCase.types
+		assert.True(t, builder.Batchable(), testCase.description)
+		actual := builder.BuildBatch(testCase.positions, testCase.batchSize)
+		assert.EqualValues(t, testCase.expect, actual, testCase.description)
+	}
+}
@@ -0,0 +1,31 @@
+package update
+
+import (
+	"context"
+	"github.com/viant/sqlx"
+	"github.com/viant/sqlx/option"
+)
+
+//Typed represents type safe updater service
+type Typed[T any] struct {
+	service *Service
+}
+
+//Service returns underlying updater service
+func (t *Typed[T]) Service() *Service {
+	return t.service
+}
+
+//Exec updates records, returns affected rows
+func (t *Typed[T]) Exec(ctx context.Context, records []*T, options ...option.Option) (int64, error) {
+	return t.service.Exec(ctx, records, options...)
+}
+
+//NewTyped creates a type safe updater service
+func NewTyped[T any](ctx context.Context, db sqlx.Executor, tableName string, options ...option.Option) (*Typed[T], error) {
+	service, err := New(ctx, db, tableName, options...)
+	if err != nil {
+		return nil, err
+	}
+	return &Typed[T]{service: service}, nil
+}
@@ -6,3 +6,8 @@ import "context"
 type Updatable interface {
 	OnUpdate(ctx context.Context) error
 }
+
+//PartialUpdatable interface to be called to restrict updated columns of a record
+type PartialUpdatable interface {
+	UpdatableColumns() []string
+}
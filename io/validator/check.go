@@ -5,6 +5,9 @@ import (
 	"github.com/viant/sqlx/option"
 	"github.com/viant/xunsafe"
 	"reflect"
+	"strconv"
+	"strings"
+	"unsafe"
 )
 
 const (
@@ -17,13 +20,27 @@ type (
 	CheckKid string
 
 	Check struct {
-		SQL            string
-		Field          *xunsafe.Field
-		ErrorMsg       string
-		CheckType      reflect.Type
-		CheckField     *xunsafe.Field
-		Required       bool
-		IdentityColumn *io.Column
+		SQL             string
+		Field           *xunsafe.Field
+		Fields          []*xunsafe.Field
+		Columns         []string
+		ErrorMsg        string
+		CheckType       reflect.Type
+		CheckField      *xunsafe.Field
+		CheckFields     []*xunsafe.Field
+		Required        bool
+		IdentityColumns []*io.Column
+		SoftDelete      *io.SoftDelete
+	}
+
+	//checkKey represents multi columns unique or ref key check definition
+	checkKey struct {
+		db       string
+		table    string
+		columns  []string
+		fields   []*xunsafe.Field
+		required bool
+		errorMsg string
 	}
 
 	Checks struct {
@@ -35,6 +52,48 @@ type (
 	}
 )
 
+//values returns checked record values
+func (c *Check) values(ptr unsafe.Pointer) []interface{} {
+	var result = make([]interface{}, len(c.Fields))
+	for i, field := range c.Fields {
+		result[i] = field.Value(ptr)
+	}
+	return result
+}
+
+//checkValues returns values read from checked table
+func (c *Check) checkValues(ptr unsafe.Pointer) []interface{} {
+	var result = make([]interface{}, len(c.CheckFields))
+	for i, field := range c.CheckFields {
+		result[i] = field.Value(ptr)
+	}
+	return result
+}
+
+//isSet returns true if any checked field was set
+func (c *Check) isSet(setMarker *option.SetMarker, ptr unsafe.Pointer) bool {
+	if setMarker == nil {
+		return true
+	}
+	for _, field := range c.Fields {
+		if setMarker.IsSet(ptr, int(setMarker.Marker.Index(field.Name))) {
+			return true
+		}
+	}
+	return false
+}
+
+func (c *Check) fieldName() string {
+	if len(c.Fields) == 1 {
+		return c.Field.Name
+	}
+	var names = make([]string, len(c.Fields))
+	for i, field := range c.Fields {
+		names[i] = field.Name
+	}
+	return strings.Join(names, ",")
+}
+
 func NewChecks(p reflect.Type, presence *option.SetMarker) (*Checks, error) {
 	var result = &Checks{Type: p}
 	sType := p
@@ -51,12 +110,11 @@ func NewChecks(p reflect.Type, presence *option.SetMarker) (*Checks, error) {
 	}
 	result.presence = presence
 
-	identityColPos := io.Columns(columns).IdentityColumnPos()
-	var identityColumn io.Column
-	if identityColPos > -1 {
-		identityColumn = columns[identityColPos]
-	}
-
+	identityColumns := identityColumns(columns)
+	softDelete := io.Columns(columns).SoftDelete()
+	var uniqueKeys, refKeys []*checkKey
+	var uniqueKeyIndex = map[string]*checkKey{}
+	var refKeyIndex = map[string]*checkKey{}
 	for _, column := range columns {
 		tag := column.Tag()
 		if tag == nil {
@@ -79,36 +137,104 @@ func NewChecks(p reflect.Type, presence *option.SetMarker) (*Checks, error) {
 		}
 
 		if tag.IsUnique && tag.Table != "" {
-			checkType := reflect.StructOf([]reflect.StructField{{Name: xField.Name, Type: xField.Type, Tag: `sqlx:"Val"`}})
-			checkField := xunsafe.NewField(checkType.Field(0))
-			result.Unique = append(result.Unique, &Check{
-				SQL:            "SELECT " + column.Name() + " AS Val FROM " + schema(tag.Db) + tag.Table + " WHERE " + column.Name(),
-				CheckType:      checkType,
-				CheckField:     checkField,
-				Required:       tag.Required,
-				Field:          xField,
-				ErrorMsg:       tag.ErrorMgs,
-				IdentityColumn: &identityColumn,
-			})
+			if tag.Key != "" {
+				uniqueKeys = appendCheckKey(uniqueKeys, uniqueKeyIndex, tag.Key, tag.Db, tag.Table, column.Name(), xField, tag)
+				continue
+			}
+			check := newCheck(tag.Db, tag.Table, []string{column.Name()}, []*xunsafe.Field{xField}, tag.Required, tag.ErrorMgs)
+			check.IdentityColumns = identityColumns
+			check.SoftDelete = softDelete
+			result.Unique = append(result.Unique, check)
 			continue
 		}
 
 		if tag.RefColumn != "" && tag.RefTable != "" {
-			checkType := reflect.StructOf([]reflect.StructField{{Name: xField.Name, Type: xField.Type, Tag: `sqlx:"Val"`}})
-			checkField := xunsafe.NewField(checkType.Field(0))
-			result.RefKey = append(result.RefKey, &Check{
-				SQL:        "SELECT " + tag.RefColumn + " AS Val FROM " + schema(tag.RefDb) + tag.RefTable + " WHERE " + tag.RefColumn,
-				CheckType:  checkType,
-				CheckField: checkField,
-				Field:      xField,
-				Required:   tag.Required,
-				ErrorMsg:   tag.ErrorMgs,
-			})
+			if tag.Key != "" {
+				refKeys = appendCheckKey(refKeys, refKeyIndex, tag.Key, tag.RefDb, tag.RefTable, tag.RefColumn, xField, tag)
+				continue
+			}
+			result.RefKey = append(result.RefKey, newCheck(tag.RefDb, tag.RefTable, []string{tag.RefColumn}, []*xunsafe.Field{xField}, tag.Required, tag.ErrorMgs))
 		}
 	}
+	for _, key := range uniqueKeys {
+		check := newCheck(key.db, key.table, key.columns, key.fields, key.required, key.errorMsg)
+		check.IdentityColumns = identityColumns
+		check.SoftDelete = softDelete
+		result.Unique = append(result.Unique, check)
+	}
+	for _, key := range refKeys {
+		result.RefKey = append(result.RefKey, newCheck(key.db, key.table, key.columns, key.fields, key.required, key.errorMsg))
+	}
 	return result, nil
 }
 
+//newCheck creates a check selecting supplied table columns, multi columns check uses (c1 = ? AND c2 = ?) OR ... criteria
+func newCheck(db, table string, columns []string, fields []*xunsafe.Field, required bool, errorMsg string) *Check {
+	var structFields = make([]reflect.StructField, len(fields))
+	var selectList = make([]string, len(fields))
+	for i, field := range fields {
+		alias := "Val"
+		if i > 0 {
+			alias += strconv.Itoa(i)
+		}
+		structFields[i] = reflect.StructField{Name: field.Name, Type: field.Type, Tag: reflect.StructTag(`sqlx:"` + alias + `"`)}
+		selectList[i] = columns[i] + " AS " + alias
+	}
+	checkType := reflect.StructOf(structFields)
+	var checkFields = make([]*xunsafe.Field, len(fields))
+	for i := range checkFields {
+		checkFields[i] = xunsafe.NewField(checkType.Field(i))
+	}
+	SQL := "SELECT " + strings.Join(selectList, ", ") + " FROM " + schema(db) + table + " WHERE"
+	if len(columns) == 1 {
+		SQL += " " + columns[0]
+	}
+	return &Check{
+		SQL:         SQL,
+		Field:       fields[0],
+		Fields:      fields,
+		Columns:     columns,
+		ErrorMsg:    errorMsg,
+		CheckType:   checkType,
+		CheckField:  checkFields[0],
+		CheckFields: checkFields,
+		Required:    required,
+	}
+}
+
+func appendCheckKey(keys []*checkKey, index map[string]*checkKey, name, db, table, column string, field *xunsafe.Field, tag *io.Tag) []*checkKey {
+	key, ok := index[name]
+	if !ok {
+		key = &checkKey{db: db, table: table}
+		index[name] = key
+		keys = append(keys, key)
+	}
+	key.columns = append(key.columns, column)
+	key.fields = append(key.fields, field)
+	key.required = key.required || tag.Required
+	if key.errorMsg == "" {
+		key.errorMsg = tag.ErrorMgs
+	}
+	return keys
+}
+
+//identityColumns returns primary key columns or identity column if no primary key was defined
+func identityColumns(columns []io.Column) []*io.Column {
+	var result []*io.Column
+	for i, column := range columns {
+		if tag := column.Tag(); tag != nil && tag.PrimaryKey {
+			result = append(result, &columns[i])
+		}
+	}
+	if len(result) > 0 {
+		return result
+	}
+	if pos := io.Columns(columns).IdentityColumnPos(); pos > -1 {
+		result = append(result, &columns[pos])
+	}
+	return result
+}
+
 func schema(db string) string {
 	if db == "" {
 		return db
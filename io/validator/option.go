@@ -6,11 +6,12 @@ import (
 
 type (
 	Options struct {
-		Required    bool
-		CheckUnique bool
-		CheckRef    bool
-		Location    string
-		SetMarker   *option.SetMarker
+		Required        bool
+		CheckUnique     bool
+		CheckRef        bool
+		Location        string
+		SetMarker       *option.SetMarker
+		SkipSoftDeleted bool
 	}
 	Option func(c *Options)
 )
@@ -35,6 +36,13 @@ func WithRef(flag bool) Option {
 	}
 }
 
+//WithSkipSoftDeleted with option to ignore soft deleted rows in unique check
+func WithSkipSoftDeleted(flag bool) Option {
+	return func(c *Options) {
+		c.SkipSoftDeleted = flag
+	}
+}
+
 //WithLocation creates with location option
 func WithLocation(location string) Option {
 	return func(c *Options) {
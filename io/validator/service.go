@@ -2,7 +2,7 @@ package validator
 
 import (
 	"context"
-	"database/sql"
+	"github.com/viant/sqlx"
 	"github.com/viant/sqlx/io"
 	"github.com/viant/sqlx/io/read"
 	"github.com/viant/sqlx/option"
@@ -43,7 +43,7 @@ func (s *Service) checksFor(t reflect.Type, setMarker *option.SetMarker) (*Check
 	return checks, nil
 }
 
-func (s *Service) Validate(ctx context.Context, db *sql.DB, any interface{}, opts ...Option) (*Validation, error) {
+func (s *Service) Validate(ctx context.Context, db sqlx.Executor, any interface{}, opts ...Option) (*Validation, error) {
 	var result = &Validation{}
 	options := NewOptions()
 	for _, opt := range opts {
@@ -100,7 +100,7 @@ func (s *Service) checkNotNull(ctx context.Context, path *Path, at io.ValueAcces
 	}
 }
 
-func (s *Service) checkUniques(ctx context.Context, path *Path, db *sql.DB, at io.ValueAccessor, count int, checks []*Check, violations *Validation, options *Options) error {
+func (s *Service) checkUniques(ctx context.Context, path *Path, db sqlx.Executor, at io.ValueAccessor, count int, checks []*Check, violations *Validation, options *Options) error {
 	if len(checks) == 0 || !options.CheckUnique {
 		return nil
 	}
@@ -112,13 +112,17 @@ func (s *Service) checkUniques(ctx context.Context, path *Path, db *sql.DB, at i
 	return nil
 }
 
-func (s *Service) checkUnique(ctx context.Context, path *Path, db *sql.DB, at io.ValueAccessor, count int, check *Check, violations *Validation, options *Options) error {
+func (s *Service) checkUnique(ctx context.Context, path *Path, db sqlx.Executor, at io.ValueAccessor, count int, check *Check, violations *Validation, options *Options) error {
 	queryCtx := s.buildUniqueMatchContext(check, count, path, at, options)
 	if len(queryCtx.values) == 0 {
 		return nil
 	}
 	//build query for all values that should be unique
-	reader, err := read.New(ctx, db, queryCtx.QueryWithExclusions(), func() interface{} {
+	query := queryCtx.QueryWithExclusions()
+	if options.SkipSoftDeleted && check.SoftDelete != nil {
+		query += " AND " + check.SoftDelete.Criterion()
+	}
+	reader, err := read.New(ctx, db, query, func() interface{} {
 		return reflect.New(check.CheckType).Interface()
 	})
 	if err != nil {
@@ -127,10 +131,9 @@ func (s *Service) checkUnique(ctx context.Context, path *Path, db *sql.DB, at io
 	var index = map[interface{}]bool{}
 	err = reader.QueryAll(ctx, func(record interface{}) error {
 		recordPtr := xunsafe.AsPointer(record)
-		value := check.CheckField.Value(recordPtr)
-		index[mapKey(value)] = true
+		index[keyOf(check.checkValues(recordPtr))] = true
 		return nil
-	}, queryCtx.values...)
+	}, queryCtx.Values()...)
 	if stmt := reader.Stmt(); stmt != nil {
 		_ = stmt.Close()
 	}
@@ -146,30 +149,28 @@ func (s *Service) checkUnique(ctx context.Context, path *Path, db *sql.DB, at io
 }
 
 func (s *Service) buildUniqueMatchContext(check *Check, count int, path *Path, at io.ValueAccessor, options *Options) *queryContext {
-	queryCtx := newQueryContext(check.SQL)
+	queryCtx := newQueryContext(check)
 	setMarker := options.SetMarker
+	fieldName := check.fieldName()
 	for i := 0; i < count; i++ {
 		itemPath := path.AppendIndex(i)
-		fieldPath := itemPath.AppendField(check.Field.Name)
+		fieldPath := itemPath.AppendField(fieldName)
 		record := at(i)
 		recordPtr := xunsafe.AsPointer(record)
-		if setMarker != nil && !setMarker.IsSet(recordPtr, int(setMarker.Marker.Index(check.Field.Name))) {
+		if !check.isSet(setMarker, recordPtr) {
 			continue
 		}
-		value := check.Field.Value(recordPtr)
-		if isNil(value) && !check.Required {
+		values := check.values(recordPtr)
+		if hasNil(values) && !check.Required {
 			continue //unique is null and not required skipping validation
 		}
-		queryCtx.Append(value, check.Field.Name, fieldPath)
-
-		if check.IdentityColumn != nil {
-			queryCtx.AddExclusion([]*io.Column{check.IdentityColumn}, recordPtr, itemPath)
-		}
+		queryCtx.Append(values, fieldName, fieldPath)
+		queryCtx.AddExclusion(check.IdentityColumns, recordPtr, itemPath)
 	}
 	return queryCtx
 }
 
-func (s *Service) checkRefs(ctx context.Context, path *Path, db *sql.DB, at io.ValueAccessor, count int, checks []*Check, violations *Validation, options *Options) error {
+func (s *Service) checkRefs(ctx context.Context, path *Path, db sqlx.Executor, at io.ValueAccessor, count int, checks []*Check, violations *Validation, options *Options) error {
 	if len(checks) == 0 || !options.CheckRef {
 		return nil
 	}
@@ -182,7 +183,7 @@ func (s *Service) checkRefs(ctx context.Context, path *Path, db *sql.DB, at io.V
 
 }
 
-func (s *Service) checkRef(ctx context.Context, path *Path, db *sql.DB, at io.ValueAccessor, count int, check *Check, violations *Validation, options *Options) error {
+func (s *Service) checkRef(ctx context.Context, path *Path, db sqlx.Executor, at io.ValueAccessor, count int, check *Check, violations *Validation, options *Options) error {
 	queryCtx := s.buildCheckRefQueryContext(check, count, path, at, options, violations)
 	if len(queryCtx.values) == 0 {
 		return nil
@@ -197,10 +198,9 @@ func (s *Service) checkRef(ctx context.Context, path *Path, db *sql.DB, at io.Va
 	var index = map[interface{}]bool{}
 	err = reader.QueryAll(ctx, func(record interface{}) error {
 		recordPtr := xunsafe.AsPointer(record)
-		value := check.CheckField.Value(recordPtr)
-		index[mapKey(value)] = true
+		index[keyOf(check.checkValues(recordPtr))] = true
 		return nil
-	}, queryCtx.values...)
+	}, queryCtx.Values()...)
 	if stmt := reader.Stmt(); stmt != nil {
 		_ = stmt.Close()
 	}
@@ -216,21 +216,22 @@ func (s *Service) checkRef(ctx context.Context, path *Path, db *sql.DB, at io.Va
 }
 
 func (s *Service) buildCheckRefQueryContext(check *Check, count int, path *Path, at io.ValueAccessor, options *Options, violations *Validation) *queryContext {
-	queryCtx := newQueryContext(check.SQL)
+	queryCtx := newQueryContext(check)
 	setMarker := options.SetMarker
+	fieldName := check.fieldName()
 	for i := 0; i < count; i++ {
 		itemPath := path.AppendIndex(i)
-		fieldPath := itemPath.AppendField(check.Field.Name)
+		fieldPath := itemPath.AppendField(fieldName)
 		record := at(i)
 		recordPtr := xunsafe.AsPointer(record)
-		if setMarker != nil && !setMarker.IsSet(recordPtr, int(setMarker.Marker.Index(check.Field.Name))) {
+		if !check.isSet(setMarker, recordPtr) {
 			continue
 		}
-		value := check.Field.Value(recordPtr)
-		if isNil(value) && !check.Required {
+		values := check.values(recordPtr)
+		if hasNil(values) && !check.Required {
 			continue //ref key is null and not required skipping validation
 		}
-		queryCtx.Append(value, check.Field.Name, fieldPath)
+		queryCtx.Append(values, fieldName, fieldPath)
 	}
 	return queryCtx
 }
@@ -251,6 +252,15 @@ func isNil(value interface{}) bool {
 	}
 }
 
+func hasNil(values []interface{}) bool {
+	for _, value := range values {
+		if isNil(value) {
+			return true
+		}
+	}
+	return false
+}
+
 func mapKey(value interface{}) interface{} {
 	switch actual := value.(type) {
 	case *string:
@@ -32,6 +32,25 @@ type FkRecord struct {
 	DeptId *int `sqlx:"name=name,refColumn=id,refTable=dept01,required" json:",omitempty"`
 }
 
+type TenantUniqueRecord struct {
+	TenantId int    `sqlx:"name=tenant_id,primaryKey"`
+	Id       int    `sqlx:"name=ID,primaryKey"`
+	Name     string `sqlx:"name=name,unique,table=v04,key=tenant_name"`
+	Region   string `sqlx:"name=region,unique,table=v04,key=tenant_name"`
+}
+
+type TenantFkRecord struct {
+	Id       int `sqlx:"name=ID,autoincrement,primaryKey"`
+	TenantId int `sqlx:"name=tenant_id,refColumn=tenant_id,refTable=dept04,key=dept"`
+	DeptId   int `sqlx:"name=dept_id,refColumn=id,refTable=dept04,key=dept"`
+}
+
+type SoftUniqueRecord struct {
+	Id       int    `sqlx:"name=ID,autoincrement,primaryKey"`
+	Name     string `sqlx:"name=name,unique,table=v05"`
+	Archived bool   `sqlx:"name=archived,softDelete"`
+}
+
 type NoNullRecord struct {
 	Id     int  `sqlx:"name=ID,autoincrement,primaryKey"`
 	Field1 *int `sqlx:"name=f1,required" json:",omitempty"`
@@ -358,6 +377,138 @@ func TestNewValidation(t *testing.T) {
 	}
 }
 
+func TestValidation_CompositeKey(t *testing.T) {
+	var testCases = []testCase{
+		{
+			description: "multi column unique validation failure",
+			driver:      "sqlite3",
+			dsn:         "/tmp/sqllite.db",
+			initSQL: []string{
+				"CREATE TABLE IF NOT EXISTS v04 (tenant_id INTEGER, id INTEGER, name TEXT, region TEXT, PRIMARY KEY(tenant_id, id))",
+				"delete from v04",
+				`insert into v04 values(1, 1, "John Wick", "us")`,
+			},
+			data:                &TenantUniqueRecord{TenantId: 1, Id: 2, Name: "John Wick", Region: "us"},
+			expectViolations:    true,
+			expectErrorFragment: "is not unique",
+		},
+		{
+			description: "multi column unique validation passed",
+			driver:      "sqlite3",
+			dsn:         "/tmp/sqllite.db",
+			initSQL: []string{
+				"CREATE TABLE IF NOT EXISTS v04 (tenant_id INTEGER, id INTEGER, name TEXT, region TEXT, PRIMARY KEY(tenant_id, id))",
+				"delete from v04",
+				`insert into v04 values(1, 1, "John Wick", "us")`,
+			},
+			data: []*TenantUniqueRecord{
+				{TenantId: 1, Id: 2, Name: "John Wick", Region: "eu"},
+				{TenantId: 1, Id: 1, Name: "John Wick", Region: "us"},
+			},
+			expectViolations: false,
+		},
+		{
+			description: "multi column fk validation failure",
+			driver:      "sqlite3",
+			dsn:         "/tmp/sqllite.db",
+			initSQL: []string{
+				"CREATE TABLE IF NOT EXISTS dept04 (tenant_id INTEGER, id INTEGER, name TEXT, PRIMARY KEY(tenant_id, id))",
+				"delete from dept04",
+				`insert into dept04 values(1, 1, "Admin")`,
+			},
+			data:                &TenantFkRecord{Id: 10, TenantId: 2, DeptId: 1},
+			expectViolations:    true,
+			expectErrorFragment: "does not exists",
+		},
+		{
+			description: "multi column fk validation passed",
+			driver:      "sqlite3",
+			dsn:         "/tmp/sqllite.db",
+			initSQL: []string{
+				"CREATE TABLE IF NOT EXISTS dept04 (tenant_id INTEGER, id INTEGER, name TEXT, PRIMARY KEY(tenant_id, id))",
+				"delete from dept04",
+				`insert into dept04 values(1, 1, "Admin")`,
+			},
+			data:             &TenantFkRecord{Id: 10, TenantId: 1, DeptId: 1},
+			expectViolations: false,
+		},
+	}
+	for _, testCase := range testCases {
+		db, err := sql.Open(testCase.driver, testCase.dsn)
+		if !assert.Nil(t, err, testCase.description) {
+			continue
+		}
+		for _, SQL := range testCase.initSQL {
+			_, err := db.Exec(SQL)
+			assert.Nil(t, err, testCase.description)
+		}
+		validator := New()
+		validation, err := validator.Validate(context.Background(), db, testCase.data, testCase.options...)
+		if !assert.Nil(t, err, testCase.description) {
+			continue
+		}
+		if testCase.expectViolations {
+			assert.True(t, validation.Failed, testCase.description)
+			assert.Contains(t, validation.Error(), testCase.expectErrorFragment, testCase.description)
+			continue
+		}
+		assert.False(t, validation.Failed, testCase.description)
+	}
+}
+
+func TestValidation_SoftDelete(t *testing.T) {
+	var initSQL = []string{
+		"CREATE TABLE IF NOT EXISTS v05 (id INTEGER PRIMARY KEY, name TEXT, archived BOOLEAN)",
+		"delete from v05",
+		`insert into v05 values(1, "John Wick", true)`,
+		`insert into v05 values(3, "Winston", false)`,
+	}
+	var testCases = []testCase{
+		{
+			description:         "soft deleted duplicate is not unique by default",
+			data:                &SoftUniqueRecord{Id: 2, Name: "John Wick"},
+			expectViolations:    true,
+			expectErrorFragment: "is not unique",
+		},
+		{
+			description:      "unique validation passed with soft deleted duplicate",
+			data:             &SoftUniqueRecord{Id: 2, Name: "John Wick"},
+			options:          []Option{WithSkipSoftDeleted(true)},
+			expectViolations: false,
+		},
+		{
+			description:         "unique validation failure with active duplicate",
+			data:                &SoftUniqueRecord{Id: 2, Name: "Winston"},
+			options:             []Option{WithSkipSoftDeleted(true)},
+			expectViolations:    true,
+			expectErrorFragment: "is not unique",
+		},
+	}
+	db, err := sql.Open("sqlite3", "/tmp/sqllite.db")
+	if !assert.Nil(t, err) {
+		return
+	}
+	for _, SQL := range initSQL {
+		_, err := db.Exec(SQL)
+		if !assert.Nil(t, err) {
+			return
+		}
+	}
+	for _, testCase := range testCases {
+		validator := New()
+		validation, err := validator.Validate(context.Background(), db, testCase.data, testCase.options...)
+		if !assert.Nil(t, err, testCase.description) {
+			continue
+		}
+		if testCase.expectViolations {
+			assert.True(t, validation.Failed, testCase.description)
+			assert.Contains(t, validation.Error(), testCase.expectErrorFragment, testCase.description)
+			continue
+		}
+		assert.False(t, validation.Failed, testCase.description)
+	}
+}
+
 func TestNewValidationWithCache(t *testing.T) {
 
 	var testCases = []testCase{
@@ -1,6 +1,7 @@
 package validator
 
 import (
+	"fmt"
 	"github.com/viant/sqlx/io"
 	"strings"
 	"unsafe"
@@ -9,8 +10,10 @@ import (
 type (
 	queryContext struct {
 		SQL             string
+		columns         []string
 		placeholders    []string
 		values          []interface{}
+		exclusionValues []interface{}
 		index           map[interface{}]*queryValue
 		queryExclusions []*queryExclusion
 	}
@@ -25,19 +28,27 @@ type (
 	}
 )
 
-func (p *queryContext) Append(value interface{}, field string, path *Path) {
+func (p *queryContext) Append(values []interface{}, field string, path *Path) {
 	if len(p.index) == 0 {
 		p.index = map[interface{}]*queryValue{}
 	}
-	p.placeholders = append(p.placeholders, "?")
-	p.values = append(p.values, value)
-	p.index[mapKey(value)] = &queryValue{
-		value: value,
+	p.placeholders = append(p.placeholders, p.placeholder())
+	p.values = append(p.values, values...)
+	p.index[keyOf(values)] = &queryValue{
+		value: valueOf(values),
 		field: field,
 		path:  path,
 	}
 }
 
+//placeholder returns single value placeholder or multi columns key criterion
+func (p *queryContext) placeholder() string {
+	if len(p.columns) < 2 {
+		return "?"
+	}
+	return "(" + strings.Join(p.columns, " = ? AND ") + " = ?)"
+}
+
 func (p *queryContext) AddExclusion(columns []*io.Column, recUPtr unsafe.Pointer, itemPath *Path) {
 	if len(columns) == 0 {
 		return
@@ -52,6 +63,7 @@ func (p *queryContext) AddExclusion(columns []*io.Column, recUPtr unsafe.Pointer
 		placeholders: make([]string, len(columns)),
 	}
 
+	var values = make([]interface{}, len(columns))
 	for i, column := range columns {
 		columnFielder, ok := (*column).(io.ColumnWithFields)
 		if !ok {
@@ -64,7 +76,7 @@ func (p *queryContext) AddExclusion(columns []*io.Column, recUPtr unsafe.Pointer
 		fieldPath := itemPath.AppendField(field.Name)
 		fieldValue := field.Value(recUPtr)
 
-		p.values = append(p.values, fieldValue)
+		values[i] = fieldValue
 		p.index[mapKey(fieldValue)] = &queryValue{
 			value: fieldValue,
 			field: field.Name,
@@ -74,12 +86,23 @@ func (p *queryContext) AddExclusion(columns []*io.Column, recUPtr unsafe.Pointer
 		queryExclusion.placeholders[i] = "?"
 		queryExclusion.columnNames[i] = columnFielder.Name()
 	}
-
+	p.exclusionValues = append(p.exclusionValues, values...)
 	p.queryExclusions = append(p.queryExclusions, queryExclusion)
 }
 
+//Values returns query values followed by exclusion values
+func (p *queryContext) Values() []interface{} {
+	if len(p.exclusionValues) == 0 {
+		return p.values
+	}
+	return append(p.values[:len(p.values):len(p.values)], p.exclusionValues...)
+}
+
 func (p *queryContext) Query() string {
-	return p.SQL + " IN (" + strings.Join(p.placeholders, ",") + ")"
+	if len(p.columns) < 2 {
+		return p.SQL + " IN (" + strings.Join(p.placeholders, ",") + ")"
+	}
+	return p.SQL + " (" + strings.Join(p.placeholders, " OR ") + ")"
 }
 
 func (p *queryContext) QueryWithExclusions() string {
@@ -90,12 +113,12 @@ func (p *queryContext) QueryWithExclusions() string {
 	for _, exclusion := range p.queryExclusions {
 		sb.WriteString(" AND ")
 		if len(exclusion.columnNames) > 1 {
-			sb.WriteString("(")
-		}
-		sb.WriteString(strings.Join(exclusion.columnNames, ","))
-		if len(exclusion.columnNames) > 1 {
-			sb.WriteString(")")
+			sb.WriteString("NOT (")
+			sb.WriteString(strings.Join(exclusion.columnNames, " = ? AND "))
+			sb.WriteString(" = ?)")
+			continue
 		}
+		sb.WriteString(exclusion.columnNames[0])
 		sb.WriteString(" NOT IN (")
 		sb.WriteString(strings.Join(exclusion.placeholders, ","))
 		sb.WriteString(")")
@@ -104,6 +127,30 @@ func (p *queryContext) QueryWithExclusions() string {
 	return sb.String()
 }
 
-func newQueryContext(SQL string) *queryContext {
-	return &queryContext{index: map[interface{}]*queryValue{}, SQL: SQL}
+//keyOf returns index key for single or multi columns values
+func keyOf(values []interface{}) interface{} {
+	if len(values) == 1 {
+		return mapKey(values[0])
+	}
+	var parts = make([]string, len(values))
+	for i, value := range values {
+		parts[i] = fmt.Sprintf("%v", derefIfNeeded(value))
+	}
+	return strings.Join(parts, "/")
+}
+
+//valueOf returns single value or dereferenced multi columns values
+func valueOf(values []interface{}) interface{} {
+	if len(values) == 1 {
+		return values[0]
+	}
+	var result = make([]interface{}, len(values))
+	for i, value := range values {
+		result[i] = derefIfNeeded(value)
+	}
+	return result
+}
+
+func newQueryContext(check *Check) *queryContext {
+	return &queryContext{index: map[interface{}]*queryValue{}, SQL: check.SQL, columns: check.Columns}
 }
@@ -51,6 +51,9 @@ func (e *Validation) AppendUnique(path *Path, field string, value interface{}, m
 func derefIfNeeded(value interface{}) interface{} {
 	v := reflect.ValueOf(value)
 	if v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			return nil
+		}
 		v = v.Elem()
 		value = v.Interface()
 	}
@@ -0,0 +1,61 @@
+package io
+
+import (
+	"reflect"
+	"time"
+)
+
+// Version represents optimistic locking version column, integer version is incremented, timestamp version is set to current time
+type Version struct {
+	Name     string
+	Position int
+	IsTime   bool
+}
+
+// Next returns next version value for version field address
+func (v *Version) Next(fieldAddr interface{}) interface{} {
+	if v.IsTime {
+		return time.Now().UTC().Truncate(time.Microsecond)
+	}
+	value := reflect.ValueOf(fieldAddr)
+	for value.Kind() == reflect.Ptr {
+		if value.IsNil() {
+			return int64(1)
+		}
+		value = value.Elem()
+	}
+	switch value.Kind() {
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return value.Uint() + 1
+	default:
+		return value.Int() + 1
+	}
+}
+
+// Set sets version field value
+func (v *Version) Set(fieldAddr interface{}, next interface{}) {
+	value := reflect.ValueOf(fieldAddr).Elem()
+	if value.Kind() == reflect.Ptr {
+		if value.IsNil() {
+			value.Set(reflect.New(value.Type().Elem()))
+		}
+		value = value.Elem()
+	}
+	value.Set(reflect.ValueOf(next).Convert(value.Type()))
+}
+
+// Version returns version column or nil
+func (c Columns) Version() *Version {
+	for i, item := range c {
+		tag := item.Tag()
+		if tag == nil || !tag.Version {
+			continue
+		}
+		scanType := item.ScanType()
+		for scanType != nil && scanType.Kind() == reflect.Ptr {
+			scanType = scanType.Elem()
+		}
+		return &Version{Name: item.Name(), Position: i, IsTime: scanType == reflect.TypeOf(time.Time{})}
+	}
+	return nil
+}
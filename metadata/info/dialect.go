@@ -1,6 +1,7 @@
 package info
 
 import (
+	"github.com/viant/sqlx/io/errors"
 	"github.com/viant/sqlx/metadata/database"
 	"github.com/viant/sqlx/metadata/info/dialect"
 	"github.com/viant/sqlx/metadata/info/placeholder"
@@ -12,21 +13,35 @@ type Dialect struct {
 	database.Product
 	Placeholder         string // prepare statement placeholder, default '?', but oracle uses ':'
 	PlaceholderResolver placeholder.Generator
+	MaxParameters       int // max bind parameters per statement, i.e. 2100 for SQL Server, 0 if unknown
 	Transactional       bool
 	Insert              dialect.InsertFeatures
 	Upsert              dialect.UpsertFeatures
+	Update              dialect.UpdateFeatures
 	Load                dialect.LoadFeature
+	Pagination          dialect.PaginationFeature
 	//LoadResolver        temp.SessionResolver
 	CanAutoincrement  bool
 	AutoincrementFunc string
 	CanLastInsertID   bool
-	CanReturning      bool //Postgress supports Returning Data From Modified Rows in one statement
+	CanReturning      bool   //Postgress supports Returning Data From Modified Rows in one statement
+	CanReturningInto  bool   //Oracle returns modified rows data into out bind variables
+	CanOutputInserted bool   //SQL Server returns inserted rows data with OUTPUT INSERTED clause
+	UpsertInserted    string //upsert RETURNING expression true for inserted rows, i.e. (xmax = 0) for PostgreSQL
+	NoRowValueIn      bool   //does not support (c1, c2) IN ((?, ?)) row value predicate, (c1 = ? AND c2 = ?) OR ... criteria are used
 	QuoteCharacter    byte
 	// TODO: check if column has a space or exist in keywords in this case use quote if keyword is specified
 	// i.e. normalized column on the dialect
 	Keywords                  map[string]bool
 	DefaultPresetIDStrategy   dialect.PresetIDStrategy
 	SpecialKeywordEscapeQuote byte
+	DualTable                 string                // table required by constant select, i.e. DUAL for Oracle
+	StatementTerminator       string                // required statement terminator, i.e. ';' for SQL Server MERGE
+	Savepoint                 string                // savepoint statement followed by name, i.e. SAVEPOINT, empty if savepoints are not supported
+	RollbackToSavepoint       string                // rollback to savepoint statement followed by name, i.e. ROLLBACK TO SAVEPOINT
+	ReleaseSavepoint          string                // release savepoint statement followed by name, empty if savepoint can not be released
+	TransientError            func(err error) bool  // returns true for errors transaction can be retried after, i.e. deadlock
+	ErrorNormalizer           func(err error) error // wraps driver error with io/errors typed error, i.e. UniqueViolation
 }
 
 //Dialects represents dialects
@@ -40,14 +55,64 @@ func (d *Dialect) PlaceholderGetter() func() string {
 	return (&placeholder.DefaultGenerator{}).Resolver()
 }
 
-//EnsurePlaceholders converts '?' to specific dialect placeholders if needed
+//QuoteIdentifier returns identifier quoted with SpecialKeywordEscapeQuote (default '"') if it is keyword
+//or has other than letter, digit or underscore characters, dotted identifier parts are quoted separately
+func (d *Dialect) QuoteIdentifier(identifier string) string {
+	quote := byte('"')
+	if d != nil && d.SpecialKeywordEscapeQuote != 0 {
+		quote = d.SpecialKeywordEscapeQuote
+	}
+	parts := strings.Split(identifier, ".")
+	for i, part := range parts {
+		if d.requiresQuote(part) {
+			parts[i] = string(quote) + part + string(quote)
+		}
+	}
+	return strings.Join(parts, ".")
+}
+
+func (d *Dialect) requiresQuote(identifier string) bool {
+	if identifier == "" || identifier == "*" {
+		return false
+	}
+	if d != nil && (d.Keywords[identifier] || d.Keywords[strings.ToUpper(identifier)]) {
+		return true
+	}
+	for i := 0; i < len(identifier); i++ {
+		switch c := identifier[i]; {
+		case c == '_', c >= 'a' && c <= 'z', c >= 'A' && c <= 'Z':
+		case c >= '0' && c <= '9' && i > 0:
+		default:
+			return true
+		}
+	}
+	return false
+}
+
+//IsTransient returns true if dialect classifies err as transient, i.e. deadlock or serialization failure
+func (d *Dialect) IsTransient(err error) bool {
+	if err == nil || d == nil || d.TransientError == nil {
+		return false
+	}
+	return d.TransientError(err)
+}
+
+//NormalizeError returns io/errors typed error wrapping driver error, err is returned unchanged if it can not be normalized
+func (d *Dialect) NormalizeError(err error) error {
+	if err == nil || d == nil || d.ErrorNormalizer == nil || errors.IsNormalized(err) {
+		return err
+	}
+	return d.ErrorNormalizer(err)
+}
+
+//EnsurePlaceholders converts '?' to specific dialect placeholders if needed, literals, quoted identifiers and comments are skipped
 func (d *Dialect) EnsurePlaceholders(SQL string) string {
 	if d.Placeholder == placeholder.Default {
 		return SQL
 	}
-	placeholders := indexPlaceholders(SQL)
+	placeholders := placeholder.Positions(SQL)
 	placeholderLen := len(placeholders)
-	if placeholderLen == 0 || placeholders[0] == -1 {
+	if placeholderLen == 0 {
 		return SQL
 	}
 	var result = make([]byte, len(SQL)-placeholderLen+d.countPlaceholdersLen(0, placeholderLen))
@@ -74,19 +139,13 @@ func (d *Dialect) countPlaceholdersLen(start, numOfPlaceholders int) int {
 	return (&placeholder.DefaultGenerator{}).Len(start, numOfPlaceholders)
 }
 
-func indexPlaceholders(SQL string) []int {
-	index := strings.Index(SQL, placeholder.Default)
-	var indexes = []int{index}
-	for index+1 < len(SQL) {
-		next := strings.Index(SQL[index+1:], placeholder.Default)
-		if next == -1 {
-			break
-		}
-		indexes = append(indexes, index+1+next)
-		index += next + 1
+//NamedPlaceholders converts :name and @name parameters to dialect placeholders, it returns converted SQL and parameter names
+func (d *Dialect) NamedPlaceholders(SQL string) (string, []string) {
+	getPlaceholder := (&placeholder.DefaultGenerator{}).Resolver()
+	if d != nil {
+		getPlaceholder = d.PlaceholderGetter()
 	}
-
-	return indexes
+	return placeholder.Named(SQL, getPlaceholder)
 }
 
 func (a Dialects) Len() int      { return len(a) }
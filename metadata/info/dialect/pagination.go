@@ -0,0 +1,13 @@
+package dialect
+
+//PaginationFeature represents dialect supported pagination syntax
+type PaginationFeature int
+
+const (
+	//PaginationLimitOffset defines LIMIT n OFFSET m pagination
+	PaginationLimitOffset = PaginationFeature(iota) //i.e. PostgreSQL, MySQL, SQLite
+	//PaginationFetchFirst defines OFFSET m ROWS FETCH FIRST n ROWS ONLY pagination
+	PaginationFetchFirst //i.e. Oracle 12c+
+	//PaginationTop defines SELECT TOP n pagination, OFFSET m ROWS FETCH NEXT n ROWS ONLY with offset
+	PaginationTop //i.e. SQL Server
+)
@@ -0,0 +1,18 @@
+package dialect
+
+//UpdateFeatures represents dialect supported multi records update type
+type UpdateFeatures int
+
+//MultiRecords returns true if dialect supports multi records update DML
+func (t UpdateFeatures) MultiRecords() bool {
+	return t != UpdateTypeSingleRecord
+}
+
+const (
+	//UpdateTypeSingleRecord defines single record update
+	UpdateTypeSingleRecord = UpdateFeatures(iota)
+	//UpdateTypeCase defines multi records update with CASE expression per column
+	UpdateTypeCase //i.e. MySQL, SQLite
+	//UpdateTypeValuesJoin defines multi records update joined with VALUES list
+	UpdateTypeValuesJoin //i.e. PostgreSQL
+)
@@ -16,4 +16,6 @@ const (
 	UpsertTypeInsertOrUpdate //i.e MySQL
 	//UpsertTypeUpdateOrInsert defined update or insert upsert
 	UpsertTypeUpdateOrInsert //i.e Firebird
+	//UpsertTypeInsertOnConflict defined insert on conflict do update upsert
+	UpsertTypeInsertOnConflict //i.e PostgreSQL 9.5+, SQLite 3.24+
 )
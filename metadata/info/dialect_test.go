@@ -2,6 +2,7 @@ package info
 
 import (
 	"github.com/stretchr/testify/assert"
+	"strconv"
 	"testing"
 )
 
@@ -20,6 +21,15 @@ func TestDialect_EnsurePlaceholders(t *testing.T) {
 			sQL:    "SELECT COUNT(1) FROM foo WHERE Kind=? AND Active=? AND year > ? ",
 			expect: "SELECT COUNT(1) FROM foo WHERE Kind=? AND Active=? AND year > ? ",
 		},
+		{
+			description: "numbered placeholders skip literals, comments and json operators",
+			dialect: Dialect{
+				Placeholder:         "$",
+				PlaceholderResolver: &numberedGenerator{},
+			},
+			sQL:    "SELECT '?' FROM foo /* ? */ WHERE Kind=? AND tags ?| ? -- ?",
+			expect: "SELECT '?' FROM foo /* ? */ WHERE Kind=$1 AND tags ?| $2 -- ?",
+		},
 	}
 
 	for _, testCase := range testCases {
@@ -27,3 +37,17 @@ func TestDialect_EnsurePlaceholders(t *testing.T) {
 		assert.Equal(t, testCase.expect, actual, testCase.description)
 	}
 }
+
+type numberedGenerator struct{}
+
+func (g *numberedGenerator) Resolver() func() string {
+	i := 0
+	return func() string {
+		i++
+		return "$" + strconv.Itoa(i)
+	}
+}
+
+func (g *numberedGenerator) Len(start, numOfPlaceholders int) int {
+	return 2 * (numOfPlaceholders - start)
+}
@@ -0,0 +1,93 @@
+package placeholder
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+)
+
+//Bind returns named parameters values from map with string keys, struct or struct pointer source,
+//struct field is matched by sqlx tag column name or field name (case insensitive)
+func Bind(names []string, source interface{}) ([]interface{}, error) {
+	value := reflect.ValueOf(source)
+	for value.Kind() == reflect.Ptr || value.Kind() == reflect.Interface {
+		if value.IsNil() {
+			return nil, fmt.Errorf("failed to bind %v: source was nil", names)
+		}
+		value = value.Elem()
+	}
+	var result = make([]interface{}, len(names))
+	switch value.Kind() {
+	case reflect.Map:
+		if value.Type().Key().Kind() != reflect.String {
+			return nil, fmt.Errorf("unsupported named parameters source: %T", source)
+		}
+		for i, name := range names {
+			item := value.MapIndex(reflect.ValueOf(name).Convert(value.Type().Key()))
+			if !item.IsValid() {
+				return nil, fmt.Errorf("failed to bind parameter %v: not found in %T", name, source)
+			}
+			result[i] = item.Interface()
+		}
+	case reflect.Struct:
+		for i, name := range names {
+			index, ok := fieldIndex(value.Type(), name)
+			if !ok {
+				return nil, fmt.Errorf("failed to bind parameter %v: not found in %T", name, source)
+			}
+			result[i] = value.FieldByIndex(index).Interface()
+		}
+	default:
+		return nil, fmt.Errorf("unsupported named parameters source: %T", source)
+	}
+	return result, nil
+}
+
+//CanBind returns true if source is map with string keys, struct or struct pointer, driver.Valuer and time.Time are not bindable
+func CanBind(source interface{}) bool {
+	switch source.(type) {
+	case driver.Valuer, time.Time, *time.Time:
+		return false
+	}
+	rType := reflect.TypeOf(source)
+	for rType != nil && rType.Kind() == reflect.Ptr {
+		rType = rType.Elem()
+	}
+	if rType == nil {
+		return false
+	}
+	return rType.Kind() == reflect.Struct || (rType.Kind() == reflect.Map && rType.Key().Kind() == reflect.String)
+}
+
+func fieldIndex(structType reflect.Type, name string) ([]int, bool) {
+	var byName []int
+	for i := 0; i < structType.NumField(); i++ {
+		field := structType.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		if column := tagColumn(field.Tag.Get("sqlx")); column != "" && strings.EqualFold(column, name) {
+			return field.Index, true
+		}
+		if byName == nil && strings.EqualFold(field.Name, name) {
+			byName = field.Index
+		}
+	}
+	return byName, byName != nil
+}
+
+//tagColumn returns sqlx tag column name, i.e. foo for "foo,primaryKey" or "name=foo"
+func tagColumn(tag string) string {
+	for i, element := range strings.Split(tag, ",") {
+		element = strings.TrimSpace(element)
+		if strings.HasPrefix(strings.ToLower(element), "name=") {
+			return strings.TrimSpace(element[5:])
+		}
+		if i == 0 && !strings.Contains(element, "=") && element != "-" {
+			return element
+		}
+	}
+	return ""
+}
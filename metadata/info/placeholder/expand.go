@@ -0,0 +1,160 @@
+package placeholder
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+//IsSlice returns true if argument is expandable slice, []byte and driver.Valuer (i.e. pq.Array) are bound as is
+func IsSlice(arg interface{}) bool {
+	if _, ok := arg.(driver.Valuer); ok {
+		return false
+	}
+	if _, ok := arg.([]byte); ok {
+		return false
+	}
+	return arg != nil && reflect.TypeOf(arg).Kind() == reflect.Slice
+}
+
+//HasSlice returns true if any argument is expandable slice
+func HasSlice(args []interface{}) bool {
+	for _, arg := range args {
+		if IsSlice(arg) {
+			return true
+		}
+	}
+	return false
+}
+
+//Expand replaces '?' placeholder bound to slice argument with as many comma separated placeholders as slice items,
+//it returns expanded SQL and flattened arguments, empty slice returns error as no placeholders list is correct for both IN and NOT IN.
+//SQL is returned unchanged if placeholders do not match arguments
+func Expand(SQL string, args []interface{}) (string, []interface{}, error) {
+	positions := Positions(SQL)
+	if len(positions) != len(args) || !HasSlice(args) {
+		return SQL, args, nil
+	}
+	var result = make([]interface{}, 0, len(args))
+	var builder strings.Builder
+	offset := 0
+	for i, arg := range args {
+		if !IsSlice(arg) {
+			result = append(result, arg)
+			continue
+		}
+		builder.WriteString(SQL[offset:positions[i]])
+		offset = positions[i] + 1
+		slice := reflect.ValueOf(arg)
+		if slice.Len() == 0 {
+			return "", nil, fmt.Errorf("failed to expand argument %v: slice was empty", i)
+		}
+		for j := 0; j < slice.Len(); j++ {
+			if j > 0 {
+				builder.WriteString(", ")
+			}
+			builder.WriteString(Default)
+			result = append(result, slice.Index(j).Interface())
+		}
+	}
+	builder.WriteString(SQL[offset:])
+	return builder.String(), result, nil
+}
+
+//Pad returns args with slice arguments padded to power of two length by repeating the last item, so that expanded
+//statements are bucketed by list size, padding stops once flattened arguments count reaches limit (0 means no limit)
+func Pad(args []interface{}, limit int) []interface{} {
+	count := 0
+	for _, arg := range args {
+		if IsSlice(arg) {
+			count += reflect.ValueOf(arg).Len()
+			continue
+		}
+		count++
+	}
+	var result []interface{}
+	for i, arg := range args {
+		if !IsSlice(arg) {
+			continue
+		}
+		slice := reflect.ValueOf(arg)
+		size := slice.Len()
+		padded := 1
+		for padded < size {
+			padded <<= 1
+		}
+		if limit > 0 && count+padded-size > limit {
+			padded = size + limit - count
+		}
+		if size == 0 || padded <= size {
+			continue
+		}
+		count += padded - size
+		paddedSlice := reflect.MakeSlice(slice.Type(), padded, padded)
+		reflect.Copy(paddedSlice, slice)
+		for j := size; j < padded; j++ {
+			paddedSlice.Index(j).Set(slice.Index(size - 1))
+		}
+		if result == nil {
+			result = make([]interface{}, len(args))
+			copy(result, args)
+		}
+		result[i] = paddedSlice.Interface()
+	}
+	if result == nil {
+		return args
+	}
+	return result
+}
+
+//Largest returns index of the largest slice argument or -1 if there is no non empty slice argument
+func Largest(args []interface{}) int {
+	largest, largestLen := -1, 0
+	for i, arg := range args {
+		if !IsSlice(arg) {
+			continue
+		}
+		if size := reflect.ValueOf(arg).Len(); size > largestLen {
+			largest, largestLen = i, size
+		}
+	}
+	return largest
+}
+
+//Chunk splits the largest slice argument so that flattened arguments count does not exceed limit,
+//args are returned as the only chunk if limit is not exceeded or can not be met
+func Chunk(args []interface{}, limit int) [][]interface{} {
+	largest := Largest(args)
+	if limit <= 0 || largest == -1 {
+		return [][]interface{}{args}
+	}
+	count, largestLen := 0, reflect.ValueOf(args[largest]).Len()
+	for _, arg := range args {
+		if !IsSlice(arg) {
+			count++
+			continue
+		}
+		count += reflect.ValueOf(arg).Len()
+	}
+	if count <= limit {
+		return [][]interface{}{args}
+	}
+	chunkSize := limit - (count - largestLen)
+	if chunkSize <= 0 {
+		return [][]interface{}{args}
+	}
+	slice := reflect.ValueOf(args[largest])
+	var result [][]interface{}
+	for offset := 0; offset < largestLen; offset += chunkSize {
+		end := offset + chunkSize
+		if end > largestLen {
+			end = largestLen
+		}
+		chunk := make([]interface{}, len(args))
+		copy(chunk, args)
+		chunk[largest] = slice.Slice(offset, end).Interface()
+		result = append(result, chunk)
+	}
+	return result
+}
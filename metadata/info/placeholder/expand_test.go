@@ -0,0 +1,162 @@
+package placeholder
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestExpand(t *testing.T) {
+	var testCases = []struct {
+		description string
+		SQL         string
+		args        []interface{}
+		expectSQL   string
+		expectArgs  []interface{}
+		expectErr   bool
+	}{
+		{
+			description: "slice argument",
+			SQL:         "SELECT * FROM foo WHERE kind = ? AND id IN (?)",
+			args:        []interface{}{"k", []int{1, 2, 3}},
+			expectSQL:   "SELECT * FROM foo WHERE kind = ? AND id IN (?, ?, ?)",
+			expectArgs:  []interface{}{"k", 1, 2, 3},
+		},
+		{
+			description: "empty slice",
+			SQL:         "SELECT * FROM foo WHERE id IN (?)",
+			args:        []interface{}{[]string{}},
+			expectErr:   true,
+		},
+		{
+			description: "bytes are not expanded",
+			SQL:         "SELECT * FROM foo WHERE data = ?",
+			args:        []interface{}{[]byte("abc")},
+			expectSQL:   "SELECT * FROM foo WHERE data = ?",
+			expectArgs:  []interface{}{[]byte("abc")},
+		},
+		{
+			description: "placeholders mismatch",
+			SQL:         "SELECT * FROM foo WHERE id IN (?) AND '?' <> ''",
+			args:        []interface{}{[]int{1}, 2},
+			expectSQL:   "SELECT * FROM foo WHERE id IN (?) AND '?' <> ''",
+			expectArgs:  []interface{}{[]int{1}, 2},
+		},
+	}
+
+	for _, testCase := range testCases {
+		actualSQL, actualArgs, err := Expand(testCase.SQL, testCase.args)
+		if testCase.expectErr {
+			assert.NotNil(t, err, testCase.description)
+			continue
+		}
+		if !assert.Nil(t, err, testCase.description) {
+			continue
+		}
+		assert.Equal(t, testCase.expectSQL, actualSQL, testCase.description)
+		assert.Equal(t, testCase.expectArgs, actualArgs, testCase.description)
+	}
+}
+
+func TestChunk(t *testing.T) {
+	var testCases = []struct {
+		description string
+		args        []interface{}
+		limit       int
+		expect      [][]interface{}
+	}{
+		{
+			description: "within limit",
+			args:        []interface{}{"k", []int{1, 2}},
+			limit:       3,
+			expect:      [][]interface{}{{"k", []int{1, 2}}},
+		},
+		{
+			description: "largest slice split",
+			args:        []interface{}{"k", []int{1, 2, 3, 4, 5}, []int{6}},
+			limit:       4,
+			expect: [][]interface{}{
+				{"k", []int{1, 2}, []int{6}},
+				{"k", []int{3, 4}, []int{6}},
+				{"k", []int{5}, []int{6}},
+			},
+		},
+		{
+			description: "no limit",
+			args:        []interface{}{[]int{1, 2, 3}},
+			expect:      [][]interface{}{{[]int{1, 2, 3}}},
+		},
+	}
+
+	for _, testCase := range testCases {
+		assert.Equal(t, testCase.expect, Chunk(testCase.args, testCase.limit), testCase.description)
+	}
+}
+
+func TestLargest(t *testing.T) {
+	var testCases = []struct {
+		description string
+		args        []interface{}
+		expect      int
+	}{
+		{
+			description: "largest slice",
+			args:        []interface{}{"k", []int{1}, []string{"a", "b"}},
+			expect:      2,
+		},
+		{
+			description: "empty slice",
+			args:        []interface{}{"k", []int{}},
+			expect:      -1,
+		},
+		{
+			description: "no slice",
+			args:        []interface{}{"k", []byte("v")},
+			expect:      -1,
+		},
+	}
+
+	for _, testCase := range testCases {
+		assert.Equal(t, testCase.expect, Largest(testCase.args), testCase.description)
+	}
+}
+
+func TestPad(t *testing.T) {
+	var testCases = []struct {
+		description string
+		args        []interface{}
+		limit       int
+		expect      []interface{}
+	}{
+		{
+			description: "padded to power of two",
+			args:        []interface{}{"k", []int{1, 2, 3}, []string{"a", "b", "c", "d", "e"}},
+			expect:      []interface{}{"k", []int{1, 2, 3, 3}, []string{"a", "b", "c", "d", "e", "e", "e", "e"}},
+		},
+		{
+			description: "power of two length",
+			args:        []interface{}{[]int{1, 2}, []int{1}},
+			expect:      []interface{}{[]int{1, 2}, []int{1}},
+		},
+		{
+			description: "padding limited",
+			args:        []interface{}{"k", []int{1, 2, 3, 4, 5}},
+			limit:       7,
+			expect:      []interface{}{"k", []int{1, 2, 3, 4, 5, 5}},
+		},
+		{
+			description: "limit exceeded",
+			args:        []interface{}{"k", []int{1, 2, 3}},
+			limit:       3,
+			expect:      []interface{}{"k", []int{1, 2, 3}},
+		},
+		{
+			description: "empty slice and bytes",
+			args:        []interface{}{[]int{}, []byte("abc")},
+			expect:      []interface{}{[]int{}, []byte("abc")},
+		},
+	}
+
+	for _, testCase := range testCases {
+		assert.Equal(t, testCase.expect, Pad(testCase.args, testCase.limit), testCase.description)
+	}
+}
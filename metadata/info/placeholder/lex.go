@@ -0,0 +1,142 @@
+package placeholder
+
+import (
+	"bytes"
+	"github.com/viant/parsly"
+	"github.com/viant/parsly/matcher"
+)
+
+const (
+	quotedCode = iota
+	lineCommentCode
+	blockCommentCode
+	dollarQuotedCode
+	jsonOperatorCode
+	operatorCode
+	placeholderCode
+	namedCode
+)
+
+//quoted represents string literal or quoted identifier token
+var quoted = parsly.NewToken(quotedCode, "quoted", &quotes{
+	matcher.NewQuote('\'', '\''),
+	matcher.NewQuote('"', '"'),
+	matcher.NewQuote('`', '`'),
+})
+
+//lineComment represents -- comment token
+var lineComment = parsly.NewToken(lineCommentCode, "--", &comment{begin: []byte("--"), end: []byte("\n")})
+
+//blockComment represents /* */ comment token
+var blockComment = parsly.NewToken(blockCommentCode, "/* */", &comment{begin: []byte("/*"), end: []byte("*/")})
+
+//dollarQuoted represents PostgreSQL $tag$ quoted string token
+var dollarQuoted = parsly.NewToken(dollarQuotedCode, "$$", &dollarQuote{})
+
+//jsonOperator represents PostgreSQL ?| and ?& JSON operators token
+var jsonOperator = parsly.NewToken(jsonOperatorCode, "?| ?&", &jsonOperatorMatcher{})
+
+//operator represents :: cast, := assignment and @@ system variable prefix token
+var operator = parsly.NewToken(operatorCode, ":: := @@", matcher.NewFragments([]byte("::"), []byte(":="), []byte("@@")))
+
+//positional represents ? placeholder token
+var positional = parsly.NewToken(placeholderCode, Default, matcher.NewByte('?'))
+
+//named represents :name or @name parameter token
+var named = parsly.NewToken(namedCode, ":name", &namedMatcher{})
+
+type quotes []parsly.Matcher
+
+//Match matches any quoted fragment
+func (q quotes) Match(cursor *parsly.Cursor) int {
+	for _, candidate := range q {
+		if matched := candidate.Match(cursor); matched > 0 {
+			return matched
+		}
+	}
+	return 0
+}
+
+type comment struct {
+	begin []byte
+	end   []byte
+}
+
+//Match matches comment, unterminated comment spans till the end of input
+func (c *comment) Match(cursor *parsly.Cursor) int {
+	input := cursor.Input[cursor.Pos:]
+	if !bytes.HasPrefix(input, c.begin) {
+		return 0
+	}
+	index := bytes.Index(input[len(c.begin):], c.end)
+	if index == -1 {
+		return len(input)
+	}
+	return len(c.begin) + index + len(c.end)
+}
+
+type dollarQuote struct{}
+
+//Match matches $tag$ ... $tag$ quoted fragment
+func (d *dollarQuote) Match(cursor *parsly.Cursor) int {
+	input := cursor.Input[cursor.Pos:]
+	if input[0] != '$' {
+		return 0
+	}
+	i := 1
+	for ; i < len(input) && isNameByte(input[i], i == 1); i++ {
+	}
+	if i == len(input) || input[i] != '$' {
+		return 0
+	}
+	tag := input[:i+1]
+	index := bytes.Index(input[len(tag):], tag)
+	if index == -1 {
+		return 0
+	}
+	return 2*len(tag) + index
+}
+
+type jsonOperatorMatcher struct{}
+
+//Match matches ?| and ?& operators, ?|| is treated as placeholder followed by concatenation
+func (j *jsonOperatorMatcher) Match(cursor *parsly.Cursor) int {
+	input := cursor.Input[cursor.Pos:]
+	if len(input) < 2 || input[0] != '?' || (input[1] != '|' && input[1] != '&') {
+		return 0
+	}
+	if len(input) > 2 && input[2] == input[1] {
+		return 0
+	}
+	return 2
+}
+
+type namedMatcher struct{}
+
+//Match matches :name or @name parameter
+func (n *namedMatcher) Match(cursor *parsly.Cursor) int {
+	input := cursor.Input[cursor.Pos:]
+	if input[0] != ':' && input[0] != '@' {
+		return 0
+	}
+	if cursor.Pos > 0 && isNameByte(cursor.Input[cursor.Pos-1], false) {
+		return 0
+	}
+	i := 1
+	for ; i < len(input) && isNameByte(input[i], i == 1); i++ {
+	}
+	if i == 1 {
+		return 0
+	}
+	return i
+}
+
+func isNameByte(b byte, first bool) bool {
+	switch {
+	case b == '_', b >= 'a' && b <= 'z', b >= 'A' && b <= 'Z':
+		return true
+	case b >= '0' && b <= '9':
+		return !first
+	}
+	return false
+}
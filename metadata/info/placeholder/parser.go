@@ -0,0 +1,81 @@
+package placeholder
+
+import (
+	"github.com/viant/parsly"
+	"strings"
+)
+
+//Parameter represents SQL parameter
+type Parameter struct {
+	Name   string //parameter name, empty for '?' placeholder
+	Offset int
+	Size   int
+}
+
+//Parse returns '?' placeholders and :name, @name parameters, literals, quoted identifiers, comments,
+//PostgreSQL ?| ?& operators and :: casts are skipped
+func Parse(SQL string) []*Parameter {
+	var result []*Parameter
+	cursor := parsly.NewCursor("", []byte(SQL), 0)
+	for cursor.HasMore() {
+		matched := cursor.MatchAny(quoted, lineComment, blockComment, dollarQuoted, jsonOperator, operator, positional, named)
+		switch matched.Code {
+		case placeholderCode:
+			result = append(result, &Parameter{Offset: matched.Offset, Size: matched.Size})
+		case namedCode:
+			result = append(result, &Parameter{Name: SQL[matched.Offset+1 : matched.Offset+matched.Size], Offset: matched.Offset, Size: matched.Size})
+		case parsly.Invalid:
+			cursor.Pos++
+		}
+	}
+	return result
+}
+
+//Positions returns '?' placeholder positions
+func Positions(SQL string) []int {
+	var result []int
+	for _, parameter := range Parse(SQL) {
+		if parameter.Name == "" {
+			result = append(result, parameter.Offset)
+		}
+	}
+	return result
+}
+
+//Replace replaces '?' placeholders with placeholders returned by next
+func Replace(SQL string, next func() string) string {
+	positions := Positions(SQL)
+	if len(positions) == 0 {
+		return SQL
+	}
+	var builder strings.Builder
+	offset := 0
+	for _, position := range positions {
+		builder.WriteString(SQL[offset:position])
+		builder.WriteString(next())
+		offset = position + 1
+	}
+	builder.WriteString(SQL[offset:])
+	return builder.String()
+}
+
+//Named replaces :name and @name parameters with placeholders returned by next, it returns rewritten SQL and parameter names
+func Named(SQL string, next func() string) (string, []string) {
+	var names []string
+	var builder strings.Builder
+	offset := 0
+	for _, parameter := range Parse(SQL) {
+		if parameter.Name == "" {
+			continue
+		}
+		builder.WriteString(SQL[offset:parameter.Offset])
+		builder.WriteString(next())
+		offset = parameter.Offset + parameter.Size
+		names = append(names, parameter.Name)
+	}
+	if len(names) == 0 {
+		return SQL, nil
+	}
+	builder.WriteString(SQL[offset:])
+	return builder.String(), names
+}
@@ -0,0 +1,128 @@
+package placeholder
+
+import (
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestNamed(t *testing.T) {
+	var testCases = []struct {
+		description string
+		SQL         string
+		expectSQL   string
+		expectNames []string
+	}{
+		{
+			description: "colon and at parameters",
+			SQL:         "SELECT * FROM foo WHERE id = :id AND name = @name",
+			expectSQL:   "SELECT * FROM foo WHERE id = $1 AND name = $2",
+			expectNames: []string{"id", "name"},
+		},
+		{
+			description: "literals, quoted identifiers and comments",
+			SQL:         "SELECT ':x', \"@y\", `:z` /* :c */ FROM foo -- :d\nWHERE id = :id",
+			expectSQL:   "SELECT ':x', \"@y\", `:z` /* :c */ FROM foo -- :d\nWHERE id = $1",
+			expectNames: []string{"id"},
+		},
+		{
+			description: "casts, assignments and system variables",
+			SQL:         "SELECT :v::text, @@version, @n := 1 FROM foo",
+			expectSQL:   "SELECT $1::text, @@version, $2 := 1 FROM foo",
+			expectNames: []string{"v", "n"},
+		},
+		{
+			description: "escaped quote and dollar quoted string",
+			SQL:         "SELECT 'it''s :a', $fn$ :b $fn$ FROM foo WHERE id = :id",
+			expectSQL:   "SELECT 'it''s :a', $fn$ :b $fn$ FROM foo WHERE id = $1",
+			expectNames: []string{"id"},
+		},
+		{
+			description: "no parameters",
+			SQL:         "SELECT * FROM foo WHERE email = 'a@b.c'",
+			expectSQL:   "SELECT * FROM foo WHERE email = 'a@b.c'",
+		},
+	}
+
+	for _, testCase := range testCases {
+		i := 0
+		actualSQL, actualNames := Named(testCase.SQL, func() string {
+			i++
+			return fmt.Sprintf("$%v", i)
+		})
+		assert.Equal(t, testCase.expectSQL, actualSQL, testCase.description)
+		assert.Equal(t, testCase.expectNames, actualNames, testCase.description)
+	}
+}
+
+func TestPositions(t *testing.T) {
+	var testCases = []struct {
+		description string
+		SQL         string
+		expect      []int
+	}{
+		{
+			description: "placeholders",
+			SQL:         "SELECT * FROM foo WHERE a = ? AND b = ?",
+			expect:      []int{28, 38},
+		},
+		{
+			description: "literal, comment and json operators",
+			SQL:         "SELECT '?' FROM foo /* ? */ WHERE tags ?| ? AND tags ?& ? -- ?",
+			expect:      []int{42, 56},
+		},
+		{
+			description: "concatenation",
+			SQL:         "SELECT ?||'x'",
+			expect:      []int{7},
+		},
+	}
+
+	for _, testCase := range testCases {
+		assert.Equal(t, testCase.expect, Positions(testCase.SQL), testCase.description)
+	}
+}
+
+func TestBind(t *testing.T) {
+	type criteria struct {
+		ID   int    `sqlx:"name=foo_id"`
+		Name string `sqlx:"foo_name,required"`
+		Kind string
+	}
+	var testCases = []struct {
+		description string
+		names       []string
+		source      interface{}
+		expect      []interface{}
+		hasError    bool
+	}{
+		{
+			description: "struct",
+			names:       []string{"foo_id", "foo_name", "kind"},
+			source:      &criteria{ID: 1, Name: "n", Kind: "k"},
+			expect:      []interface{}{1, "n", "k"},
+		},
+		{
+			description: "map",
+			names:       []string{"id", "id"},
+			source:      map[string]interface{}{"id": 2},
+			expect:      []interface{}{2, 2},
+		},
+		{
+			description: "missing parameter",
+			names:       []string{"x"},
+			source:      map[string]interface{}{"id": 2},
+			hasError:    true,
+		},
+	}
+
+	for _, testCase := range testCases {
+		actual, err := Bind(testCase.names, testCase.source)
+		if testCase.hasError {
+			assert.NotNil(t, err, testCase.description)
+			continue
+		}
+		assert.Nil(t, err, testCase.description)
+		assert.Equal(t, testCase.expect, actual, testCase.description)
+	}
+}
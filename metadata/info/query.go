@@ -2,8 +2,8 @@ package info
 
 import (
 	"context"
-	"database/sql"
 	"fmt"
+	"github.com/viant/sqlx"
 	"github.com/viant/sqlx/metadata/database"
 )
 
@@ -21,7 +21,7 @@ type (
 
 	// Handler interface for handling pre- and post-query custom functions
 	Handler interface {
-		Handle(ctx context.Context, db *sql.DB, target interface{}, options ...interface{}) (doNext bool, err error)
+		Handle(ctx context.Context, db sqlx.Executor, target interface{}, options ...interface{}) (doNext bool, err error)
 		CanUse(options ...interface{}) bool
 	}
 
@@ -40,11 +40,11 @@ type (
 
 // DefaultHandler represents default handler, implements Handler interface
 type DefaultHandler struct {
-	fn func(ctx context.Context, db *sql.DB, target interface{}, options ...interface{}) (doNext bool, err error)
+	fn func(ctx context.Context, db sqlx.Executor, target interface{}, options ...interface{}) (doNext bool, err error)
 }
 
 // Handle default implementation Handler's Handle function
-func (h *DefaultHandler) Handle(ctx context.Context, db *sql.DB, target interface{}, options ...interface{}) (doNext bool, err error) {
+func (h *DefaultHandler) Handle(ctx context.Context, db sqlx.Executor, target interface{}, options ...interface{}) (doNext bool, err error) {
 	return h.fn(ctx, db, target, options...)
 }
 
@@ -54,7 +54,7 @@ func (h *DefaultHandler) CanUse(options ...interface{}) bool {
 }
 
 // NewHandler creates new DefaultHandler
-func NewHandler(fn func(ctx context.Context, db *sql.DB, target interface{}, options ...interface{}) (doNext bool, err error)) *DefaultHandler {
+func NewHandler(fn func(ctx context.Context, db sqlx.Executor, target interface{}, options ...interface{}) (doNext bool, err error)) *DefaultHandler {
 	return &DefaultHandler{
 		fn: fn,
 	}
@@ -63,7 +63,7 @@ func NewHandler(fn func(ctx context.Context, db *sql.DB, target interface{}, opt
 type nopHandler struct{}
 
 // Handle default implementation Handler's Handle function
-func (h *nopHandler) Handle(ctx context.Context, db *sql.DB, target interface{}, options ...interface{}) (doNext bool, err error) {
+func (h *nopHandler) Handle(ctx context.Context, db sqlx.Executor, target interface{}, options ...interface{}) (doNext bool, err error) {
 	return false, nil
 }
 
@@ -0,0 +1,15 @@
+package ansi
+
+import (
+	"errors"
+	ioerrors "github.com/viant/sqlx/io/errors"
+)
+
+// normalizeError wraps driver error exposing SQLSTATE code with typed error
+func normalizeError(err error) error {
+	var stateErr interface{ SQLState() string }
+	if errors.As(err, &stateErr) {
+		return ioerrors.New(ioerrors.StateKind(stateErr.SQLState()), &ioerrors.Cause{Err: err})
+	}
+	return err
+}
@@ -2,8 +2,8 @@ package handler
 
 import (
 	"context"
-	"database/sql"
 	"fmt"
+	"github.com/viant/sqlx"
 	"github.com/viant/sqlx/metadata/sink"
 	"github.com/viant/sqlx/option"
 	"strconv"
@@ -12,7 +12,7 @@ import (
 type table struct{}
 
 // Handle default implementation Handler's Handle function
-func (h *table) Handle(ctx context.Context, db *sql.DB, target interface{}, iopts ...interface{}) (doNext bool, err error) {
+func (h *table) Handle(ctx context.Context, db sqlx.Executor, target interface{}, iopts ...interface{}) (doNext bool, err error) {
 	destPtr, ok := target.(*[]*sink.Column)
 	var dest *[]sink.Column
 	if !ok {
@@ -23,11 +23,16 @@ func init() {
 		Product:                 ANSI,
 		Placeholder:             "?",
 		Transactional:           true,
+		NoRowValueIn:            true,
 		Insert:                  dialect.InsertWithSingleValues,
 		Upsert:                  dialect.UpsertTypeUnsupported,
 		Load:                    dialect.LoadTypeUnsupported,
 		PlaceholderResolver:     &placeholder.DefaultGenerator{},
 		DefaultPresetIDStrategy: dialect.PresetIDStrategyUndefined,
+		Savepoint:               "SAVEPOINT",
+		RollbackToSavepoint:     "ROLLBACK TO SAVEPOINT",
+		ReleaseSavepoint:        "RELEASE SAVEPOINT",
+		ErrorNormalizer:         normalizeError,
 	})
 	registry.Register(
 		info.NewQuery(info.KindVersion, "SELECT 1", ANSI).OnPre(info.NopHandler()),
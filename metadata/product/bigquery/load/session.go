@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"github.com/google/uuid"
 	vBigquery "github.com/viant/bigquery/reader"
+	"github.com/viant/sqlx"
 	"github.com/viant/sqlx/io"
 	readerCsv "github.com/viant/sqlx/io/load/reader/csv"
 	readerJson "github.com/viant/sqlx/io/load/reader/json"
@@ -51,7 +52,7 @@ func NewSession(dialect *info.Dialect) io.Session {
 }
 
 //Exec loads given data to database
-func (s *Session) Exec(ctx context.Context, data interface{}, db *sql.DB, tableName string, options ...option.Option) (sql.Result, error) {
+func (s *Session) Exec(ctx context.Context, data interface{}, db sqlx.Executor, tableName string, options ...option.Option) (sql.Result, error) {
 	loadFormat := option.Options(options).LoadFormat()
 	loadHint := option.Options(options).LoadHint()
 
@@ -72,7 +73,9 @@ func (s *Session) Exec(ctx context.Context, data interface{}, db *sql.DB, tableN
 
 	SQL := BuildSQL(loadFormat, readerID, loadHint, tableName)
 
-	return db.ExecContext(ctx, SQL)
+	return sqlx.InterceptExec(ctx, option.Options(options).Interceptor(), SQL, nil, func(ctx context.Context) (sql.Result, error) {
+		return db.ExecContext(ctx, SQL)
+	})
 }
 
 func (s *Session) getReader(loadFormat string, data interface{}) (goIo.Reader, error) {
@@ -217,6 +217,7 @@ SESSION_USER() AS USER_NAME,
 		Product:                 bigQuery,
 		Placeholder:             "?",
 		Transactional:           false, //only script is transactional
+		NoRowValueIn:            true,
 		Insert:                  dialect.InsertWithMultiValues,
 		Upsert:                  dialect.UpsertTypeMerge,
 		Load:                    dialect.LoadTypeLocalData,
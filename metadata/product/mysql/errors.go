@@ -0,0 +1,66 @@
+package mysql
+
+import (
+	"errors"
+	driver "github.com/go-sql-driver/mysql"
+	ioerrors "github.com/viant/sqlx/io/errors"
+	"regexp"
+	"strings"
+)
+
+const (
+	errNotNullNoDefault = 1364
+	errDuplicateEntry   = 1062
+	errNullColumn       = 1048
+	errRowIsReferenced  = 1451
+	errNoReferencedRow  = 1452
+	errCheckViolated    = 3819
+	errStatementTimeout = 3024
+)
+
+var (
+	duplicateKeyExpr = regexp.MustCompile("for key '([^']+)'")
+	foreignKeyExpr   = regexp.MustCompile("\\(`[^`]+`\\.`([^`]+)`, CONSTRAINT `([^`]+)` FOREIGN KEY \\(`([^`]+)`")
+	columnExpr       = regexp.MustCompile("(?:Column|Field) '([^']+)'")
+	checkExpr        = regexp.MustCompile("Check constraint '([^']+)'")
+)
+
+// normalizeError wraps MySQL driver error with typed error
+func normalizeError(err error) error {
+	var mysqlErr *driver.MySQLError
+	if !errors.As(err, &mysqlErr) {
+		return err
+	}
+	cause := &ioerrors.Cause{Err: err}
+	kind := ioerrors.KindUnknown
+	switch mysqlErr.Number {
+	case errDuplicateEntry:
+		kind = ioerrors.KindUniqueViolation
+		if match := duplicateKeyExpr.FindStringSubmatch(mysqlErr.Message); len(match) > 1 {
+			cause.Constraint = match[1]
+			if index := strings.LastIndex(match[1], "."); index != -1 { //MySQL 8 reports table.key
+				cause.Table, cause.Constraint = match[1][:index], match[1][index+1:]
+			}
+		}
+	case errRowIsReferenced, errNoReferencedRow:
+		kind = ioerrors.KindForeignKeyViolation
+		if match := foreignKeyExpr.FindStringSubmatch(mysqlErr.Message); len(match) > 3 {
+			cause.Table, cause.Constraint, cause.Column = match[1], match[2], match[3]
+		}
+	case errNullColumn, errNotNullNoDefault:
+		kind = ioerrors.KindNotNullViolation
+		if match := columnExpr.FindStringSubmatch(mysqlErr.Message); len(match) > 1 {
+			cause.Column = match[1]
+		}
+	case errCheckViolated:
+		kind = ioerrors.KindCheckViolation
+		if match := checkExpr.FindStringSubmatch(mysqlErr.Message); len(match) > 1 {
+			cause.Constraint = match[1]
+		}
+	case errDeadlock:
+		kind = ioerrors.KindDeadlock
+	case errLockWaitTimeout, errStatementTimeout:
+		kind = ioerrors.KindTimeout
+	}
+	return ioerrors.New(kind, cause)
+}
@@ -0,0 +1,86 @@
+package mysql
+
+import (
+	driver "github.com/go-sql-driver/mysql"
+	"github.com/stretchr/testify/assert"
+	ioerrors "github.com/viant/sqlx/io/errors"
+	"testing"
+)
+
+func TestNormalizeError(t *testing.T) {
+	var testCases = []struct {
+		description string
+		err         error
+		expect      interface{}
+		cause       ioerrors.Cause
+	}{
+		{
+			description: "duplicate entry",
+			err:         &driver.MySQLError{Number: 1062, Message: "Duplicate entry 'abc' for key 'foo.uq_name'"},
+			expect:      &ioerrors.UniqueViolation{},
+			cause:       ioerrors.Cause{Constraint: "uq_name", Table: "foo"},
+		},
+		{
+			description: "foreign key",
+			err:         &driver.MySQLError{Number: 1452, Message: "Cannot add or update a child row: a foreign key constraint fails (`db`.`bar`, CONSTRAINT `fk_foo` FOREIGN KEY (`foo_id`) REFERENCES `foo` (`id`))"},
+			expect:      &ioerrors.ForeignKeyViolation{},
+			cause:       ioerrors.Cause{Constraint: "fk_foo", Table: "bar", Column: "foo_id"},
+		},
+		{
+			description: "not null",
+			err:         &driver.MySQLError{Number: 1048, Message: "Column 'name' cannot be null"},
+			expect:      &ioerrors.NotNullViolation{},
+			cause:       ioerrors.Cause{Column: "name"},
+		},
+		{
+			description: "check",
+			err:         &driver.MySQLError{Number: 3819, Message: "Check constraint 'ck_qty' is violated."},
+			expect:      &ioerrors.CheckViolation{},
+			cause:       ioerrors.Cause{Constraint: "ck_qty"},
+		},
+		{
+			description: "deadlock",
+			err:         &driver.MySQLError{Number: 1213, Message: "Deadlock found when trying to get lock"},
+			expect:      &ioerrors.Deadlock{},
+		},
+		{
+			description: "lock wait timeout",
+			err:         &driver.MySQLError{Number: 1205, Message: "Lock wait timeout exceeded"},
+			expect:      &ioerrors.Timeout{},
+		},
+		{
+			description: "syntax error",
+			err:         &driver.MySQLError{Number: 1064, Message: "You have an error in your SQL syntax"},
+		},
+	}
+
+	for _, testCase := range testCases {
+		actual := normalizeError(testCase.err)
+		if testCase.expect == nil {
+			assert.Equal(t, testCase.err, actual, testCase.description)
+			continue
+		}
+		assert.IsType(t, testCase.expect, actual, testCase.description)
+		cause := testCase.cause
+		cause.Err = testCase.err
+		assert.Equal(t, cause, *causeOf(actual), testCase.description)
+	}
+}
+
+func causeOf(err error) *ioerrors.Cause {
+	switch actual := err.(type) {
+	case *ioerrors.UniqueViolation:
+		return &actual.Cause
+	case *ioerrors.ForeignKeyViolation:
+		return &actual.Cause
+	case *ioerrors.NotNullViolation:
+		return &actual.Cause
+	case *ioerrors.CheckViolation:
+		return &actual.Cause
+	case *ioerrors.Deadlock:
+		return &actual.Cause
+	case *ioerrors.Timeout:
+		return &actual.Cause
+	}
+	return nil
+}
@@ -5,6 +5,7 @@ import (
 	"database/sql"
 	"github.com/go-sql-driver/mysql"
 	"github.com/google/uuid"
+	"github.com/viant/sqlx"
 	"github.com/viant/sqlx/io"
 	"github.com/viant/sqlx/io/load/reader/csv"
 	"github.com/viant/sqlx/metadata/info"
@@ -36,7 +37,7 @@ func NewSession(dialect *info.Dialect) io.Session {
 
 //Exec inserts given data to database using "LOAD DATA LOCAL INFILE"
 //note: local_infile=1 must be enabled on database
-func (s *Session) Exec(ctx context.Context, data interface{}, db *sql.DB, tableName string, options ...option.Option) (sql.Result, error) {
+func (s *Session) Exec(ctx context.Context, data interface{}, db sqlx.Executor, tableName string, options ...option.Option) (sql.Result, error) {
 	dataReader, dataType, err := csv.NewReader(data, mysqlLoadConfig)
 	if err != nil {
 		return nil, err
@@ -61,11 +62,12 @@ func (s *Session) Exec(ctx context.Context, data interface{}, db *sql.DB, tableN
 	SQL := BuildSQL(mysqlLoadConfig, readerID, tableName, columns)
 
 	result := &io.QueryResult{}
-	if s.Transaction != nil {
-		result.Result, err = s.Transaction.ExecContext(ctx, SQL)
-	} else {
-		result.Result, err = db.ExecContext(ctx, SQL)
-	}
+	result.Result, err = sqlx.InterceptExec(ctx, option.Options(options).Interceptor(), SQL, nil, func(ctx context.Context) (sql.Result, error) {
+		if s.Transaction != nil {
+			return s.Transaction.ExecContext(ctx, SQL)
+		}
+		return db.ExecContext(ctx, SQL)
+	})
 	err = s.end(err)
 
 	if err != nil {
@@ -82,7 +84,7 @@ func (s *Session) Exec(ctx context.Context, data interface{}, db *sql.DB, tableN
 	return result, err
 }
 
-func (s *Session) begin(ctx context.Context, db *sql.DB, options []option.Option) error {
+func (s *Session) begin(ctx context.Context, db sqlx.Executor, options []option.Option) error {
 	var err error
 	s.Transaction, err = io.TransactionFor(ctx, s.dialect, db, options)
 	if err != nil {
@@ -270,9 +270,11 @@ RELEASE_LOCK('$Args[0].$Args[1].$Args[2]')  AS SUCCESS`,
 	registry.RegisterDialect(&info.Dialect{
 		Product:                   mySQL5,
 		Placeholder:               "?",
+		MaxParameters:             65535,
 		Transactional:             true,
 		Insert:                    dialect.InsertWithMultiValues,
 		Upsert:                    dialect.UpsertTypeInsertOrUpdate,
+		Update:                    dialect.UpdateTypeCase,
 		Load:                      dialect.LoadTypeLocalData,
 		SpecialKeywordEscapeQuote: '`',
 		QuoteCharacter:            '\'',
@@ -281,6 +283,11 @@ RELEASE_LOCK('$Args[0].$Args[1].$Args[2]')  AS SUCCESS`,
 		// TODO: provide real autoincrement function
 		AutoincrementFunc:       "autoincrement",
 		DefaultPresetIDStrategy: dialect.PresetIDWithTransientTransaction,
+		Savepoint:               "SAVEPOINT",
+		RollbackToSavepoint:     "ROLLBACK TO SAVEPOINT",
+		ReleaseSavepoint:        "RELEASE SAVEPOINT",
+		TransientError:          isTransient,
+		ErrorNormalizer:         normalizeError,
 	})
 
 }
@@ -4,6 +4,7 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
+	"github.com/viant/sqlx"
 	"github.com/viant/sqlx/metadata/sink"
 	"github.com/viant/sqlx/option"
 	"strconv"
@@ -19,7 +20,7 @@ const autoincrementColumnDef = " NOT NULL AUTO_INCREMENT,"
 // Warning!
 // Until we don't use autoincrement in the table (by insert at least one row with 0-value id), "show create table" and "information_schema.tables"
 // show wrong autoincrement value if @@SESSION.auto_increment_increment > 1
-func UpdateMySQLSequence(ctx context.Context, db *sql.DB, target interface{}, iopts ...interface{}) (doNext bool, err error) {
+func UpdateMySQLSequence(ctx context.Context, db sqlx.Executor, target interface{}, iopts ...interface{}) (doNext bool, err error) {
 
 	options := option.AsOptions(iopts)
 	tx := options.Tx()
@@ -37,7 +38,7 @@ func UpdateMySQLSequence(ctx context.Context, db *sql.DB, target interface{}, io
 	return false, nil
 }
 
-func updateSequence(ctx context.Context, db *sql.DB, sequence *sink.Sequence, tx *sql.Tx) error {
+func updateSequence(ctx context.Context, db sqlx.Executor, sequence *sink.Sequence, tx *sql.Tx) error {
 	var name, DDL string
 	SQL := buildShowCreate([]interface{}{sequence.Catalog, sequence.Schema, sequence.Name})
 
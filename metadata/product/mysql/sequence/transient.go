@@ -4,6 +4,7 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
+	"github.com/viant/sqlx"
 	"github.com/viant/sqlx/io"
 	"github.com/viant/sqlx/metadata"
 	"github.com/viant/sqlx/metadata/info"
@@ -18,7 +19,7 @@ import (
 type Transient struct{}
 
 // Handle sets new autoincrement value by inserting row using new transaction finished by rollback, uses locking
-func (n *Transient) Handle(ctx context.Context, db *sql.DB, target interface{}, iopts ...interface{}) (doNext bool, err error) {
+func (n *Transient) Handle(ctx context.Context, db sqlx.Executor, target interface{}, iopts ...interface{}) (doNext bool, err error) {
 
 	meta := metadata.New()
 	options := option.AsOptions(iopts)
@@ -33,7 +34,11 @@ func (n *Transient) Handle(ctx context.Context, db *sql.DB, target interface{},
 		return false, fmt.Errorf("invalid target, expected :%T, but had: %T", targetSequence, target)
 	}
 
-	tx, err := db.BeginTx(ctx, nil)
+	beginner, ok := db.(sqlx.TxBeginner)
+	if !ok {
+		return false, fmt.Errorf("unable to begin transient transaction with %T", db)
+	}
+	tx, err := beginner.BeginTx(ctx, nil)
 	if err != nil {
 		return false, err
 	}
@@ -95,7 +100,7 @@ func (n *Transient) turnFkKeyCheck(tx *sql.Tx, flag int) error {
 	return err
 }
 
-func (n *Transient) lock(ctx context.Context, meta *metadata.Service, db *sql.DB, options option.Options) error {
+func (n *Transient) lock(ctx context.Context, meta *metadata.Service, db sqlx.Executor, options option.Options) error {
 	result := sink.Lock{}
 	argsOps := options.Args()
 	if argsOps == nil {
@@ -111,7 +116,7 @@ func (n *Transient) lock(ctx context.Context, meta *metadata.Service, db *sql.DB
 	return nil
 }
 
-func (n *Transient) unlock(ctx context.Context, meta *metadata.Service, db *sql.DB, options option.Options) error {
+func (n *Transient) unlock(ctx context.Context, meta *metadata.Service, db sqlx.Executor, options option.Options) error {
 	result := sink.Lock{}
 	argsOps := options.Args()
 	if argsOps == nil {
@@ -19,7 +19,7 @@ type Udf struct{}
 // and using internal autoincrement value handling
 //
 // all this handler requires more testing (especially with transactions)
-func (n *Udf) Handle(ctx context.Context, db *sql.DB, target interface{}, iopts ...interface{}) (doNext bool, err error) {
+func (n *Udf) Handle(ctx context.Context, db sqlx.Executor, target interface{}, iopts ...interface{}) (doNext bool, err error) {
 	options := option.AsOptions(iopts)
 	recordCount := options.RecordCount()
 	if recordCount == 0 {
@@ -91,7 +91,7 @@ func (n *Udf) CanUse(iopts ...interface{}) bool {
 	return options.PresetIDStrategy() == dialect.PresetIDWithUDFSequence
 }
 
-func runQuery(ctx context.Context, db *sql.DB, SQL string, trg []interface{}, tx *sql.Tx) (err error) {
+func runQuery(ctx context.Context, db sqlx.Executor, SQL string, trg []interface{}, tx *sql.Tx) (err error) {
 	var rows *sql.Rows
 
 	if tx != nil {
@@ -0,0 +1,20 @@
+package mysql
+
+import (
+	"errors"
+	driver "github.com/go-sql-driver/mysql"
+)
+
+const (
+	errLockWaitTimeout = 1205
+	errDeadlock        = 1213
+)
+
+// isTransient returns true for deadlock and lock wait timeout errors
+func isTransient(err error) bool {
+	var mysqlErr *driver.MySQLError
+	if !errors.As(err, &mysqlErr) {
+		return false
+	}
+	return mysqlErr.Number == errDeadlock || mysqlErr.Number == errLockWaitTimeout
+}
@@ -0,0 +1,63 @@
+package oracle
+
+import (
+	ioerrors "github.com/viant/sqlx/io/errors"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+const (
+	errUniqueViolated     = 1
+	errResourceBusy       = 54
+	errCannotInsertNull   = 1400
+	errCannotUpdateNull   = 1407
+	errUserCancel         = 1013
+	errCheckViolated      = 2290
+	errParentKeyNotFound  = 2291
+	errChildRecordFound   = 2292
+	errWaitTimeoutExpired = 30006
+)
+
+var (
+	codeExpr       = regexp.MustCompile(`ORA-(\d{5})`)
+	constraintExpr = regexp.MustCompile(`constraint \(([^)]+)\)`)
+	nullColumnExpr = regexp.MustCompile(`NULL into \(([^)]+)\)`)
+)
+
+// normalizeError wraps Oracle driver error with typed error, error code is parsed from ORA-NNNNN message prefix
+func normalizeError(err error) error {
+	message := err.Error()
+	match := codeExpr.FindStringSubmatch(message)
+	if len(match) < 2 {
+		return err
+	}
+	code, _ := strconv.Atoi(match[1])
+	cause := &ioerrors.Cause{Err: err}
+	kind := ioerrors.KindUnknown
+	switch code {
+	case errUniqueViolated:
+		kind = ioerrors.KindUniqueViolation
+	case errParentKeyNotFound, errChildRecordFound:
+		kind = ioerrors.KindForeignKeyViolation
+	case errCheckViolated:
+		kind = ioerrors.KindCheckViolation
+	case errCannotInsertNull, errCannotUpdateNull:
+		kind = ioerrors.KindNotNullViolation
+		if match := nullColumnExpr.FindStringSubmatch(message); len(match) > 1 {
+			path := strings.Split(strings.ReplaceAll(match[1], `"`, ""), ".")
+			cause.Column = path[len(path)-1]
+			if len(path) > 1 {
+				cause.Table = path[len(path)-2]
+			}
+		}
+	case errDeadlock:
+		kind = ioerrors.KindDeadlock
+	case errResourceBusy, errUserCancel, errWaitTimeoutExpired:
+		kind = ioerrors.KindTimeout
+	}
+	if match := constraintExpr.FindStringSubmatch(message); len(match) > 1 {
+		cause.Constraint = match[1]
+	}
+	return ioerrors.New(kind, cause)
+}
@@ -0,0 +1,261 @@
+package oracle
+
+import (
+	"github.com/viant/sqlx/metadata/database"
+	"github.com/viant/sqlx/metadata/info"
+	"github.com/viant/sqlx/metadata/info/dialect"
+	"github.com/viant/sqlx/metadata/product/oracle/sequence"
+	"github.com/viant/sqlx/metadata/registry"
+	"log"
+)
+
+const product = "Oracle"
+
+var oracle = database.Product{
+	Name:      product,
+	DriverPkg: "godror",
+	Driver:    "OracleDriver",
+}
+
+//Oracle return Oracle product
+func Oracle() *database.Product {
+	return &oracle
+}
+
+func init() {
+	err := registry.Register(
+		info.NewQuery(info.KindVersion, `SELECT 'Oracle ' || VERSION FROM PRODUCT_COMPONENT_VERSION WHERE PRODUCT LIKE 'Oracle%' AND ROWNUM = 1`, oracle),
+
+		info.NewQuery(info.KindCatalogs, `SELECT SYS_CONTEXT('USERENV', 'DB_NAME') AS CATALOG_NAME FROM DUAL`, oracle),
+
+		info.NewQuery(info.KindCatalog, `SELECT CATALOG_NAME FROM (SELECT SYS_CONTEXT('USERENV', 'DB_NAME') AS CATALOG_NAME FROM DUAL)`,
+			oracle,
+			info.NewCriterion(info.Catalog, "CATALOG_NAME"),
+		),
+
+		info.NewQuery(info.KindCurrentSchema, `SELECT SYS_CONTEXT('USERENV', 'CURRENT_SCHEMA') AS SCHEMA_NAME FROM DUAL`, oracle),
+
+		info.NewQuery(info.KindSchemas, `SELECT
+SYS_CONTEXT('USERENV', 'DB_NAME') AS CATALOG_NAME,
+USERNAME AS SCHEMA_NAME
+FROM ALL_USERS`,
+			oracle,
+			info.NewCriterion(info.Catalog, ""),
+		),
+
+		info.NewQuery(info.KindSchema, `SELECT
+SYS_CONTEXT('USERENV', 'DB_NAME') AS CATALOG_NAME,
+USERNAME AS SCHEMA_NAME
+FROM ALL_USERS`,
+			oracle,
+			info.NewCriterion(info.Catalog, ""),
+			info.NewCriterion(info.Schema, "USERNAME"),
+		),
+
+		info.NewQuery(info.KindTables, `SELECT
+SYS_CONTEXT('USERENV', 'DB_NAME') AS TABLE_CATALOG,
+t.OWNER AS TABLE_SCHEMA,
+t.TABLE_NAME,
+'TABLE' AS TABLE_TYPE,
+NVL(t.NUM_ROWS, 0) AS TABLE_ROWS
+FROM ALL_TABLES t`,
+			oracle,
+			info.NewCriterion(info.Catalog, ""),
+			info.NewCriterion(info.Schema, "t.OWNER"),
+		),
+
+		info.NewQuery(info.KindTable, `SELECT
+SYS_CONTEXT('USERENV', 'DB_NAME') AS TABLE_CATALOG,
+c.OWNER AS TABLE_SCHEMA,
+c.TABLE_NAME,
+c.COLUMN_NAME,
+c.COLUMN_ID AS ORDINAL_POSITION,
+c.DATA_TYPE,
+c.CHAR_LENGTH AS CHARACTER_MAXIMUM_LENGTH,
+c.DATA_PRECISION AS NUMERIC_PRECISION,
+c.DATA_SCALE AS NUMERIC_SCALE,
+CASE WHEN c.NULLABLE = 'Y' THEN 'YES' ELSE 'NO' END AS IS_NULLABLE,
+CASE WHEN c.IDENTITY_COLUMN = 'YES' THEN 1 ELSE 0 END AS IS_AUTOINCREMENT
+FROM ALL_TAB_COLUMNS c`,
+			oracle,
+			info.NewCriterion(info.Catalog, ""),
+			info.NewCriterion(info.Schema, "c.OWNER"),
+			info.NewCriterion(info.Table, "c.TABLE_NAME"),
+		),
+
+		info.NewQuery(info.KindViews, `SELECT
+SYS_CONTEXT('USERENV', 'DB_NAME') AS TABLE_CATALOG,
+v.OWNER AS TABLE_SCHEMA,
+v.VIEW_NAME AS TABLE_NAME,
+'VIEW' AS TABLE_TYPE
+FROM ALL_VIEWS v`,
+			oracle,
+			info.NewCriterion(info.Catalog, ""),
+			info.NewCriterion(info.Schema, "v.OWNER"),
+		),
+
+		info.NewQuery(info.KindView, `SELECT
+SYS_CONTEXT('USERENV', 'DB_NAME') AS TABLE_CATALOG,
+c.OWNER AS TABLE_SCHEMA,
+c.TABLE_NAME,
+c.COLUMN_NAME,
+c.COLUMN_ID AS ORDINAL_POSITION,
+c.DATA_TYPE,
+c.CHAR_LENGTH AS CHARACTER_MAXIMUM_LENGTH,
+c.DATA_PRECISION AS NUMERIC_PRECISION,
+c.DATA_SCALE AS NUMERIC_SCALE,
+CASE WHEN c.NULLABLE = 'Y' THEN 'YES' ELSE 'NO' END AS IS_NULLABLE
+FROM ALL_TAB_COLUMNS c
+JOIN ALL_VIEWS v ON v.OWNER = c.OWNER AND v.VIEW_NAME = c.TABLE_NAME`,
+			oracle,
+			info.NewCriterion(info.Catalog, ""),
+			info.NewCriterion(info.Schema, "c.OWNER"),
+			info.NewCriterion(info.View, "c.TABLE_NAME"),
+		),
+
+		info.NewQuery(info.KindPrimaryKeys, `SELECT
+c.CONSTRAINT_NAME,
+'PRIMARY KEY' AS CONSTRAINT_TYPE,
+SYS_CONTEXT('USERENV', 'DB_NAME') AS CONSTRAINT_CATALOG,
+c.OWNER AS CONSTRAINT_SCHEMA,
+c.TABLE_NAME,
+k.POSITION AS ORDINAL_POSITION,
+k.COLUMN_NAME
+FROM ALL_CONSTRAINTS c
+JOIN ALL_CONS_COLUMNS k ON k.OWNER = c.OWNER AND k.CONSTRAINT_NAME = c.CONSTRAINT_NAME
+WHERE c.CONSTRAINT_TYPE = 'P'`,
+			oracle,
+			info.NewCriterion(info.Catalog, ""),
+			info.NewCriterion(info.Schema, "c.OWNER"),
+			info.NewCriterion(info.Table, "c.TABLE_NAME"),
+		),
+
+		info.NewQuery(info.KindForeignKeys, `SELECT
+c.CONSTRAINT_NAME,
+'FOREIGN KEY' AS CONSTRAINT_TYPE,
+SYS_CONTEXT('USERENV', 'DB_NAME') AS CONSTRAINT_CATALOG,
+c.OWNER AS CONSTRAINT_SCHEMA,
+c.TABLE_NAME,
+k.POSITION AS ORDINAL_POSITION,
+k.COLUMN_NAME,
+r.TABLE_NAME AS REFERENCED_TABLE_NAME,
+rk.COLUMN_NAME AS REFERENCED_COLUMN_NAME,
+r.OWNER AS REFERENCED_TABLE_SCHEMA,
+c.DELETE_RULE AS ON_DELETE
+FROM ALL_CONSTRAINTS c
+JOIN ALL_CONS_COLUMNS k ON k.OWNER = c.OWNER AND k.CONSTRAINT_NAME = c.CONSTRAINT_NAME
+JOIN ALL_CONSTRAINTS r ON r.OWNER = c.R_OWNER AND r.CONSTRAINT_NAME = c.R_CONSTRAINT_NAME
+JOIN ALL_CONS_COLUMNS rk ON rk.OWNER = r.OWNER AND rk.CONSTRAINT_NAME = r.CONSTRAINT_NAME AND rk.POSITION = k.POSITION
+WHERE c.CONSTRAINT_TYPE = 'R'`,
+			oracle,
+			info.NewCriterion(info.Catalog, ""),
+			info.NewCriterion(info.Schema, "c.OWNER"),
+			info.NewCriterion(info.Table, "c.TABLE_NAME"),
+		),
+
+		info.NewQuery(info.KindIndexes, `SELECT
+SYS_CONTEXT('USERENV', 'DB_NAME') AS TABLE_CATALOG,
+i.TABLE_OWNER AS TABLE_SCHEMA,
+i.TABLE_NAME,
+i.OWNER AS INDEX_SCHEMA,
+i.INDEX_NAME,
+i.INDEX_TYPE,
+CASE WHEN i.UNIQUENESS = 'UNIQUE' THEN '1' ELSE '0' END AS INDEX_UNIQUE,
+LISTAGG(c.COLUMN_NAME, ',') WITHIN GROUP (ORDER BY c.COLUMN_POSITION) AS INDEX_COLUMNS
+FROM ALL_INDEXES i
+JOIN ALL_IND_COLUMNS c ON c.INDEX_OWNER = i.OWNER AND c.INDEX_NAME = i.INDEX_NAME
+$WHERE
+GROUP BY i.TABLE_OWNER, i.TABLE_NAME, i.OWNER, i.INDEX_NAME, i.INDEX_TYPE, i.UNIQUENESS`,
+			oracle,
+			info.NewCriterion(info.Catalog, ""),
+			info.NewCriterion(info.Schema, "i.TABLE_OWNER"),
+			info.NewCriterion(info.Table, "i.TABLE_NAME"),
+		),
+
+		info.NewQuery(info.KindIndex, `SELECT
+SYS_CONTEXT('USERENV', 'DB_NAME') AS TABLE_CATALOG,
+c.TABLE_OWNER AS TABLE_SCHEMA,
+c.TABLE_NAME,
+c.INDEX_NAME,
+c.COLUMN_NAME,
+c.DESCEND AS DESCENDING,
+c.COLUMN_POSITION AS INDEX_POSITION
+FROM ALL_IND_COLUMNS c`,
+			oracle,
+			info.NewCriterion(info.Catalog, ""),
+			info.NewCriterion(info.Schema, "c.TABLE_OWNER"),
+			info.NewCriterion(info.Table, "c.TABLE_NAME"),
+			info.NewCriterion(info.Index, "c.INDEX_NAME"),
+		),
+
+		info.NewQuery(info.KindSequences, `SELECT
+SYS_CONTEXT('USERENV', 'DB_NAME') AS SEQUENCE_CATALOG,
+s.SEQUENCE_OWNER AS SEQUENCE_SCHEMA,
+s.SEQUENCE_NAME,
+s.LAST_NUMBER AS SEQUENCE_VALUE,
+s.INCREMENT_BY,
+'NUMBER' AS DATA_TYPE,
+s.MIN_VALUE AS START_VALUE,
+LEAST(s.MAX_VALUE, 9223372036854775807) AS MAX_VALUE
+FROM ALL_SEQUENCES s`,
+			oracle,
+			info.NewCriterion(info.Catalog, ""),
+			info.NewCriterion(info.Schema, "s.SEQUENCE_OWNER"),
+			info.NewCriterion(info.Sequence, "s.SEQUENCE_NAME"),
+		),
+
+		info.NewQuery(info.KindFunctions, `SELECT
+SYS_CONTEXT('USERENV', 'DB_NAME') AS ROUTINE_CATALOG,
+o.OWNER AS ROUTINE_SCHEMA,
+o.OBJECT_NAME AS ROUTINE_NAME,
+o.OBJECT_TYPE AS ROUTINE_TYPE,
+CASE WHEN p.DETERMINISTIC = 'YES' THEN 'YES' ELSE 'NO' END AS IS_DETERMINISTIC
+FROM ALL_OBJECTS o
+LEFT JOIN ALL_PROCEDURES p ON p.OWNER = o.OWNER AND p.OBJECT_NAME = o.OBJECT_NAME AND p.PROCEDURE_NAME IS NULL
+WHERE o.OBJECT_TYPE IN ('FUNCTION', 'PROCEDURE')`,
+			oracle,
+			info.NewCriterion(info.Catalog, ""),
+			info.NewCriterion(info.Schema, "o.OWNER"),
+			info.NewCriterion(info.Function, "o.OBJECT_NAME"),
+		),
+
+		info.NewQuery(info.KindSession, `SELECT
+SYS_CONTEXT('USERENV', 'SID') AS PID,
+USER AS USER_NAME,
+SYS_CONTEXT('USERENV', 'DB_NAME') AS CATALOG_NAME,
+SYS_CONTEXT('USERENV', 'CURRENT_SCHEMA') AS SCHEMA_NAME
+FROM DUAL`, oracle),
+
+		info.NewQuery(info.KindSequenceNextValue, `SELECT 1 FROM DUAL`,
+			oracle,
+			info.NewCriterion(info.Catalog, ""),
+			info.NewCriterion(info.Schema, ""),
+			info.NewCriterion(info.Object, ""),
+			info.NewCriterion(info.SequenceNewCurrentValue, ""),
+		).OnPre(&sequence.Next{}),
+	)
+	if err != nil {
+		log.Printf("failed to register queries: %v", err)
+	}
+
+	registry.RegisterDialect(&info.Dialect{
+		Product:                 oracle,
+		Placeholder:             ":",
+		Transactional:           true,
+		Insert:                  dialect.InsertWithSingleValues,
+		Upsert:                  dialect.UpsertTypeMergeInto,
+		Load:                    dialect.LoadTypeUnsupported,
+		Pagination:              dialect.PaginationFetchFirst,
+		CanAutoincrement:        true,
+		CanLastInsertID:         false,
+		CanReturningInto:        true,
+		QuoteCharacter:          '\'',
+		PlaceholderResolver:     &PlaceholderGenerator{},
+		DefaultPresetIDStrategy: dialect.PresetIDWithSequence,
+		Savepoint:               "SAVEPOINT",
+		RollbackToSavepoint:     "ROLLBACK TO SAVEPOINT",
+		TransientError:          isTransient,
+		ErrorNormalizer:         normalizeError,
+		DualTable:               "DUAL",
+	})
+}
@@ -0,0 +1,139 @@
+package oracle_test
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"github.com/viant/sqlx/metadata"
+	"github.com/viant/sqlx/metadata/info"
+	_ "github.com/viant/sqlx/metadata/product/oracle"
+	"github.com/viant/sqlx/metadata/registry"
+	"github.com/viant/sqlx/metadata/sink"
+	"github.com/viant/sqlx/option"
+	"testing"
+)
+
+func TestService_Info(t *testing.T) {
+	var testCases = []struct {
+		description string
+		kind        info.Kind
+		args        []interface{}
+		sink        func() interface{}
+		expect      interface{}
+	}{
+		{
+			description: "table columns",
+			kind:        info.KindTable,
+			args:        []interface{}{"", "APP", "EVENTS"},
+			sink:        func() interface{} { return &[]sink.Column{} },
+			expect: []string{
+				"ID", "NAME",
+			},
+		},
+		{
+			description: "primary keys",
+			kind:        info.KindPrimaryKeys,
+			args:        []interface{}{"", "APP", "EVENTS"},
+			sink:        func() interface{} { return &[]sink.Key{} },
+			expect:      []string{"ID"},
+		},
+		{
+			description: "indexes",
+			kind:        info.KindIndexes,
+			args:        []interface{}{"", "APP", "EVENTS"},
+			sink:        func() interface{} { return &[]sink.Index{} },
+			expect:      []string{"ID"},
+		},
+	}
+
+	for _, testCase := range testCases {
+		db, _, err := newFixtureDB("testdata/fixtures.json")
+		if !assert.Nil(t, err, testCase.description) {
+			continue
+		}
+		meta := metadata.New()
+		product, err := meta.DetectProduct(context.Background(), db)
+		if !assert.Nil(t, err, testCase.description) {
+			continue
+		}
+		assert.EqualValues(t, "Oracle", product.Name, testCase.description)
+		assert.EqualValues(t, 19, product.Major, testCase.description)
+
+		target := testCase.sink()
+		err = meta.Info(context.Background(), db, testCase.kind, target, option.NewArgs(testCase.args...))
+		if !assert.Nil(t, err, testCase.description) {
+			continue
+		}
+		var actual []string
+		switch items := target.(type) {
+		case *[]sink.Column:
+			for _, item := range *items {
+				actual = append(actual, item.Name)
+			}
+		case *[]sink.Key:
+			for _, item := range *items {
+				actual = append(actual, item.Column)
+			}
+		case *[]sink.Index:
+			for _, item := range *items {
+				actual = append(actual, item.Columns)
+			}
+		}
+		assert.EqualValues(t, testCase.expect, actual, testCase.description)
+	}
+}
+
+func TestService_SequenceNextValue(t *testing.T) {
+	var testCases = []struct {
+		description string
+		sequence    string
+		expectValue int64
+		expectMin   int64
+		expectErr   bool
+	}{
+		{
+			description: "consecutive values",
+			sequence:    "EVENTS_SEQ",
+			expectValue: 13,
+			expectMin:   10,
+		},
+		{
+			description: "values taken by concurrent session",
+			sequence:    "GAPS_SEQ",
+			expectErr:   true,
+		},
+	}
+	for _, testCase := range testCases {
+		db, fixtures, err := newFixtureDB("testdata/fixtures.json")
+		if !assert.Nil(t, err, testCase.description) {
+			continue
+		}
+		meta := metadata.New()
+		sequence := &sink.Sequence{}
+		err = meta.Info(context.Background(), db, info.KindSequenceNextValue, sequence, option.NewArgs("", "APP", testCase.sequence), option.RecordCount(3))
+		for _, executed := range fixtures.executed {
+			assert.NotContains(t, executed, "ALTER SEQUENCE", testCase.description)
+		}
+		if testCase.expectErr {
+			assert.NotNil(t, err, testCase.description)
+			continue
+		}
+		if !assert.Nil(t, err, testCase.description) {
+			continue
+		}
+		assert.EqualValues(t, testCase.sequence, sequence.Name, testCase.description)
+		assert.EqualValues(t, testCase.expectValue, sequence.Value, testCase.description)
+		assert.EqualValues(t, testCase.expectMin, sequence.MinValue(3), testCase.description)
+	}
+}
+
+func TestDialect(t *testing.T) {
+	product := registry.Products()["oracle"]
+	if !assert.NotNil(t, product) {
+		return
+	}
+	dialect := registry.LookupDialect(product)
+	if !assert.NotNil(t, dialect) {
+		return
+	}
+	assert.EqualValues(t, "SELECT * FROM foo WHERE id = :1 AND name = :2", dialect.EnsurePlaceholders("SELECT * FROM foo WHERE id = ? AND name = ?"))
+}
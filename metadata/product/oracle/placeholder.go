@@ -0,0 +1,35 @@
+package oracle
+
+import "strconv"
+
+//PlaceholderGenerator represents placeholder
+type PlaceholderGenerator struct {
+}
+
+//Resolver returns Oracle placeholder
+func (p *PlaceholderGenerator) Resolver() func() string {
+	counter := 0
+	return func() string {
+		counter++
+		return ":" + strconv.Itoa(counter)
+	}
+}
+
+//Len calculates length of placeholders
+//might be used to alocate in advance required slice length
+func (p *PlaceholderGenerator) Len(start, numOfPlaceholders int) int {
+	result := 0
+	for lower, digits := 1, 1; lower <= numOfPlaceholders; lower, digits = lower*10, digits+1 {
+		from, to := lower, lower*10-1
+		if from <= start {
+			from = start + 1
+		}
+		if to > numOfPlaceholders {
+			to = numOfPlaceholders
+		}
+		if from <= to {
+			result += (to - from + 1) * (digits + 1)
+		}
+	}
+	return result
+}
@@ -0,0 +1,105 @@
+package sequence
+
+import (
+	"context"
+	"fmt"
+	"github.com/viant/sqlx"
+	"github.com/viant/sqlx/io"
+	"github.com/viant/sqlx/metadata"
+	"github.com/viant/sqlx/metadata/info"
+	"github.com/viant/sqlx/metadata/sink"
+	"github.com/viant/sqlx/option"
+	"sort"
+)
+
+// Next represents struct used to reserve next sequence values
+// with NEXTVAL call per reserved value
+type Next struct{}
+
+// reserveAttempts represents max attempts to reserve consecutive sequence values
+const reserveAttempts = 3
+
+// Handle reserves record count consecutive sequence values with single query calling NEXTVAL for each value,
+// concurrent session can take values in between, values are reserved again in that case,
+// sequence value is set to the one following the last reserved value, as the inserter assigns values preceding it
+func (n *Next) Handle(ctx context.Context, db sqlx.Executor, target interface{}, iopts ...interface{}) (doNext bool, err error) {
+	meta := metadata.New()
+	options := option.AsOptions(iopts)
+
+	argsOps := options.Args()
+	if argsOps == nil {
+		return false, fmt.Errorf("argsOps was empty")
+	}
+
+	targetSequence, ok := target.(*sink.Sequence)
+	if !ok {
+		return false, fmt.Errorf("invalid target, expected :%T, but had: %T", targetSequence, target)
+	}
+
+	sequence := sink.Sequence{}
+	if err = meta.Info(ctx, db, info.KindSequences, &sequence, options...); err != nil {
+		return false, err
+	}
+	if sequence.Name == "" {
+		return false, fmt.Errorf("failed to lookup sequence: %v", argsOps.Unwrap())
+	}
+
+	incrementBy := sequence.IncrementBy
+	if incrementBy == 0 {
+		incrementBy = 1
+	}
+	count := options.RecordCount()
+	if count < 1 {
+		count = 1
+	}
+	name := sequence.Name
+	if sequence.Schema != "" {
+		name = sequence.Schema + "." + name
+	}
+
+	for attempt := 1; attempt <= reserveAttempts; attempt++ {
+		lastValue, consecutive, err := reserve(ctx, db, name, count, incrementBy)
+		if err != nil {
+			return false, err
+		}
+		if !consecutive {
+			continue
+		}
+		sequence.Value = lastValue + incrementBy
+		sequence.IncrementBy = incrementBy
+		*targetSequence = sequence
+		return false, nil
+	}
+	return false, fmt.Errorf("failed to reserve %v consecutive values of sequence %v", count, name)
+}
+
+// reserve returns the last of count sequence values and true if values are consecutive
+func reserve(ctx context.Context, db sqlx.Executor, name string, count int64, incrementBy int64) (lastValue int64, consecutive bool, err error) {
+	rows, err := db.QueryContext(ctx, "SELECT "+name+".NEXTVAL FROM DUAL CONNECT BY LEVEL <= :1", count)
+	if err != nil {
+		return 0, false, err
+	}
+	defer io.RunWithError(rows.Close, &err)
+	var values []int64
+	for rows.Next() {
+		var value int64
+		if err = rows.Scan(&value); err != nil {
+			return 0, false, err
+		}
+		values = append(values, value)
+	}
+	if err = rows.Err(); err != nil {
+		return 0, false, err
+	}
+	if int64(len(values)) != count {
+		return 0, false, fmt.Errorf("failed to reserve sequence %v values, expected %v, but had %v", name, count, len(values))
+	}
+	sort.Slice(values, func(i, j int) bool { return (values[i] < values[j]) == (incrementBy > 0) })
+	firstValue, lastValue := values[0], values[len(values)-1]
+	return lastValue, lastValue-firstValue == (count-1)*incrementBy, nil
+}
+
+// CanUse returns true if Handle function can be executed
+func (n *Next) CanUse(iopts ...interface{}) bool {
+	return true
+}
@@ -0,0 +1,21 @@
+package oracle
+
+import "errors"
+
+const (
+	errDeadlock        = 60   //ORA-00060
+	errCannotSerialize = 8177 //ORA-08177
+)
+
+// isTransient returns true for deadlock and serialization failure errors
+func isTransient(err error) bool {
+	var codeErr interface{ Code() int }
+	if !errors.As(err, &codeErr) {
+		return false
+	}
+	switch codeErr.Code() {
+	case errDeadlock, errCannotSerialize:
+		return true
+	}
+	return false
+}
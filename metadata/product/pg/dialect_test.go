@@ -1,6 +1,8 @@
 package pg
 
 import (
+	"fmt"
+	"github.com/lib/pq"
 	"github.com/stretchr/testify/assert"
 	"github.com/viant/sqlx/metadata/info"
 	"testing"
@@ -28,3 +30,22 @@ func TestDialect_EnsurePlaceholders(t *testing.T) {
 	}
 
 }
+
+func TestDialect_IsTransient(t *testing.T) {
+	var testCases = []struct {
+		description string
+		err         error
+		expect      bool
+	}{
+		{description: "serialization failure", err: &pq.Error{Code: "40001"}, expect: true},
+		{description: "wrapped deadlock", err: fmt.Errorf("failed to update: %w", &pq.Error{Code: "40P01"}), expect: true},
+		{description: "unique violation", err: &pq.Error{Code: "23505"}},
+		{description: "generic error", err: fmt.Errorf("40001")},
+		{description: "nil error"},
+	}
+
+	for _, testCase := range testCases {
+		dialect := info.Dialect{TransientError: isTransient}
+		assert.Equal(t, testCase.expect, dialect.IsTransient(testCase.err), testCase.description)
+	}
+}
@@ -0,0 +1,20 @@
+package pg
+
+import (
+	"errors"
+	"github.com/lib/pq"
+	ioerrors "github.com/viant/sqlx/io/errors"
+)
+
+// normalizeError wraps PostgreSQL driver error with typed error
+func normalizeError(err error) error {
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) {
+		return ioerrors.New(ioerrors.StateKind(string(pqErr.Code)), &ioerrors.Cause{Constraint: pqErr.Constraint, Table: pqErr.Table, Column: pqErr.Column, Err: err})
+	}
+	var stateErr interface{ SQLState() string }
+	if errors.As(err, &stateErr) {
+		return ioerrors.New(ioerrors.StateKind(stateErr.SQLState()), &ioerrors.Cause{Err: err})
+	}
+	return err
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"database/sql"
 	"github.com/lib/pq"
+	"github.com/viant/sqlx"
 	"github.com/viant/sqlx/io"
 	"github.com/viant/sqlx/io/read"
 	"github.com/viant/sqlx/metadata/info"
@@ -21,7 +22,7 @@ type Session struct {
 }
 
 //Exec inserts data to table using "Copy in"
-func (s *Session) Exec(ctx context.Context, data interface{}, db *sql.DB, tableName string, options ...option.Option) (sql.Result, error) {
+func (s *Session) Exec(ctx context.Context, data interface{}, db sqlx.Executor, tableName string, options ...option.Option) (sql.Result, error) {
 	dataAccessor, size, err := io.Values(data)
 	if err != nil {
 		return nil, err
@@ -44,7 +45,8 @@ func (s *Session) Exec(ctx context.Context, data interface{}, db *sql.DB, tableN
 		return nil, err
 	}
 
-	stmt, err := s.Transaction.Prepare(pq.CopyIn(tableName, names...))
+	SQL := pq.CopyIn(tableName, names...)
+	stmt, err := s.Transaction.Prepare(SQL)
 	if err != nil {
 		return nil, s.end(err)
 	}
@@ -54,7 +56,9 @@ func (s *Session) Exec(ctx context.Context, data interface{}, db *sql.DB, tableN
 		return result, s.end(err)
 	}
 
-	exec, err := stmt.ExecContext(ctx)
+	exec, err := sqlx.InterceptExec(ctx, option.Options(options).Interceptor(), SQL, nil, func(ctx context.Context) (sql.Result, error) {
+		return stmt.ExecContext(ctx)
+	})
 	return exec, s.end(err)
 
 }
@@ -84,7 +88,7 @@ func (s *Session) mapColumnsToLowerCasedNames(columns []io.Column) []string {
 	return names
 }
 
-func (s *Session) begin(ctx context.Context, db *sql.DB, options []option.Option) error {
+func (s *Session) begin(ctx context.Context, db sqlx.Executor, options []option.Option) error {
 	if err := s.ensureTransaction(ctx, options, db); err != nil {
 		return err
 	}
@@ -92,7 +96,7 @@ func (s *Session) begin(ctx context.Context, db *sql.DB, options []option.Option
 	return nil
 }
 
-func (s *Session) ensureTransaction(ctx context.Context, options option.Options, db *sql.DB) error {
+func (s *Session) ensureTransaction(ctx context.Context, options option.Options, db sqlx.Executor) error {
 	var err error
 	s.Transaction, err = io.TransactionFor(ctx, s.dialect, db, options)
 	if err != nil {
@@ -259,15 +259,23 @@ WHERE pid=pg_backend_pid() LIMIT 1;
 		Placeholder:             "$",
 		Transactional:           true,
 		Insert:                  dialect.InsertWithMultiValues,
-		Upsert:                  dialect.UpsertTypeMergeInto,
+		Upsert:                  dialect.UpsertTypeInsertOnConflict,
+		Update:                  dialect.UpdateTypeValuesJoin,
 		Load:                    dialect.LoadTypeUnsupported,
 		CanAutoincrement:        true,
 		CanLastInsertID:         false,
 		CanReturning:            true,
+		UpsertInserted:          "(xmax = 0)",
 		QuoteCharacter:          '\'',
 		PlaceholderResolver:     &PlaceholderGenerator{},
+		MaxParameters:           65535,
 		AutoincrementFunc:       "nextval",
 		DefaultPresetIDStrategy: dialect.PresetIDStrategyUndefined,
+		Savepoint:               "SAVEPOINT",
+		RollbackToSavepoint:     "ROLLBACK TO SAVEPOINT",
+		ReleaseSavepoint:        "RELEASE SAVEPOINT",
+		TransientError:          isTransient,
+		ErrorNormalizer:         normalizeError,
 	})
 
 }
@@ -2,8 +2,9 @@ package sequence
 
 import (
 	"context"
-	"database/sql"
 	"fmt"
+	"github.com/viant/sqlx"
+	"github.com/viant/sqlx/io"
 	"github.com/viant/sqlx/metadata"
 	"github.com/viant/sqlx/metadata/info"
 	"github.com/viant/sqlx/metadata/sink"
@@ -15,16 +16,20 @@ import (
 type Next struct{}
 
 // Handle sets new autoincrement value by inserting row using new transaction finished by rollback, uses locking
-func (n *Next) Handle(ctx context.Context, db *sql.DB, target interface{}, iopts ...interface{}) (doNext bool, err error) {
+func (n *Next) Handle(ctx context.Context, db sqlx.Executor, target interface{}, iopts ...interface{}) (doNext bool, err error) {
 
 	meta := metadata.New()
 	options := option.AsOptions(iopts)
 
-	tx, err := db.BeginTx(ctx, nil)
+	tx, err := io.BeginTransaction(ctx, db, nil)
 	if err != nil {
 		return false, err
 	}
-	defer tx.Commit()
+	var executor = db
+	if tx != nil {
+		executor = tx
+		defer tx.Commit()
+	}
 
 	argsOps := options.Args()
 	if argsOps == nil {
@@ -50,7 +55,7 @@ func (n *Next) Handle(ctx context.Context, db *sql.DB, target interface{}, iopts
 		sequence.Value + count,
 	}
 	DML := fmt.Sprintf("SELECT setval('%s_id_seq', ?, true)", sequence.Name)
-	row := tx.QueryRowContext(ctx, DML, args...)
+	row := executor.QueryRowContext(ctx, DML, args...)
 	val := 0
 	if err = row.Scan(&val); err != nil {
 		return false, err
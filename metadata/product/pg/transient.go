@@ -0,0 +1,21 @@
+package pg
+
+import "errors"
+
+const (
+	serializationFailure = "40001"
+	deadlockDetected     = "40P01"
+)
+
+// isTransient returns true for serialization failure and deadlock errors
+func isTransient(err error) bool {
+	var stateErr interface{ SQLState() string }
+	if !errors.As(err, &stateErr) {
+		return false
+	}
+	switch stateErr.SQLState() {
+	case serializationFailure, deadlockDetected:
+		return true
+	}
+	return false
+}
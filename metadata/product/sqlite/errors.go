@@ -0,0 +1,51 @@
+package sqlite
+
+import (
+	ioerrors "github.com/viant/sqlx/io/errors"
+	"strings"
+)
+
+const (
+	uniqueFailed     = "UNIQUE constraint failed: "
+	notNullFailed    = "NOT NULL constraint failed: "
+	checkFailed      = "CHECK constraint failed: "
+	foreignKeyFailed = "FOREIGN KEY constraint failed"
+	databaseLocked   = "database is locked"
+	tableLocked      = "database table is locked"
+)
+
+// normalizeError wraps SQLite driver error with typed error, SQLite reports constraint details in error message only
+func normalizeError(err error) error {
+	message := err.Error()
+	cause := &ioerrors.Cause{Err: err}
+	kind := ioerrors.KindUnknown
+	switch {
+	case strings.Contains(message, uniqueFailed):
+		kind = ioerrors.KindUniqueViolation
+		cause.Table, cause.Column = tableColumn(message, uniqueFailed)
+	case strings.Contains(message, notNullFailed):
+		kind = ioerrors.KindNotNullViolation
+		cause.Table, cause.Column = tableColumn(message, notNullFailed)
+	case strings.Contains(message, checkFailed):
+		kind = ioerrors.KindCheckViolation
+		cause.Constraint = strings.TrimSpace(message[strings.Index(message, checkFailed)+len(checkFailed):])
+	case strings.Contains(message, foreignKeyFailed):
+		kind = ioerrors.KindForeignKeyViolation
+	case strings.Contains(message, databaseLocked), strings.Contains(message, tableLocked):
+		kind = ioerrors.KindTimeout
+	}
+	return ioerrors.New(kind, cause)
+}
+
+// tableColumn returns first table and column listed after prefix, i.e. UNIQUE constraint failed: foo.id, foo.name
+func tableColumn(message, prefix string) (string, string) {
+	columns := message[strings.Index(message, prefix)+len(prefix):]
+	if index := strings.Index(columns, ","); index != -1 {
+		columns = columns[:index]
+	}
+	columns = strings.TrimSpace(columns)
+	if index := strings.Index(columns, "."); index != -1 {
+		return columns[:index], columns[index+1:]
+	}
+	return "", columns
+}
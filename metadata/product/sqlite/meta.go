@@ -26,6 +26,14 @@ var sqLite333 = database.Product{
 	Driver:    "SQLiteDriver",
 }
 
+var sqLite335 = database.Product{
+	Name:      product,
+	Major:     3,
+	Minor:     35,
+	DriverPkg: "sqlite3",
+	Driver:    "SQLiteDriver",
+}
+
 //SQLite3 return SQLite3 product
 func SQLite3() *database.Product {
 	return &sqLite3
@@ -38,6 +46,7 @@ func SQLiteMaj3Min33() *database.Product {
 func init() {
 	registerProduct(sqLite333, "sqlite_master")
 	registerProduct(sqLite3, "sqlite_schema")
+	registry.RegisterDialect(newDialect(sqLite335))
 }
 
 func registerProduct(product database.Product, schemaTable string) {
@@ -206,16 +215,28 @@ FROM pragma_database_list
 		log.Printf("failed to register queries: %v", err)
 	}
 
-	registry.RegisterDialect(&info.Dialect{
+	registry.RegisterDialect(newDialect(product))
+}
+
+//newDialect returns SQLite dialect, RETURNING clause is supported since 3.35
+func newDialect(product database.Product) *info.Dialect {
+	return &info.Dialect{
 		Product:                 product,
 		Placeholder:             "?",
+		MaxParameters:           999,
 		Transactional:           true,
 		QuoteCharacter:          '\'',
 		Insert:                  dialect.InsertWithMultiValues,
-		Upsert:                  dialect.UpsertTypeInsertOrReplace,
+		Upsert:                  dialect.UpsertTypeInsertOnConflict,
+		Update:                  dialect.UpdateTypeCase,
 		Load:                    dialect.LoadTypeUnsupported,
 		CanAutoincrement:        true,
 		CanLastInsertID:         true,
+		CanReturning:            product.Major > 3 || product.Minor >= 35,
 		DefaultPresetIDStrategy: dialect.PresetIDStrategyUndefined,
-	})
+		Savepoint:               "SAVEPOINT",
+		RollbackToSavepoint:     "ROLLBACK TO SAVEPOINT",
+		ReleaseSavepoint:        "RELEASE SAVEPOINT",
+		ErrorNormalizer:         normalizeError,
+	}
 }
@@ -2,8 +2,8 @@ package sequence
 
 import (
 	"context"
-	"database/sql"
 	"fmt"
+	"github.com/viant/sqlx"
 	"github.com/viant/sqlx/metadata/info/dialect"
 	"github.com/viant/sqlx/metadata/sink"
 	"github.com/viant/sqlx/option"
@@ -14,7 +14,7 @@ import (
 type Max struct{}
 
 // Handle sets new autoincrement value using simple "SELECT MAX(ID)" approach
-func (n *Max) Handle(ctx context.Context, db *sql.DB, target interface{}, iopts ...interface{}) (doNext bool, err error) {
+func (n *Max) Handle(ctx context.Context, db sqlx.Executor, target interface{}, iopts ...interface{}) (doNext bool, err error) {
 	options := option.AsOptions(iopts)
 
 	recordCount := options.RecordCount()
@@ -2,8 +2,9 @@ package sequence
 
 import (
 	"context"
-	"database/sql"
 	"fmt"
+	"github.com/viant/sqlx"
+	"github.com/viant/sqlx/io"
 	"github.com/viant/sqlx/metadata"
 	"github.com/viant/sqlx/metadata/info"
 	"github.com/viant/sqlx/metadata/sink"
@@ -15,16 +16,20 @@ import (
 type Next struct{}
 
 // Handle sets new autoincrement value by inserting row using new transaction finished by rollback, uses locking
-func (n *Next) Handle(ctx context.Context, db *sql.DB, target interface{}, iopts ...interface{}) (doNext bool, err error) {
+func (n *Next) Handle(ctx context.Context, db sqlx.Executor, target interface{}, iopts ...interface{}) (doNext bool, err error) {
 
 	meta := metadata.New()
 	options := option.AsOptions(iopts)
 
-	tx, err := db.BeginTx(ctx, nil)
+	tx, err := io.BeginTransaction(ctx, db, nil)
 	if err != nil {
 		return false, err
 	}
-	defer tx.Commit()
+	var executor = db
+	if tx != nil {
+		executor = tx
+		defer tx.Commit()
+	}
 
 	argsOps := options.Args()
 	if argsOps == nil {
@@ -55,7 +60,7 @@ func (n *Next) Handle(ctx context.Context, db *sql.DB, target interface{}, iopts
 	if sequence.Value == 0 {
 		DML = "INSERT INTO sqlite_sequence (seq, name) VALUES (?,?)"
 	}
-	_, err = tx.ExecContext(ctx, DML, args...)
+	_, err = executor.ExecContext(ctx, DML, args...)
 	if err != nil {
 		return false, err
 	}
@@ -0,0 +1,63 @@
+package sqlserver
+
+import (
+	"errors"
+	ioerrors "github.com/viant/sqlx/io/errors"
+	"regexp"
+)
+
+const (
+	errCannotInsertNull   = 515
+	errConstraintConflict = 547
+	errDuplicateIndexKey  = 2601
+	errDuplicateKey       = 2627
+	errLockTimeout        = 1222
+)
+
+var (
+	duplicateKeyExpr   = regexp.MustCompile(`constraint '([^']+)'.*object '([^']+)'`)
+	duplicateIndexExpr = regexp.MustCompile(`object '([^']+)' with unique index '([^']+)'`)
+	conflictExpr       = regexp.MustCompile(`conflicted with the (FOREIGN KEY|REFERENCE|CHECK|SAME TABLE REFERENCE) constraint "([^"]+)".*?table "([^"]+)"(?:, column '([^']+)')?`)
+	nullColumnExpr     = regexp.MustCompile(`column '([^']+)', table '([^']+)'`)
+)
+
+// normalizeError wraps SQL Server driver error with typed error
+func normalizeError(err error) error {
+	var numberErr interface{ SQLErrorNumber() int32 }
+	if !errors.As(err, &numberErr) {
+		return err
+	}
+	message := numberErr.(error).Error()
+	cause := &ioerrors.Cause{Err: err}
+	kind := ioerrors.KindUnknown
+	switch numberErr.SQLErrorNumber() {
+	case errDuplicateKey:
+		kind = ioerrors.KindUniqueViolation
+		if match := duplicateKeyExpr.FindStringSubmatch(message); len(match) > 2 {
+			cause.Constraint, cause.Table = match[1], match[2]
+		}
+	case errDuplicateIndexKey:
+		kind = ioerrors.KindUniqueViolation
+		if match := duplicateIndexExpr.FindStringSubmatch(message); len(match) > 2 {
+			cause.Table, cause.Constraint = match[1], match[2]
+		}
+	case errConstraintConflict:
+		kind = ioerrors.KindForeignKeyViolation
+		if match := conflictExpr.FindStringSubmatch(message); len(match) > 4 {
+			if match[1] == "CHECK" {
+				kind = ioerrors.KindCheckViolation
+			}
+			cause.Constraint, cause.Table, cause.Column = match[2], match[3], match[4]
+		}
+	case errCannotInsertNull:
+		kind = ioerrors.KindNotNullViolation
+		if match := nullColumnExpr.FindStringSubmatch(message); len(match) > 2 {
+			cause.Column, cause.Table = match[1], match[2]
+		}
+	case errDeadlockVictim:
+		kind = ioerrors.KindDeadlock
+	case errLockTimeout:
+		kind = ioerrors.KindTimeout
+	}
+	return ioerrors.New(kind, cause)
+}
@@ -5,6 +5,7 @@ import (
 	"database/sql"
 	"encoding/json"
 	mssql "github.com/denisenkom/go-mssqldb"
+	"github.com/viant/sqlx"
 	"github.com/viant/sqlx/io"
 	"github.com/viant/sqlx/metadata"
 	"github.com/viant/sqlx/metadata/info"
@@ -28,7 +29,7 @@ func NewSession(dialect *info.Dialect) io.Session {
 
 // Exec inserts given data to database using "LOAD DATA LOCAL INFILE"
 // note: local_infile=1 must be enabled on database
-func (s *Session) Exec(ctx context.Context, data interface{}, db *sql.DB, tableName string, options ...option.Option) (sql.Result, error) {
+func (s *Session) Exec(ctx context.Context, data interface{}, db sqlx.Executor, tableName string, options ...option.Option) (sql.Result, error) {
 	loadHint := option.Options(options).LoadHint()
 
 	var bulkOptions = mssql.BulkOptions{}
@@ -83,7 +84,9 @@ func (s *Session) Exec(ctx context.Context, data interface{}, db *sql.DB, tableN
 		}
 	}
 
-	res, err := stmt.Exec()
+	res, err := sqlx.InterceptExec(ctx, option.Options(options).Interceptor(), SQL, nil, func(ctx context.Context) (sql.Result, error) {
+		return stmt.ExecContext(ctx)
+	})
 	if err != nil {
 		return nil, err
 	}
@@ -101,7 +104,7 @@ func (s *Session) Exec(ctx context.Context, data interface{}, db *sql.DB, tableN
 	return res, nil
 }
 
-func (s *Session) getMetaColumns(db *sql.DB, tableName string) ([]io.Column, error) {
+func (s *Session) getMetaColumns(db sqlx.Executor, tableName string) ([]io.Column, error) {
 	meta := metadata.New()
 
 	options := option.Options{s.dialect}
@@ -139,7 +142,7 @@ func asArgs(xStruct *xunsafe.Struct, record interface{}) []interface{} {
 	return args
 }
 
-func (s *Session) begin(ctx context.Context, db *sql.DB, options []option.Option) error {
+func (s *Session) begin(ctx context.Context, db sqlx.Executor, options []option.Option) error {
 	var err error
 	s.Transaction, err = io.TransactionFor(ctx, s.dialect, db, options)
 	if err != nil {
@@ -497,15 +497,24 @@ FROM @T
 		Product:                 sqlServer,
 		Placeholder:             "@p",
 		Transactional:           true,
+		NoRowValueIn:            true,
 		Insert:                  dialect.InsertWithMultiValues,
 		Upsert:                  dialect.UpsertTypeMergeInto,
 		Load:                    dialect.LoadTypeLocalData,
+		Pagination:              dialect.PaginationTop,
 		QuoteCharacter:          '\'',
 		CanAutoincrement:        true,
 		CanLastInsertID:         false, //TODO ???
+		CanOutputInserted:       true,
 		AutoincrementFunc:       "",
 		PlaceholderResolver:     new(PlaceHolderGenerator),
+		MaxParameters:           2100,
 		DefaultPresetIDStrategy: dialect.PresetIDStrategyUndefined,
+		Savepoint:               "SAVE TRANSACTION",
+		RollbackToSavepoint:     "ROLLBACK TRANSACTION",
+		TransientError:          isTransient,
+		ErrorNormalizer:         normalizeError,
+		StatementTerminator:     ";",
 	})
 }
 
@@ -21,5 +21,9 @@ func (p *PlaceHolderGenerator) Resolver() func() string {
 //Len calculates length of placeholders
 //might be used to allocate in advance required slice length
 func (p *PlaceHolderGenerator) Len(start, numOfPlaceholders int) int {
-	return numOfPlaceholders - start
+	result := 0
+	for i := start + 1; i <= start+numOfPlaceholders; i++ {
+		result += len("@p") + len(strconv.Itoa(i))
+	}
+	return result
 }
@@ -0,0 +1,21 @@
+package sqlserver
+
+import "errors"
+
+const (
+	errDeadlockVictim  = 1205
+	errSnapshotUpdated = 3960
+)
+
+// isTransient returns true for deadlock victim and snapshot isolation update conflict errors
+func isTransient(err error) bool {
+	var numberErr interface{ SQLErrorNumber() int32 }
+	if !errors.As(err, &numberErr) {
+		return false
+	}
+	switch numberErr.SQLErrorNumber() {
+	case errDeadlockVictim, errSnapshotUpdated:
+		return true
+	}
+	return false
+}
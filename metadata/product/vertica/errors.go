@@ -0,0 +1,35 @@
+package vertica
+
+import (
+	"errors"
+	vertigo "github.com/vertica/vertica-sql-go"
+	ioerrors "github.com/viant/sqlx/io/errors"
+	"regexp"
+)
+
+const nullValueNotAllowed = "22004"
+
+var (
+	constraintExpr = regexp.MustCompile(`violates constraint '([^']+)'`)
+	nullColumnExpr = regexp.MustCompile(`NOT NULL column \(([^)]+)\)`)
+)
+
+// normalizeError wraps Vertica driver error with typed error
+func normalizeError(err error) error {
+	var vErr *vertigo.VError
+	if !errors.As(err, &vErr) {
+		return err
+	}
+	cause := &ioerrors.Cause{Err: err}
+	kind := ioerrors.StateKind(vErr.SQLState)
+	if vErr.SQLState == nullValueNotAllowed {
+		kind = ioerrors.KindNotNullViolation
+	}
+	if match := constraintExpr.FindStringSubmatch(vErr.Message); len(match) > 1 {
+		cause.Constraint = match[1]
+	}
+	if match := nullColumnExpr.FindStringSubmatch(vErr.Message); len(match) > 1 {
+		cause.Column = match[1]
+	}
+	return ioerrors.New(kind, cause)
+}
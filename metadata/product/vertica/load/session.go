@@ -4,6 +4,7 @@ import (
 	"context"
 	"database/sql"
 	vcontext "github.com/vertica/vertica-sql-go"
+	"github.com/viant/sqlx"
 	"github.com/viant/sqlx/io"
 	"github.com/viant/sqlx/io/load/reader/csv"
 	"github.com/viant/sqlx/metadata/info"
@@ -36,7 +37,7 @@ func NewSession(dialect *info.Dialect) io.Session {
 }
 
 //Exec inserts given data to database using "COPY FROM STDIN "
-func (s *Session) Exec(ctx context.Context, data interface{}, db *sql.DB, tableName string, options ...option.Option) (sql.Result, error) {
+func (s *Session) Exec(ctx context.Context, data interface{}, db sqlx.Executor, tableName string, options ...option.Option) (sql.Result, error) {
 	dataReader, dataType, err := csv.NewReader(data, verticaLoadConfig)
 	if err != nil {
 		return nil, err
@@ -63,7 +64,9 @@ func (s *Session) Exec(ctx context.Context, data interface{}, db *sql.DB, tableN
 
 	result := &io.QueryResult{}
 	if s.Transaction != nil {
-		result.Result, err = s.Transaction.ExecContext(vCtx, SQL)
+		result.Result, err = sqlx.InterceptExec(vCtx, option.Options(options).Interceptor(), SQL, nil, func(ctx context.Context) (sql.Result, error) {
+			return s.Transaction.ExecContext(ctx, SQL)
+		})
 		err = s.end(err)
 	}
 
@@ -81,7 +84,7 @@ func (s *Session) Exec(ctx context.Context, data interface{}, db *sql.DB, tableN
 	return result, err
 }
 
-func (s *Session) begin(ctx context.Context, db *sql.DB, options []option.Option) error {
+func (s *Session) begin(ctx context.Context, db sqlx.Executor, options []option.Option) error {
 	var err error
 	s.Transaction, err = io.TransactionFor(ctx, s.dialect, db, options)
 	if err != nil {
@@ -325,6 +325,7 @@ FROM V_MONITOR.SESSIONS`,
 		Product:                 vertica,
 		Placeholder:             ":", // "@" or ":" for backward compatibility
 		Transactional:           true,
+		NoRowValueIn:            true,
 		Insert:                  dialect.InsertWithMultiValues,
 		Upsert:                  dialect.UpsertTypeMergeInto,
 		Load:                    dialect.LoadTypeLocalData,
@@ -333,5 +334,9 @@ FROM V_MONITOR.SESSIONS`,
 		CanLastInsertID:         true, // LAST_INSERT_ID works only with AUTO_INCREMENT and IDENTITY columns
 		AutoincrementFunc:       "nextval",
 		DefaultPresetIDStrategy: dialect.PresetIDStrategyUndefined,
+		Savepoint:               "SAVEPOINT",
+		RollbackToSavepoint:     "ROLLBACK TO SAVEPOINT",
+		ReleaseSavepoint:        "RELEASE SAVEPOINT",
+		ErrorNormalizer:         normalizeError,
 	})
 }
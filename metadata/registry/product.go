@@ -1,7 +1,7 @@
 package registry
 
 import (
-	"database/sql"
+	"github.com/viant/sqlx"
 	"github.com/viant/sqlx/metadata/database"
 	"reflect"
 	"strings"
@@ -9,9 +9,16 @@ import (
 
 const defaultProductName = "ansi"
 
-//MatchProduct matches product with sql driver
-func MatchProduct(db *sql.DB) *database.Product {
-	driverTypeName := reflect.TypeOf(db.Driver()).Elem().String()
+//MatchProduct matches product with sql driver, nil is returned if executor driver can not be inferred
+func MatchProduct(db sqlx.Executor) *database.Product {
+	driverType := sqlx.DriverType(db)
+	if driverType == nil {
+		return nil
+	}
+	for driverType.Kind() == reflect.Ptr {
+		driverType = driverType.Elem()
+	}
+	driverTypeName := driverType.String()
 	driverTypePair := strings.Split(driverTypeName, ".")
 	driverPkg := driverTypePair[0]
 	driverName := driverTypePair[1]
@@ -4,8 +4,11 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
+	"github.com/viant/sqlx"
+	"github.com/viant/sqlx/io/read/cache/ast"
 	"github.com/viant/sqlx/metadata/database"
 	"github.com/viant/sqlx/metadata/info"
+	"github.com/viant/sqlx/metadata/info/placeholder"
 	"github.com/viant/sqlx/metadata/product/ansi"
 	"github.com/viant/sqlx/metadata/registry"
 	"github.com/viant/sqlx/option"
@@ -19,12 +22,12 @@ type (
 		recent
 	}
 	recent struct {
-		db      *sql.DB
+		db      sqlx.Executor
 		product *database.Product
 	}
 )
 
-func (s *Service) setService(db *sql.DB, product *database.Product) {
+func (s *Service) setService(db sqlx.Executor, product *database.Product) {
 	if s.recent.db == nil || s.recent.db != db || s.recent.product == nil || s.recent.product != product || s.dialect == nil {
 		s.recent.db = db
 		s.recent.product = product
@@ -33,7 +36,7 @@ func (s *Service) setService(db *sql.DB, product *database.Product) {
 }
 
 //DetectProduct detect product for supplied *sql.DB
-func (s *Service) DetectProduct(ctx context.Context, db *sql.DB) (*database.Product, error) {
+func (s *Service) DetectProduct(ctx context.Context, db sqlx.Executor) (*database.Product, error) {
 	if product := s.recent.match(db); product != nil {
 		s.dialect = registry.LookupDialect(product)
 		return product, nil
@@ -51,13 +54,15 @@ func (s *Service) DetectProduct(ctx context.Context, db *sql.DB) (*database.Prod
 }
 
 //Execute execute the metadata kind corresponding SQL
-func (s *Service) Execute(ctx context.Context, db *sql.DB, kind info.Kind, options ...option.Option) (sql.Result, error) {
+func (s *Service) Execute(ctx context.Context, db sqlx.Executor, kind info.Kind, options ...option.Option) (sql.Result, error) {
 	var err error
 	product := option.Options(options).Product()
 	if product == nil {
 		if product, err = s.DetectProduct(ctx, db); err != nil {
 			return nil, err
 		}
+	} else {
+		s.setService(db, product)
 	}
 	queries := registry.Lookup(product.Name, kind)
 	if len(queries) == 0 {
@@ -72,7 +77,7 @@ func (s *Service) Execute(ctx context.Context, db *sql.DB, kind info.Kind, optio
 }
 
 //Info execute the metadata kind corresponding Query, result are passed to sink
-func (s *Service) Info(ctx context.Context, db *sql.DB, kind info.Kind, sink Sink, options ...option.Option) error {
+func (s *Service) Info(ctx context.Context, db sqlx.Executor, kind info.Kind, sink Sink, options ...option.Option) error {
 	var err error
 
 	product := option.Options.Product(options)
@@ -109,7 +114,7 @@ func (s *Service) Info(ctx context.Context, db *sql.DB, kind info.Kind, sink Sin
 	return nil
 }
 
-func (s *Service) runHandler(ctx context.Context, db *sql.DB, handlers []info.Handler, sink Sink, options option.Options) (shallReturn bool, err error) {
+func (s *Service) runHandler(ctx context.Context, db sqlx.Executor, handlers []info.Handler, sink Sink, options option.Options) (shallReturn bool, err error) {
 	if len(handlers) == 0 {
 		return false, nil
 	}
@@ -129,10 +134,12 @@ func (s *Service) runHandler(ctx context.Context, db *sql.DB, handlers []info.Ha
 	return false, err
 }
 
-func (s *Service) matchProduct(ctx context.Context, db *sql.DB) (*database.Product, error) {
+//matchProduct matches executor product, returns error if product can not be inferred from executor driver (i.e. *sql.Tx),
+//in that case product has to be supplied with *info.Dialect or *database.Product option
+func (s *Service) matchProduct(ctx context.Context, db sqlx.Executor) (*database.Product, error) {
 	product := registry.MatchProduct(db)
 	if product == nil {
-		return &ansi.ANSI, nil
+		return nil, fmt.Errorf("failed to infer %T driver, use *info.Dialect or *database.Product option", db)
 	}
 	if product.Name == ansi.ANSI.Name {
 		return product, nil
@@ -140,7 +147,7 @@ func (s *Service) matchProduct(ctx context.Context, db *sql.DB) (*database.Produ
 	return s.matchVersion(ctx, db, product)
 }
 
-func (s *Service) matchVersion(ctx context.Context, db *sql.DB, product *database.Product) (*database.Product, error) {
+func (s *Service) matchVersion(ctx context.Context, db sqlx.Executor, product *database.Product) (*database.Product, error) {
 	versionQueries := registry.Lookup(product.Name, info.KindVersion)
 	if len(versionQueries) == 0 {
 		return product, nil
@@ -165,64 +172,93 @@ func (s *Service) matchVersion(ctx context.Context, db *sql.DB, product *databas
 	return nil, err
 }
 
-func (s *Service) executeQuery(ctx context.Context, db *sql.DB, query *info.Query, options ...option.Option) (sql.Result, error) {
+func (s *Service) executeQuery(ctx context.Context, db sqlx.Executor, query *info.Query, options ...option.Option) (sql.Result, error) {
 	tx := option.Options.Tx(options)
 
 	args := &option.Args{}
 	option.Assign(options, &args)
-	SQL, params, err := prepareSQL(query, s.dialect.PlaceholderGetter(), args)
-
+	SQL, params, err := prepareStatement(query, args, options)
+	if err != nil {
+		return nil, err
+	}
+	statements, err := s.statements(SQL, params)
 	if err != nil {
 		return nil, err
 	}
+	result := &chunkedResult{}
+	for _, aStatement := range statements {
+		stmtResult, err := s.execStatement(ctx, db, tx, aStatement, options)
+		if err != nil {
+			return nil, err
+		}
+		if len(statements) == 1 {
+			return stmtResult, nil
+		}
+		if err = result.add(stmtResult); err != nil {
+			return nil, err
+		}
+	}
+	return result, nil
+}
+
+func (s *Service) execStatement(ctx context.Context, db sqlx.Executor, tx *sql.Tx, aStatement *statement, options []option.Option) (sql.Result, error) {
 	var stmt *sql.Stmt
+	var err error
 	if tx != nil {
-		stmt, err = tx.PrepareContext(ctx, SQL)
+		stmt, err = tx.PrepareContext(ctx, aStatement.SQL)
 	} else {
-		stmt, err = db.PrepareContext(ctx, SQL)
+		stmt, err = db.PrepareContext(ctx, aStatement.SQL)
 	}
 
 	if err != nil {
 		return nil, err
 	}
 	defer stmt.Close()
-	return stmt.ExecContext(ctx, params...)
+	return sqlx.InterceptExec(ctx, option.Options(options).Interceptor(), aStatement.SQL, aStatement.args, func(ctx context.Context) (sql.Result, error) {
+		return stmt.ExecContext(ctx, aStatement.args...)
+	})
 }
 
-func (s *Service) runQuery(ctx context.Context, db *sql.DB, query *info.Query, sink Sink, options ...option.Option) error {
+func (s *Service) runQuery(ctx context.Context, db sqlx.Executor, query *info.Query, sink Sink, options ...option.Option) error {
 	tx := option.Options.Tx(options)
 	args := &option.Args{}
 	option.Assign(options, &args)
-	// placeholder can be different i.e. @p for sqlserver
-	placeholderGetter := func() string {
-		return "?"
-	}
-	if s.dialect != nil {
-		placeholderGetter = s.dialect.PlaceholderGetter()
+	SQL, params, err := prepareStatement(query, args, options)
+	if err != nil {
+		return err
 	}
-
-	SQL, params, err := prepareSQL(query, placeholderGetter, args)
+	statements, err := s.statements(SQL, params)
 	if err != nil {
 		return err
 	}
+	if len(statements) > 1 && !isSliceSink(sink) {
+		return fmt.Errorf("failed to run query: %v, slice arguments exceed %v parameters limit, chunks can only be merged into slice, but had %T", SQL, s.dialect.MaxParameters, sink)
+	}
+	for _, aStatement := range statements {
+		if err = s.queryStatement(ctx, db, tx, aStatement, sink, options); err != nil {
+			return err
+		}
+	}
+	return nil
+}
 
+//queryStatement runs statement, rows are fetched into sink, slice sink is appended
+func (s *Service) queryStatement(ctx context.Context, db sqlx.Executor, tx *sql.Tx, aStatement *statement, sink Sink, options []option.Option) error {
 	var stmt *sql.Stmt
+	var err error
 	if tx != nil {
-		stmt, err = tx.PrepareContext(ctx, SQL)
+		stmt, err = tx.PrepareContext(ctx, aStatement.SQL)
 	} else {
-		stmt, err = db.PrepareContext(ctx, SQL)
+		stmt, err = db.PrepareContext(ctx, aStatement.SQL)
 	}
 	if err != nil {
 		return err
 	}
 	defer stmt.Close()
 
-	var rows *sql.Rows
-	if len(params) > 0 {
-		rows, err = stmt.Query(params...)
-	} else {
-		rows, err = stmt.Query()
-	}
+	rows, err := sqlx.InterceptQuery(ctx, option.Options(options).Interceptor(), aStatement.SQL, aStatement.args, func(ctx context.Context) (*sql.Rows, error) {
+		return stmt.QueryContext(ctx, aStatement.args...)
+	})
 	if err != nil {
 		return err
 	}
@@ -237,7 +273,99 @@ func (s *Service) runQuery(ctx context.Context, db *sql.DB, query *info.Query, s
 	}
 }
 
-func prepareSQL(query *info.Query, placeholderGetter func() string, argsOpt *option.Args) (string, []interface{}, error) {
+//prepareStatement returns query SQL with '?' placeholders and values of criteria and, if options define named arguments, :name and @name parameters
+func prepareStatement(query *info.Query, args *option.Args, options []option.Option) (string, []interface{}, error) {
+	SQL, criteriaValues, err := prepareSQL(query, args)
+	if err != nil {
+		return "", nil, err
+	}
+	namedArgs := option.Options(options).NamedArgs()
+	if namedArgs == nil || !hasNamed(SQL) {
+		return SQL, criteriaValues, nil
+	}
+	return bindNamed(SQL, criteriaValues, namedArgs.Source)
+}
+
+//statements returns statements with slice values expanded to placeholders list and dialect placeholders,
+//values exceeding dialect MaxParameters are split into chunks of the largest slice if query results can be merged, see ast.IsChunkable
+func (s *Service) statements(SQL string, values []interface{}) ([]*statement, error) {
+	if len(values) == 0 {
+		return []*statement{{SQL: SQL}}, nil
+	}
+	chunks := [][]interface{}{values}
+	if s.dialect != nil && s.dialect.MaxParameters > 0 && placeholder.HasSlice(values) {
+		if chunks = placeholder.Chunk(values, s.dialect.MaxParameters); len(chunks) > 1 {
+			positions := placeholder.Positions(SQL)
+			if len(positions) != len(values) || !ast.IsChunkable(SQL, positions[placeholder.Largest(values)]) {
+				return nil, fmt.Errorf("failed to run query: %v, slice arguments exceed %v parameters limit, only column IN (?) criterion of query without ordering, limit, grouping or aggregates can be chunked", SQL, s.dialect.MaxParameters)
+			}
+		}
+	}
+	var result = make([]*statement, 0, len(chunks))
+	for _, chunk := range chunks {
+		expanded, args, err := placeholder.Expand(SQL, chunk)
+		if err != nil {
+			return nil, err
+		}
+		if s.dialect != nil { //placeholder can be different i.e. @p for sqlserver
+			expanded = placeholder.Replace(expanded, s.dialect.PlaceholderGetter())
+		}
+		result = append(result, &statement{SQL: expanded, args: args})
+	}
+	return result, nil
+}
+
+//hasNamed returns true if SQL uses :name or @name parameters
+func hasNamed(SQL string) bool {
+	for _, parameter := range placeholder.Parse(SQL) {
+		if parameter.Name != "" {
+			return true
+		}
+	}
+	return false
+}
+
+//bindNamed replaces :name and @name parameters with '?' placeholders, values are bound in placeholders order:
+//named parameters from source, '?' criteria placeholders from criteria values
+func bindNamed(SQL string, criteriaValues []interface{}, source interface{}) (string, []interface{}, error) {
+	parameters := placeholder.Parse(SQL)
+	var names []string
+	for _, parameter := range parameters {
+		if parameter.Name != "" {
+			names = append(names, parameter.Name)
+		}
+	}
+	namedValues, err := placeholder.Bind(names, source)
+	if err != nil {
+		return "", nil, err
+	}
+	var values = make([]interface{}, 0, len(parameters))
+	var builder strings.Builder
+	offset, named, criteria := 0, 0, 0
+	for _, parameter := range parameters {
+		builder.WriteString(SQL[offset:parameter.Offset])
+		builder.WriteString(placeholder.Default)
+		offset = parameter.Offset + parameter.Size
+		if parameter.Name != "" {
+			values = append(values, namedValues[named])
+			named++
+			continue
+		}
+		if criteria >= len(criteriaValues) {
+			return "", nil, fmt.Errorf("failed to bind %v: expected %v '?' placeholders, but had more", SQL, len(criteriaValues))
+		}
+		values = append(values, criteriaValues[criteria])
+		criteria++
+	}
+	if criteria != len(criteriaValues) {
+		return "", nil, fmt.Errorf("failed to bind %v: expected %v '?' placeholders, but had %v", SQL, len(criteriaValues), criteria)
+	}
+	builder.WriteString(SQL[offset:])
+	return builder.String(), values, nil
+}
+
+//prepareSQL returns query SQL with criteria '?' placeholders, slice value criterion uses IN (?) predicate
+func prepareSQL(query *info.Query, argsOpt *option.Args) (string, []interface{}, error) {
 	args := argsOpt.Unwrap()
 	var filterArgs = make([]interface{}, 0)
 	if len(args) == 0 && query.Criteria.Supported() == 0 {
@@ -286,7 +414,11 @@ func prepareSQL(query *info.Query, placeholderGetter func() string, argsOpt *opt
 				if args[i] == "" {
 					continue
 				}
-				criteriaValues = append(criteriaValues, column+"="+placeholderGetter())
+				if placeholder.IsSlice(args[i]) {
+					criteriaValues = append(criteriaValues, column+" IN ("+placeholder.Default+")")
+				} else {
+					criteriaValues = append(criteriaValues, column+"="+placeholder.Default)
+				}
 				filterArgs = append(filterArgs, args[i])
 			}
 		}
@@ -308,7 +440,7 @@ func prepareSQL(query *info.Query, placeholderGetter func() string, argsOpt *opt
 }
 
 //match checks if the db matched previously match product
-func (r *recent) match(db *sql.DB) *database.Product {
+func (r *recent) match(db sqlx.Executor) *database.Product {
 	if r.db == db {
 		return r.product
 	}
@@ -12,7 +12,7 @@ import (
 	"github.com/viant/sqlx/metadata/database"
 	"github.com/viant/sqlx/metadata/info"
 	_ "github.com/viant/sqlx/metadata/product/mysql"
-	_ "github.com/viant/sqlx/metadata/product/sqlite"
+	"github.com/viant/sqlx/metadata/product/sqlite"
 	"github.com/viant/sqlx/metadata/sink"
 	"github.com/viant/sqlx/option"
 	"os"
@@ -74,6 +74,48 @@ func TestAbstractService_DetectVersion(t *testing.T) {
 	}
 }
 
+func TestAbstractService_Info_tx(t *testing.T) {
+	var ctx = context.Background()
+	db, err := prepareDb(&prepare{driver: "sqlite3", dsn: "/tmp/mydb.db"})
+	if !assert.Nil(t, err) {
+		return
+	}
+	defer db.Close()
+	tx, err := db.BeginTx(ctx, nil)
+	if !assert.Nil(t, err) {
+		return
+	}
+	defer tx.Rollback()
+
+	var testCases = []struct {
+		description string
+		options     []option.Option
+		expectErr   bool
+	}{
+		{
+			description: "product can not be inferred from transaction",
+			expectErr:   true,
+		},
+		{
+			description: "product option",
+			options:     []option.Option{sqlite.SQLite3()},
+		},
+	}
+	for _, testCase := range testCases {
+		meta := metadata.New()
+		var version string
+		err = meta.Info(ctx, tx, info.KindVersion, &version, testCase.options...)
+		if testCase.expectErr {
+			assert.NotNil(t, err, testCase.description)
+			continue
+		}
+		if !assert.Nil(t, err, testCase.description) {
+			continue
+		}
+		assert.Contains(t, version, "SQLite", testCase.description)
+	}
+}
+
 func TestAbstractService_Info(t *testing.T) {
 	//	os.Setenv("MYSQL_TEST_HOST", "127.0.0.1:3307")
 	mySQLTestHost := os.Getenv("MYSQL_TEST_HOST")
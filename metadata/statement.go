@@ -0,0 +1,49 @@
+package metadata
+
+import (
+	"database/sql"
+	"reflect"
+)
+
+type (
+	//statement represents expanded query statement
+	statement struct {
+		SQL  string
+		args []interface{}
+	}
+
+	//chunkedResult represents result of statements run for slice argument chunks
+	chunkedResult struct {
+		rowsAffected   int64
+		lastInsertedID int64
+	}
+)
+
+//add adds statement result
+func (r *chunkedResult) add(result sql.Result) error {
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	r.rowsAffected += rowsAffected
+	if lastInsertedID, err := result.LastInsertId(); err == nil {
+		r.lastInsertedID = lastInsertedID
+	}
+	return nil
+}
+
+//LastInsertId returns the last statement inserted id
+func (r *chunkedResult) LastInsertId() (int64, error) {
+	return r.lastInsertedID, nil
+}
+
+//RowsAffected returns all statements rows affected
+func (r *chunkedResult) RowsAffected() (int64, error) {
+	return r.rowsAffected, nil
+}
+
+//isSliceSink returns true if sink is a slice pointer, slice sink is appended with fetched rows
+func isSliceSink(sink Sink) bool {
+	sinkType := reflect.TypeOf(sink)
+	return sinkType != nil && sinkType.Kind() == reflect.Ptr && sinkType.Elem().Kind() == reflect.Slice
+}
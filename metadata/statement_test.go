@@ -0,0 +1,128 @@
+package metadata
+
+import (
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"github.com/viant/sqlx/metadata/database"
+	"github.com/viant/sqlx/metadata/info"
+	"github.com/viant/sqlx/option"
+	"testing"
+)
+
+type numberedGenerator struct{}
+
+func (g *numberedGenerator) Resolver() func() string {
+	index := 0
+	return func() string {
+		index++
+		return fmt.Sprintf("$%v", index)
+	}
+}
+
+func (g *numberedGenerator) Len(start, numOfPlaceholders int) int {
+	return 0
+}
+
+func TestService_statements(t *testing.T) {
+	var testCases = []struct {
+		description   string
+		SQL           string
+		args          []interface{}
+		options       []option.Option
+		maxParameters int
+		expectSQL     []string
+		expectArgs    [][]interface{}
+		expectErr     bool
+	}{
+		{
+			description: "criteria preceding named parameters",
+			SQL:         "SELECT * FROM (SELECT * FROM tables s $WHERE) t WHERE t.kind = :kind AND t.id IN (:ids)",
+			args:        []interface{}{"", "public", "foo"},
+			options:     []option.Option{option.NewNamedArgs(map[string]interface{}{"kind": "BASE", "ids": []int{1, 2}})},
+			expectSQL:   []string{"SELECT * FROM (SELECT * FROM tables s  WHERE s.schema=$1 AND s.name=$2 ) t WHERE t.kind = $3 AND t.id IN ($4, $5)"},
+			expectArgs:  [][]interface{}{{"public", "foo", "BASE", 1, 2}},
+		},
+		{
+			description: "named parameters preceding criteria",
+			SQL:         "SELECT * FROM tables s WHERE s.kind = @kind",
+			args:        []interface{}{"", "public", "foo"},
+			options:     []option.Option{option.NewNamedArgs(map[string]interface{}{"kind": "BASE"})},
+			expectSQL:   []string{"SELECT * FROM tables s WHERE s.kind = $1 AND s.schema=$2 AND s.name=$3"},
+			expectArgs:  [][]interface{}{{"BASE", "public", "foo"}},
+		},
+		{
+			description: "without named arguments",
+			SQL:         "SELECT * FROM tables s",
+			args:        []interface{}{"", "public", "foo"},
+			expectSQL:   []string{"SELECT * FROM tables s WHERE s.schema=$1 AND s.name=$2"},
+			expectArgs:  [][]interface{}{{"public", "foo"}},
+		},
+		{
+			description: "positional slice criterion",
+			SQL:         "SELECT * FROM tables s",
+			args:        []interface{}{"", "public", []string{"foo", "bar"}},
+			expectSQL:   []string{"SELECT * FROM tables s WHERE s.schema=$1 AND s.name IN ($2, $3)"},
+			expectArgs:  [][]interface{}{{"public", "foo", "bar"}},
+		},
+		{
+			description:   "positional slice criterion chunks",
+			SQL:           "SELECT * FROM tables s",
+			args:          []interface{}{"", "public", []string{"t1", "t2", "t3"}},
+			maxParameters: 3,
+			expectSQL: []string{
+				"SELECT * FROM tables s WHERE s.schema=$1 AND s.name IN ($2, $3)",
+				"SELECT * FROM tables s WHERE s.schema=$1 AND s.name IN ($2)",
+			},
+			expectArgs: [][]interface{}{{"public", "t1", "t2"}, {"public", "t3"}},
+		},
+		{
+			description:   "ordered query can not be chunked",
+			SQL:           "SELECT * FROM tables s $WHERE ORDER BY s.name",
+			args:          []interface{}{"", "public", []string{"t1", "t2", "t3"}},
+			maxParameters: 3,
+			expectErr:     true,
+		},
+		{
+			description: "empty slice criterion",
+			SQL:         "SELECT * FROM tables s",
+			args:        []interface{}{"", "public", []string{}},
+			expectErr:   true,
+		},
+		{
+			description: "missing named parameter",
+			SQL:         "SELECT * FROM tables s WHERE s.kind = :kind",
+			args:        []interface{}{"", "public", "foo"},
+			options:     []option.Option{option.NewNamedArgs(map[string]interface{}{})},
+			expectErr:   true,
+		},
+	}
+
+	for _, testCase := range testCases {
+		query := info.NewQuery(info.KindTable, testCase.SQL, database.Product{},
+			info.NewCriterion(info.Catalog, ""),
+			info.NewCriterion(info.Schema, "s.schema"),
+			info.NewCriterion(info.Table, "s.name"),
+		)
+		service := &Service{dialect: &info.Dialect{PlaceholderResolver: &numberedGenerator{}, MaxParameters: testCase.maxParameters}}
+		SQL, args, err := prepareStatement(query, option.NewArgs(testCase.args...), testCase.options)
+		var statements []*statement
+		if err == nil {
+			statements, err = service.statements(SQL, args)
+		}
+		if testCase.expectErr {
+			assert.NotNil(t, err, testCase.description)
+			continue
+		}
+		if !assert.Nil(t, err, testCase.description) {
+			continue
+		}
+		var actualSQL []string
+		var actualArgs [][]interface{}
+		for _, aStatement := range statements {
+			actualSQL = append(actualSQL, aStatement.SQL)
+			actualArgs = append(actualArgs, aStatement.args)
+		}
+		assert.Equal(t, testCase.expectSQL, actualSQL, testCase.description)
+		assert.Equal(t, testCase.expectArgs, actualArgs, testCase.description)
+	}
+}
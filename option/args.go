@@ -37,3 +37,23 @@ func (a *Args) StringN(n int) ([]string, error) {
 func NewArgs(args ...interface{}) *Args {
 	return &Args{args}
 }
+
+//NamedArgs represents :name and @name parameters source, map with string keys, struct or struct pointer
+type NamedArgs struct {
+	Source interface{}
+}
+
+//NewNamedArgs creates named parameters option
+func NewNamedArgs(source interface{}) *NamedArgs {
+	return &NamedArgs{Source: source}
+}
+
+//NamedArgs returns named parameters option or nil
+func (o Options) NamedArgs() *NamedArgs {
+	for _, candidate := range o {
+		if result, ok := candidate.(*NamedArgs); ok {
+			return result
+		}
+	}
+	return nil
+}
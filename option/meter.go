@@ -0,0 +1,25 @@
+package option
+
+import "context"
+
+type (
+	//Meter represents metrics option, see io/telemetry/otel for OpenTelemetry adapter
+	Meter interface {
+		//Counter returns counter adding positive or negative deltas
+		Counter(name, description, unit string) (Counter, error)
+		//Histogram returns histogram recording values distribution
+		Histogram(name, description, unit string) (Histogram, error)
+	}
+
+	//Counter represents metrics counter
+	Counter interface {
+		//Add adds delta to counter
+		Add(ctx context.Context, delta int64, attributes ...Attribute)
+	}
+
+	//Histogram represents metrics histogram
+	Histogram interface {
+		//Record records value
+		Record(ctx context.Context, value float64, attributes ...Attribute)
+	}
+)
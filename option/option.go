@@ -22,6 +22,9 @@ type Identity string
 //IdentityOnly  represents identity (pk) only option
 type IdentityOnly bool
 
+//SkipSoftDeleted represents option to ignore soft deleted rows
+type SkipSoftDeleted bool
+
 //Option represents generic option
 type Option interface{}
 
@@ -118,6 +121,17 @@ func (o Options) IdentityOnly() bool {
 	return false
 }
 
+//SkipSoftDeleted returns skip soft deleted option value or false
+func (o Options) SkipSoftDeleted() bool {
+	for _, candidate := range o {
+		switch actual := candidate.(type) {
+		case SkipSoftDeleted:
+			return bool(actual)
+		}
+	}
+	return false
+}
+
 //SetMarker returns SetMarker option value or false
 func (o Options) SetMarker() *SetMarker {
 	if len(o) == 0 {
@@ -291,6 +305,17 @@ func (o Options) Args() *Args {
 	return nil
 }
 
+//Interceptor returns interceptors chain or nil
+func (o Options) Interceptor() sqlx.Interceptor {
+	var interceptors []sqlx.Interceptor
+	for _, candidate := range o {
+		if interceptor, ok := candidate.(sqlx.Interceptor); ok {
+			interceptors = append(interceptors, interceptor)
+		}
+	}
+	return sqlx.Chain(interceptors...)
+}
+
 // RecordCount represents record count option
 type RecordCount int64
 
@@ -0,0 +1,43 @@
+package option
+
+import (
+	"math/rand"
+	"time"
+)
+
+//Retry represents transient errors (deadlock, serialization failure) retry policy
+type Retry struct {
+	MaxAttempts int           //max attempts, including the first one
+	Delay       time.Duration //initial backoff delay, doubled with each attempt
+	MaxDelay    time.Duration //max backoff delay, unlimited if zero
+}
+
+//Backoff returns delay before next attempt, half of exponential delay is randomized (jitter)
+func (r *Retry) Backoff(attempt int) time.Duration {
+	delay := r.Delay
+	for i := 1; i < attempt && (r.MaxDelay == 0 || delay < r.MaxDelay); i++ {
+		delay *= 2
+	}
+	if r.MaxDelay > 0 && delay > r.MaxDelay {
+		delay = r.MaxDelay
+	}
+	if half := int64(delay / 2); half > 0 {
+		return time.Duration(half + rand.Int63n(half+1))
+	}
+	return delay
+}
+
+//NewRetry creates a retry policy
+func NewRetry(maxAttempts int, delay, maxDelay time.Duration) *Retry {
+	return &Retry{MaxAttempts: maxAttempts, Delay: delay, MaxDelay: maxDelay}
+}
+
+//Retry returns retry policy or nil
+func (o Options) Retry() *Retry {
+	for _, candidate := range o {
+		if retry, ok := candidate.(*Retry); ok {
+			return retry
+		}
+	}
+	return nil
+}
@@ -0,0 +1,35 @@
+package option
+
+import "context"
+
+type (
+	//Tracer represents tracing option, see io/telemetry/otel for OpenTelemetry adapter
+	Tracer interface {
+		//Start starts a span, returned context carries the span
+		Start(ctx context.Context, name string, attributes ...Attribute) (context.Context, Span)
+	}
+
+	//Span represents traced operation
+	Span interface {
+		//SetAttributes sets span attributes
+		SetAttributes(attributes ...Attribute)
+		//End records error if any and ends the span
+		End(err error)
+	}
+
+	//Attribute represents span attribute
+	Attribute struct {
+		Key   string
+		Value interface{}
+	}
+)
+
+//Tracer returns tracer or nil
+func (o Options) Tracer() Tracer {
+	for _, candidate := range o {
+		if result, ok := candidate.(Tracer); ok {
+			return result
+		}
+	}
+	return nil
+}